@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// securitySectionTitle is the heading of the section security advisories are listed in
+const securitySectionTitle = "### セキュリティ"
+
+// advisoryIDPattern matches CVE IDs and GitHub security advisory (GHSA) IDs
+var advisoryIDPattern = regexp.MustCompile(`(?i)\b(CVE-\d{4}-\d{4,}|GHSA(?:-[23456789cfghjmpqrvwx]{4}){3})\b`)
+
+// advisory is a security advisory referenced by a commit
+type advisory struct {
+	GHSAID   string `json:"ghsa_id"`
+	CVEID    string `json:"cve_id"`
+	Summary  string `json:"summary"`
+	Severity string `json:"severity"`
+	URL      string `json:"html_url"`
+}
+
+// severityRanks orders advisories from the most severe; unknown severities come last
+var severityRanks = map[string]int{"critical": 0, "high": 1, "medium": 2, "moderate": 2, "low": 3}
+
+// severityLabels are the labels shown for advisory severities
+var severityLabels = map[string]string{"critical": "重大", "high": "高", "medium": "中", "moderate": "中", "low": "低"}
+
+// fetchAdvisory looks up an advisory in the GitHub Advisory Database; replaced in tests
+var fetchAdvisory = func(id string) (advisory, error) {
+	endpoint := "advisories/" + id
+	if strings.HasPrefix(id, "CVE-") {
+		endpoint = "advisories?cve_id=" + id
+	}
+	output, err := ghCommand("", "api", endpoint).Output()
+	if err != nil {
+		return advisory{}, fmt.Errorf("failed to fetch advisory %s (is gh installed and authenticated?): %w", id, err)
+	}
+
+	var found advisory
+	if strings.HasPrefix(id, "CVE-") {
+		var list []advisory
+		if err := json.Unmarshal(output, &list); err != nil {
+			return advisory{}, fmt.Errorf("failed to parse advisory %s: %w", id, err)
+		}
+		if len(list) == 0 {
+			return advisory{}, fmt.Errorf("advisory %s was not found", id)
+		}
+		found = list[0]
+	} else if err := json.Unmarshal(output, &found); err != nil {
+		return advisory{}, fmt.Errorf("failed to parse advisory %s: %w", id, err)
+	}
+	return found, nil
+}
+
+// advisoryIDs returns the distinct advisory IDs in text, uppercased and in order of appearance
+func advisoryIDs(text string) []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, m := range advisoryIDPattern.FindAllString(text, -1) {
+		id := strings.ToUpper(m)
+		if strings.HasPrefix(id, "GHSA") {
+			id = "GHSA" + strings.ToLower(m[4:])
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// resolveAdvisories fetches the referenced advisories; an advisory that cannot be fetched is still
+// listed with its ID, and a CVE and the GHSA of the same advisory are listed once
+func resolveAdvisories(ids []string) []advisory {
+	var advisories []advisory
+	listed := map[string]bool{}
+	for _, id := range ids {
+		if listed[id] {
+			continue
+		}
+		a, err := fetchAdvisory(id)
+		if err != nil {
+			ui.Printf("⚠️  Warning: %v\n", err)
+			a = advisory{}
+			if strings.HasPrefix(id, "CVE-") {
+				a.CVEID = id
+			} else {
+				a.GHSAID = id
+			}
+		}
+		if a.URL == "" {
+			a.URL = advisoryURL(a)
+		}
+		listed[a.GHSAID], listed[a.CVEID] = true, true
+		advisories = append(advisories, a)
+	}
+	sort.SliceStable(advisories, func(i, j int) bool {
+		return severityRank(advisories[i].Severity) < severityRank(advisories[j].Severity)
+	})
+	return advisories
+}
+
+// advisoryURL returns the public page of an advisory without a fetched URL
+func advisoryURL(a advisory) string {
+	if a.GHSAID != "" {
+		return "https://github.com/advisories/" + a.GHSAID
+	}
+	return "https://nvd.nist.gov/vuln/detail/" + a.CVEID
+}
+
+// severityRank returns the sort rank of a severity
+func severityRank(severity string) int {
+	if rank, ok := severityRanks[strings.ToLower(severity)]; ok {
+		return rank
+	}
+	return len(severityRanks)
+}
+
+// advisoryBullet formats an advisory as a bullet with its link and severity
+func advisoryBullet(a advisory) string {
+	id, other := a.GHSAID, a.CVEID
+	if id == "" {
+		id, other = a.CVEID, ""
+	}
+	summary := strings.TrimSpace(a.Summary)
+	if summary == "" {
+		summary = "セキュリティ上の問題を修正"
+	}
+	refs := fmt.Sprintf("[%s](%s)", id, a.URL)
+	if other != "" {
+		refs += "、" + other
+	}
+	if label, ok := severityLabels[strings.ToLower(a.Severity)]; ok {
+		return fmt.Sprintf("- 【重大度: %s】%s（%s）", label, summary, refs)
+	}
+	return fmt.Sprintf("- %s（%s）", summary, refs)
+}
+
+// mentionsAdvisory reports whether a bullet refers to one of the IDs of a
+func mentionsAdvisory(bullet string, a advisory) bool {
+	upper := strings.ToUpper(bullet)
+	return (a.GHSAID != "" && strings.Contains(upper, strings.ToUpper(a.GHSAID))) ||
+		(a.CVEID != "" && strings.Contains(upper, a.CVEID))
+}
+
+// addSecurityAdvisories lists advisories in the security section of entry, replacing bullets the
+// AI wrote about them; the section is created at the end when the entry has none
+func addSecurityAdvisories(entry string, advisories []advisory) string {
+	if len(advisories) == 0 {
+		return entry
+	}
+	bullets := topLevelBullets(entry)
+	var drop []duplicateBullet
+	for i, bullet := range bullets {
+		for _, a := range advisories {
+			if mentionsAdvisory(bullet, a) {
+				drop = append(drop, duplicateBullet{Line: i})
+				break
+			}
+		}
+	}
+	if len(drop) > 0 {
+		entry = dropBullets(entry, drop)
+	}
+
+	added := make([]string, len(advisories))
+	for i, a := range advisories {
+		added[i] = advisoryBullet(a)
+	}
+	lines := strings.Split(strings.TrimRight(entry, "\n"), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != securitySectionTitle {
+			continue
+		}
+		end := i + 1
+		for end < len(lines) && !htmlHeadingPattern.MatchString(lines[end]) {
+			end++
+		}
+		for end > i+1 && strings.TrimSpace(lines[end-1]) == "" {
+			end--
+		}
+		if end == i+1 {
+			added = append([]string{""}, added...)
+		}
+		result := append(append(lines[:end:end], added...), lines[end:]...)
+		return strings.Join(result, "\n")
+	}
+	return strings.Join(lines, "\n") + "\n\n" + securitySectionTitle + "\n\n" + strings.Join(added, "\n")
+}
+
+// appendSecurityAdvisories lists the advisories referenced by the messages of the commits in from..to
+// that touch the selected module
+func appendSecurityAdvisories(entry, from, to string) string {
+	if !genOpts.SecurityAdvisories {
+		return entry
+	}
+	revRange := to
+	if from != "" && from != gitRefHEAD {
+		revRange = from + ".." + to
+	}
+	messages, err := gitOutput(append([]string{"log", "--format=%B", revRange}, modulePathspec()...)...)
+	if err != nil {
+		ui.Printf("⚠️  Warning: Failed to read commit messages for advisories: %v\n", err)
+		return entry
+	}
+	ids := advisoryIDs(messages)
+	if len(ids) == 0 {
+		return entry
+	}
+	return addSecurityAdvisories(entry, resolveAdvisories(ids))
+}
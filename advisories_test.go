@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAdvisoryIDs(t *testing.T) {
+	text := "fix: sanitize paths (cve-2024-12345)\n\nSee GHSA-C2QF-rxjj-qqgw and CVE-2024-12345."
+	want := []string{"CVE-2024-12345", "GHSA-c2qf-rxjj-qqgw"}
+	if got := advisoryIDs(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("advisoryIDs() = %q, want %q", got, want)
+	}
+}
+
+func TestAddSecurityAdvisories(t *testing.T) {
+	original := fetchAdvisory
+	defer func() { fetchAdvisory = original }()
+	fetchAdvisory = func(id string) (advisory, error) {
+		switch id {
+		case "CVE-2024-1111":
+			return advisory{GHSAID: "GHSA-4xqv-9cwh-jxcj", CVEID: "CVE-2024-1111", Summary: "Path traversal in upload", Severity: "high", URL: "https://github.com/advisories/GHSA-4xqv-9cwh-jxcj"}, nil
+		case "GHSA-4xqv-9cwh-jxcj":
+			t.Error("the GHSA of an already listed CVE was fetched again")
+		}
+		return advisory{}, errors.New("not found")
+	}
+
+	advisories := resolveAdvisories([]string{"CVE-2024-9999", "CVE-2024-1111", "GHSA-4xqv-9cwh-jxcj"})
+	entry := "## [v1.1.0] - 2024-02-01\n\n### 追加\n\n- 新機能\n\n### 修正\n\n- CVE-2024-1111 のパストラバーサルを修正\n\n### セキュリティ\n\n- 依存ライブラリを更新"
+	want := "## [v1.1.0] - 2024-02-01\n\n### 追加\n\n- 新機能\n\n### セキュリティ\n\n- 依存ライブラリを更新\n" +
+		"- 【重大度: 高】Path traversal in upload（[GHSA-4xqv-9cwh-jxcj](https://github.com/advisories/GHSA-4xqv-9cwh-jxcj)、CVE-2024-1111）\n" +
+		"- セキュリティ上の問題を修正（[CVE-2024-9999](https://nvd.nist.gov/vuln/detail/CVE-2024-9999)）"
+	if got := addSecurityAdvisories(entry, advisories); got != want {
+		t.Errorf("addSecurityAdvisories() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Without a security section one is added at the end
+	got := addSecurityAdvisories("## [v1.1.0] - 2024-02-01\n\n### 追加\n\n- 新機能", advisories[:1])
+	if want := "## [v1.1.0] - 2024-02-01\n\n### 追加\n\n- 新機能\n\n### セキュリティ\n\n- 【重大度: 高】Path traversal in upload（[GHSA-4xqv-9cwh-jxcj](https://github.com/advisories/GHSA-4xqv-9cwh-jxcj)、CVE-2024-1111）"; got != want {
+		t.Errorf("addSecurityAdvisories() without a section =\n%s", got)
+	}
+}
+
+func TestAppendSecurityAdvisoriesInModule(t *testing.T) {
+	savedOpts, savedFetch, savedModule := genOpts, fetchAdvisory, goModule
+	defer func() { genOpts, fetchAdvisory, goModule = savedOpts, savedFetch, savedModule }()
+	genOpts.SecurityAdvisories = true
+	fetchAdvisory = func(id string) (advisory, error) {
+		return advisory{CVEID: id, Summary: "Fixed " + id, URL: "https://nvd.nist.gov/vuln/detail/" + id}, nil
+	}
+	newTestRepo(t)
+	testCommit(t, "init", map[string]string{"api/go.mod": "module example.com/api\n", "web/go.mod": "module example.com/web\n"})
+	testGit(t, "tag", "api/v1.0.0")
+	testCommit(t, "fix: CVE-2024-1111", map[string]string{"api/handler.go": "package api\n"})
+	testCommit(t, "fix: CVE-2024-2222", map[string]string{"web/handler.go": "package web\n"})
+
+	goModule = "api"
+	got := appendSecurityAdvisories("## [api/v1.1.0] - 2025-09-01\n\n### 修正\n\n- 修正", "api/v1.0.0", gitRefHEAD)
+	if !strings.Contains(got, "CVE-2024-1111") || strings.Contains(got, "CVE-2024-2222") {
+		t.Errorf("appendSecurityAdvisories() with --module api =\n%s\nwant only the advisory of the api commit", got)
+	}
+}
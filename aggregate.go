@@ -0,0 +1,185 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// serviceChangelog holds the latest released entries of one service
+type serviceChangelog struct {
+	Name    string
+	Entries []changelogEntry
+}
+
+func aggregateCommand(args []string) error {
+	fs := flag.NewFlagSet("aggregate", flag.ContinueOnError)
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md inside each repository")
+	latest := fs.Int("latest", 1, "Number of latest released entries collected from each repository")
+	title := fs.String("title", "Platform Release Notes", "Title of the combined document")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	model := fs.String("model", "claude", "AI model to use: claude, or mock[:file.md] for a canned entry without AI access")
+	noAI := fs.Bool("no-ai", false, "Combine the entries by service without AI composition")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update aggregate [flags] [<name>=]<repository>...\n\n")
+		fmt.Fprintf(os.Stderr, "Combines the latest entries of several repositories into release notes grouped by service.\n")
+		fmt.Fprintf(os.Stderr, "The service name defaults to the repository directory name.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("expected at least one repository")
+	}
+	if *latest < 1 {
+		return fmt.Errorf("--latest must be at least 1")
+	}
+
+	services, err := collectServiceChangelogs(fs.Args(), *changelogFile, *latest)
+	if err != nil {
+		return err
+	}
+
+	var document string
+	if *noAI {
+		document = renderAggregate(*title, services)
+	} else {
+		executor, err := newExecutor(*model)
+		if err != nil {
+			return err
+		}
+		spin := startSpinner(ui.Sprintf("Composing release notes for %d service(s)", len(services)), !ui.plain)
+		document, err = executor.Execute(aggregatePrompt(*title, services))
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to compose release notes: %w", err)
+		}
+	}
+
+	if *output == "" {
+		fmt.Println(document)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(strings.TrimRight(document, "\n")+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+	ui.Printf("✅ Wrote release notes for %d service(s) to %s\n", len(services), *output)
+	return nil
+}
+
+// parseServiceArg splits a "name=path" argument; without a name the base name of the repository is used
+func parseServiceArg(arg string) (name, path string) {
+	if name, path, ok := strings.Cut(arg, "="); ok && name != "" {
+		return name, path
+	}
+	return filepath.Base(filepath.Clean(arg)), arg
+}
+
+// collectServiceChangelogs reads the latest released entries of each repository; an argument may
+// also point directly at a changelog file
+func collectServiceChangelogs(args []string, changelogFile string, latest int) ([]serviceChangelog, error) {
+	var services []serviceChangelog
+	for _, arg := range args {
+		name, path := parseServiceArg(arg)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, changelogFile)
+		} else if err == nil && !strings.Contains(arg, "=") {
+			name = filepath.Base(filepath.Dir(filepath.Clean(path)))
+		}
+
+		entries, err := readChangelogEntries(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read changelog of %s: %w", name, err)
+		}
+		released := latestReleasedEntries(entries, latest)
+		if len(released) == 0 {
+			ui.Printf("⚠️  Warning: %s has no released entries, skipping.\n", name)
+			continue
+		}
+		services = append(services, serviceChangelog{Name: name, Entries: released})
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("no released entries found")
+	}
+	return services, nil
+}
+
+// latestReleasedEntries returns up to n entries, newest first, skipping the Unreleased section
+func latestReleasedEntries(entries []changelogEntry, n int) []changelogEntry {
+	var released []changelogEntry
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Version, "Unreleased") {
+			continue
+		}
+		if released = append(released, entry); len(released) == n {
+			break
+		}
+	}
+	return released
+}
+
+// demoteHeadings adds levels to every Markdown heading so entries can be nested under a service heading
+func demoteHeadings(markdown string, levels int) string {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			lines[i] = strings.Repeat("#", levels) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// serviceEntries returns the entries of a service as Markdown, newest first
+func serviceEntries(service serviceChangelog) string {
+	parts := make([]string, len(service.Entries))
+	for i, entry := range service.Entries {
+		parts[i] = entry.Markdown()
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderAggregate combines the entries under one heading per service without AI assistance
+func renderAggregate(title string, services []serviceChangelog) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, service := range services {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", service.Name, demoteHeadings(normalizeNewlines(serviceEntries(service)), 1))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// aggregatePrompt asks the AI to compose release notes grouped by service from the collected entries
+func aggregatePrompt(title string, services []serviceChangelog) string {
+	var sources strings.Builder
+	for _, service := range services {
+		fmt.Fprintf(&sources, "サービス: %s\n---\n%s\n---\n\n", service.Name, serviceEntries(service))
+	}
+
+	return fmt.Sprintf(`以下は複数のサービスのCHANGELOGから抜き出した最新のリリースエントリーです。これらをまとめて、プラットフォーム全体のリリースノートを作成してください。
+
+%s以下の形式で出力してください:
+# %s
+
+## 概要
+
+- プラットフォーム全体として重要な変更を3〜5項目で要約
+
+## サービス名
+
+### バージョン
+
+- そのサービスの主な変更を箇条書きで記載
+
+注意事項：
+- サービスごとに見出しを分け、上記のサービスの順序を保ってください
+- 各サービスのバージョン番号は元のエントリーから正確に引き継いでください
+- 元のエントリーにない変更を追加しないでください
+- 複数のサービスにまたがる変更は概要でまとめて説明してください
+- 前置きや説明文は一切含めないでください
+- リリースノート本文のみを出力してください
+- 各項目は日本語で記述してください`, sources.String(), title)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseServiceArg(t *testing.T) {
+	tests := []struct {
+		arg, name, path string
+	}{
+		{"../billing/", "billing", "../billing/"},
+		{"api=../services/gateway", "api", "../services/gateway"},
+	}
+	for _, tt := range tests {
+		name, path := parseServiceArg(tt.arg)
+		if name != tt.name || path != tt.path {
+			t.Errorf("parseServiceArg(%q) = %q, %q, want %q, %q", tt.arg, name, path, tt.name, tt.path)
+		}
+	}
+}
+
+func TestLatestReleasedEntries(t *testing.T) {
+	entries := []changelogEntry{{Version: "Unreleased"}, {Version: "v1.2.0"}, {Version: "v1.1.0"}, {Version: "v1.0.0"}}
+	got := latestReleasedEntries(entries, 2)
+	if len(got) != 2 || got[0].Version != "v1.2.0" || got[1].Version != "v1.1.0" {
+		t.Errorf("latestReleasedEntries() = %+v", got)
+	}
+}
+
+func TestCollectServiceChangelogsAndRender(t *testing.T) {
+	root := t.TempDir()
+	billing := filepath.Join(root, "billing")
+	if err := os.Mkdir(billing, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	changelog := "# Changelog\n\n## [v2.0.0] - 2025-09-01\n\n### 追加\n\n- 請求書のPDF出力\n\n## [v1.0.0] - 2025-08-01\n\n### 追加\n\n- 初回リリース\n"
+	if err := os.WriteFile(filepath.Join(billing, "CHANGELOG.md"), []byte(changelog), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gatewayFile := filepath.Join(root, "gateway.md")
+	if err := os.WriteFile(gatewayFile, []byte("# Changelog\n\n## [Unreleased]\n\n- 作業中\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	services, err := collectServiceChangelogs([]string{billing, "gateway=" + gatewayFile}, "CHANGELOG.md", 1)
+	if err != nil {
+		t.Fatalf("collectServiceChangelogs() error = %v", err)
+	}
+	if len(services) != 1 || services[0].Name != "billing" || len(services[0].Entries) != 1 {
+		t.Fatalf("collectServiceChangelogs() = %+v", services)
+	}
+
+	want := "# Platform\n\n## billing\n\n### [v2.0.0] - 2025-09-01\n\n#### 追加\n\n- 請求書のPDF出力"
+	if got := renderAggregate("Platform", services); got != want {
+		t.Errorf("renderAggregate() =\n%q\nwant\n%q", got, want)
+	}
+
+	prompt := aggregatePrompt("Platform", services)
+	for _, s := range []string{"# Platform", "サービス: billing", "請求書のPDF出力"} {
+		if !strings.Contains(prompt, s) {
+			t.Errorf("aggregatePrompt() does not contain %q", s)
+		}
+	}
+
+	if _, err := collectServiceChangelogs([]string{filepath.Join(root, "missing")}, "CHANGELOG.md", 1); err == nil {
+		t.Error("collectServiceChangelogs() succeeded for a missing repository")
+	}
+}
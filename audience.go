@@ -0,0 +1,51 @@
+package main
+
+// Values accepted by --audience
+const (
+	audienceEndUser   = "end-user"
+	audienceDeveloper = "developer"
+	audienceOps       = "ops"
+)
+
+// Values accepted by --tone
+const (
+	toneFormal = "formal"
+	toneCasual = "casual"
+)
+
+// audienceInstructions are the prompt instructions for each --audience value
+var audienceInstructions = map[string]string{
+	audienceEndUser:   "読者は製品のエンドユーザーです。利用者にとってのメリットや使い方の変化を中心に、専門用語を避けて記載し、内部のリファクタリングやテストの変更は省いてください",
+	audienceDeveloper: "読者はこのプロジェクトを利用・開発する開発者です。API・関数・フラグ・設定項目の名前や互換性への影響など、技術的な詳細を具体的かつ簡潔に記載してください",
+	audienceOps:       "読者はこのソフトウェアを運用する担当者です。デプロイ手順・設定・マイグレーション・性能・互換性・監視など、運用への影響を優先して記載してください",
+}
+
+// toneInstructions are the prompt instructions for each --tone value
+var toneInstructions = map[string]string{
+	toneFormal: "丁寧でフォーマルな文体（です・ます調）で統一してください",
+	toneCasual: "親しみやすいくだけた文体で、短く簡潔に記載してください",
+}
+
+// validAudience reports whether audience is a supported --audience value
+func validAudience(audience string) bool {
+	_, ok := audienceInstructions[audience]
+	return audience == "" || ok
+}
+
+// validTone reports whether tone is a supported --tone value
+func validTone(tone string) bool {
+	_, ok := toneInstructions[tone]
+	return tone == "" || ok
+}
+
+// audienceNotes returns the prompt instructions for the configured audience and tone
+func audienceNotes() []string {
+	var notes []string
+	if note, ok := audienceInstructions[genOpts.Audience]; ok {
+		notes = append(notes, note)
+	}
+	if note, ok := toneInstructions[genOpts.Tone]; ok {
+		notes = append(notes, note)
+	}
+	return notes
+}
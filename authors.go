@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// authorMarkerPattern matches the commit hashes the AI appends to a bullet for --attribute-authors
+var authorMarkerPattern = regexp.MustCompile(`[ \t]*<!--\s*by:\s*([0-9a-fA-F,\s]*?)\s*-->`)
+
+// noreplyEmailPattern matches GitHub noreply addresses, which contain the account name
+var noreplyEmailPattern = regexp.MustCompile(`(?i)^(?:\d+\+)?([a-z0-9][a-z0-9-]*)@users\.noreply\.github\.com$`)
+
+// commitAuthor is the author of a commit in the release range
+type commitAuthor struct {
+	Hash   string
+	Handle string
+}
+
+// authorHandle returns how an author is credited: the GitHub account for noreply addresses, the
+// name as a handle when it has no spaces, and the plain name otherwise
+func authorHandle(name, email string) string {
+	if m := noreplyEmailPattern.FindStringSubmatch(strings.TrimSpace(email)); m != nil {
+		return "@" + m[1]
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return name
+	}
+	return "@" + strings.TrimPrefix(name, "@")
+}
+
+// rangeAuthors returns the authors of the commits in from..to, leaving out dependency bots
+func rangeAuthors(from, to string) ([]commitAuthor, error) {
+	revRange := to
+	if from != "" && from != gitRefHEAD {
+		revRange = from + ".." + to
+	}
+	output, err := gitOutput("log", "--format=%H%x1f%aN%x1f%aE", revRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit authors: %w", err)
+	}
+	var authors []commitAuthor
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.SplitN(line, "\x1f", 3)
+		if len(fields) != 3 || botName(fields[1]) != "" {
+			continue
+		}
+		if handle := authorHandle(fields[1], fields[2]); handle != "" {
+			authors = append(authors, commitAuthor{Hash: fields[0], Handle: handle})
+		}
+	}
+	return authors, nil
+}
+
+// creditAuthors replaces the commit hashes appended to each bullet with "(by @alice, @bob)".
+// Hashes that match no human author of the range are dropped, and so is the marker when none match.
+func creditAuthors(entry string, authors []commitAuthor) string {
+	return authorMarkerPattern.ReplaceAllStringFunc(entry, func(marker string) string {
+		var handles []string
+		seen := map[string]bool{}
+		for _, hash := range strings.FieldsFunc(authorMarkerPattern.FindStringSubmatch(marker)[1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n'
+		}) {
+			if len(hash) < 7 {
+				continue
+			}
+			for _, a := range authors {
+				if strings.HasPrefix(a.Hash, strings.ToLower(hash)) && !seen[a.Handle] {
+					seen[a.Handle] = true
+					handles = append(handles, a.Handle)
+				}
+			}
+		}
+		if len(handles) == 0 {
+			return ""
+		}
+		return " (by " + strings.Join(handles, ", ") + ")"
+	})
+}
+
+// attributeAuthors credits the authors of the commits behind each bullet of an entry for from..to
+func attributeAuthors(entry, from, to string) string {
+	if !genOpts.AttributeAuthors {
+		return entry
+	}
+	authors, err := rangeAuthors(from, to)
+	if err != nil {
+		ui.Printf("⚠️  Warning: %v\n", err)
+	}
+	return creditAuthors(entry, authors)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuthorHandle(t *testing.T) {
+	for _, tt := range []struct{ name, email, want string }{
+		{"Alice Smith", "12345+alice@users.noreply.github.com", "@alice"},
+		{"Bob", "bob@users.noreply.github.com", "@bob"},
+		{"carol", "carol@example.com", "@carol"},
+		{"Dave Jones", "dave@example.com", "Dave Jones"},
+	} {
+		if got := authorHandle(tt.name, tt.email); got != tt.want {
+			t.Errorf("authorHandle(%q, %q) = %q, want %q", tt.name, tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestCreditAuthors(t *testing.T) {
+	authors := []commitAuthor{
+		{Hash: "abc1234ffffffffffffffffffffffffffffffff0", Handle: "@alice"},
+		{Hash: "def5678ffffffffffffffffffffffffffffffff0", Handle: "@bob"},
+		{Hash: "0123456ffffffffffffffffffffffffffffffff0", Handle: "@alice"},
+	}
+	entry := "## [v1.1.0] - 2024-05-01\n\n### 追加\n\n" +
+		"- エクスポート機能を追加 <!-- by: abc1234 def5678 0123456 -->\n" +
+		"- 設定ファイルに対応 (abc1234) <!-- by: abc1234 -->\n" +
+		"- botの更新 <!-- by: 9999999 -->"
+	want := "## [v1.1.0] - 2024-05-01\n\n### 追加\n\n" +
+		"- エクスポート機能を追加 (by @alice, @bob)\n" +
+		"- 設定ファイルに対応 (abc1234) (by @alice)\n" +
+		"- botの更新"
+	if got := creditAuthors(entry, authors); got != want {
+		t.Errorf("creditAuthors() = %q, want %q", got, want)
+	}
+}
+
+func TestAttributeAuthors(t *testing.T) {
+	savedOpts := genOpts
+	defer func() { genOpts = savedOpts }()
+	newTestRepo(t)
+
+	commit := func(name, email, file string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(gitDir, file), []byte(file), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		testGit(t, "add", ".")
+		testGit(t, "-c", "user.name="+name, "-c", "user.email="+email, "commit", "--quiet", "-m", "add "+file)
+	}
+	commit("Alice", "1+alice@users.noreply.github.com", "a.go")
+	commit("dependabot[bot]", "bot@example.com", "b.go")
+	hashes, err := gitOutput("log", "--format=%h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Fields(hashes)
+	entry := "- 追加 <!-- by: " + lines[1] + " -->\n- 更新 <!-- by: " + lines[0] + " -->"
+
+	genOpts.AttributeAuthors = false
+	if got := attributeAuthors(entry, "", gitRefHEAD); got != entry {
+		t.Errorf("attributeAuthors() without the option = %q", got)
+	}
+	genOpts.AttributeAuthors = true
+	if got, want := attributeAuthors(entry, "", gitRefHEAD), "- 追加 (by @alice)\n- 更新"; got != want {
+		t.Errorf("attributeAuthors() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Values accepted by --bot-commits
+const (
+	botCommitsCollapse = "collapse"
+	botCommitsExclude  = "exclude"
+	botCommitsKeep     = "keep"
+)
+
+// commitLogFormat is the git log format read by parseCommitLog: abbreviated hash, author name and subject
+const commitLogFormat = "--format=%h%x1f%an%x1f%s"
+
+// botAuthorPattern matches the author names used by dependency update bots
+var botAuthorPattern = regexp.MustCompile(`(?i)^(dependabot|renovate)(\[bot\]|-bot)?$`)
+
+// validBotCommits reports whether mode is a supported --bot-commits value
+func validBotCommits(mode string) bool {
+	return mode == botCommitsCollapse || mode == botCommitsExclude || mode == botCommitsKeep
+}
+
+// botName returns the normalized bot name for a commit author, or "" for human authors
+func botName(author string) string {
+	m := botAuthorPattern.FindStringSubmatch(strings.TrimSpace(author))
+	if m == nil {
+		return ""
+	}
+	return strings.ToLower(m[1])
+}
+
+// parseCommitLog converts commitLogFormat output to `git log --oneline` lines,
+// leaving out commits by dependency bots unless they are kept, and counts those commits per bot
+func parseCommitLog(output string) (oneline string, bots map[string]int) {
+	var b strings.Builder
+	bots = map[string]int{}
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		fields := strings.SplitN(line, "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if bot := botName(fields[1]); bot != "" {
+			bots[bot]++
+			if genOpts.BotCommits != botCommitsKeep {
+				continue
+			}
+		}
+		b.WriteString(fields[0] + " " + fields[2] + "\n")
+	}
+	return b.String(), bots
+}
+
+// countBotCommits returns the number of commits per dependency bot in from..to that touch the
+// selected module
+func countBotCommits(from, to string) (map[string]int, error) {
+	revRange := to
+	if from != "" && from != gitRefHEAD {
+		revRange = from + ".." + to
+	}
+	output, err := gitCommand(append([]string{"log", commitLogFormat, revRange}, modulePathspec()...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit authors: %w", err)
+	}
+	_, bots := parseCommitLog(string(output))
+	return bots, nil
+}
+
+// botCommitsBullet summarizes bot commits as a single bullet
+func botCommitsBullet(bots map[string]int) string {
+	names := make([]string, 0, len(bots))
+	total := 0
+	for name, n := range bots {
+		names = append(names, name)
+		total += n
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("- %s による依存関係の自動更新（%d件）", strings.Join(names, "、"), total)
+}
+
+// appendBotCommits collapses bot commits in from..to into one bullet in the dependency section
+func appendBotCommits(entry, from, to string) string {
+	if genOpts.BotCommits != botCommitsCollapse {
+		return entry
+	}
+	bots, err := countBotCommits(from, to)
+	if err != nil {
+		ui.Printf("⚠️  Warning: %v\n", err)
+		return entry
+	}
+	if len(bots) == 0 {
+		return entry
+	}
+
+	entry = strings.TrimRight(entry, "\n")
+	if lastSectionTitle(entry) == dependencySectionTitle {
+		return entry + "\n" + botCommitsBullet(bots)
+	}
+	return entry + "\n\n" + dependencySectionTitle + "\n\n" + botCommitsBullet(bots)
+}
+
+// lastSectionTitle returns the last "### " heading line of an entry
+func lastSectionTitle(entry string) string {
+	lines := strings.Split(entry, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], "### ") {
+			return strings.TrimSpace(lines[i])
+		}
+	}
+	return ""
+}
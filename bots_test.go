@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseCommitLog(t *testing.T) {
+	original := genOpts
+	defer func() { genOpts = original }()
+
+	output := "a1\x1fAlice\x1ffeat: add export\n" +
+		"b2\x1fdependabot[bot]\x1fbuild(deps): bump x from 1 to 2\n" +
+		"c3\x1frenovate[bot]\x1fchore(deps): update y\n" +
+		"d4\x1fdependabot[bot]\x1fbuild(deps): bump z\n"
+
+	genOpts = generationOptions{BotCommits: botCommitsCollapse}
+	oneline, bots := parseCommitLog(output)
+	if oneline != "a1 feat: add export\n" {
+		t.Errorf("parseCommitLog() oneline = %q", oneline)
+	}
+	if !reflect.DeepEqual(bots, map[string]int{"dependabot": 2, "renovate": 1}) {
+		t.Errorf("parseCommitLog() bots = %v", bots)
+	}
+
+	genOpts = generationOptions{BotCommits: botCommitsKeep}
+	if oneline, _ := parseCommitLog(output); oneline != "a1 feat: add export\nb2 build(deps): bump x from 1 to 2\nc3 chore(deps): update y\nd4 build(deps): bump z\n" {
+		t.Errorf("parseCommitLog() with keep = %q", oneline)
+	}
+}
+
+func TestBotName(t *testing.T) {
+	for author, want := range map[string]string{
+		"dependabot[bot]": "dependabot",
+		"renovate-bot":    "renovate",
+		"renovate[bot]":   "renovate",
+		"Alice":           "",
+	} {
+		if got := botName(author); got != want {
+			t.Errorf("botName(%q) = %q, want %q", author, got, want)
+		}
+	}
+}
+
+func TestBotCommitsBulletPlacement(t *testing.T) {
+	bullet := botCommitsBullet(map[string]int{"renovate": 1, "dependabot": 2})
+	if bullet != "- dependabot、renovate による依存関係の自動更新（3件）" {
+		t.Errorf("botCommitsBullet() = %q", bullet)
+	}
+	if got := lastSectionTitle("### 追加\n\n- a\n\n" + dependencySectionTitle + "\n\n- `x` を更新"); got != dependencySectionTitle {
+		t.Errorf("lastSectionTitle() = %q", got)
+	}
+}
+
+func TestCountBotCommitsInModule(t *testing.T) {
+	savedModule := goModule
+	defer func() { goModule = savedModule }()
+	newTestRepo(t)
+	testCommit(t, "init", map[string]string{"api/go.mod": "module example.com/api\n", "web/go.mod": "module example.com/web\n"})
+	testGit(t, "tag", "api/v1.0.0")
+	for _, dir := range []string{"api", "web"} {
+		if err := os.WriteFile(filepath.Join(gitDir, dir, "go.sum"), []byte(dir+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		testGit(t, "add", "-A")
+		testGit(t, "-c", "user.name=dependabot[bot]", "commit", "--quiet", "-m", "build(deps): bump "+dir)
+	}
+
+	goModule = "api"
+	bots, err := countBotCommits("api/v1.0.0", gitRefHEAD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bots, map[string]int{"dependabot": 1}) {
+		t.Errorf("countBotCommits() with --module api = %v, want the one api commit", bots)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// defaultBranch returns the default branch of the remote, or "" when the remote HEAD is unknown
+func defaultBranch() string {
+	output, err := gitOutput("symbolic-ref", "--quiet", "refs/remotes/"+gitRemote+"/HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(output), "refs/remotes/"+gitRemote+"/")
+}
+
+// currentBranch returns the checked out branch, or "" when HEAD is detached
+func currentBranch() string {
+	output, err := gitOutput("symbolic-ref", "--quiet", "--short", gitRefHEAD)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// excludedChanges returns the tracked files with uncommitted changes that the entry will not include,
+// given the --include-staged and --include-working-tree options. Files the tool writes itself are skipped.
+func excludedChanges(written ...string) ([]string, error) {
+	var args [][]string
+	if !genOpts.IncludeStaged {
+		args = append(args, []string{"diff", "--cached", "--name-only"})
+	}
+	if !genOpts.IncludeWorkingTree {
+		args = append(args, []string{"diff", "--name-only"})
+	}
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	top, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	skip := map[string]bool{}
+	for _, file := range written {
+		if abs, err := filepath.Abs(file); err == nil {
+			// The top level is reported with symlinks resolved, e.g. /private/var on macOS
+			if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+				abs = resolved
+			}
+			skip[abs] = true
+		}
+	}
+
+	seen := map[string]bool{}
+	var files []string
+	for _, a := range args {
+		output, err := gitOutput(a...)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range strings.Split(strings.TrimSpace(output), "\n") {
+			if file == "" || seen[file] || skip[filepath.Join(strings.TrimSpace(top), filepath.FromSlash(file))] {
+				continue
+			}
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// checkReleaseBranch warns when a release entry is generated on a branch other than the default
+// branch, and fails when uncommitted changes would be left out of the entry unless allowDirty is set
+func checkReleaseBranch(changelogFile string, allowDirty bool) error {
+	if current, main := currentBranch(), defaultBranch(); current != "" && main != "" && current != main {
+		ui.Printf("⚠️  Warning: On branch %s, not the default branch %s; the entry describes this branch since the latest tag.\n", current, main)
+	}
+
+	files, err := excludedChanges(changelogFile, repoPath("package.json"))
+	if err != nil || len(files) == 0 {
+		return nil
+	}
+	if allowDirty {
+		ui.Printf("⚠️  Warning: %d file(s) with uncommitted changes are not included in the entry: %s\n", len(files), strings.Join(files, ", "))
+		return nil
+	}
+	return &ConfigError{Err: fmt.Errorf("%d file(s) with uncommitted changes would not be included in the entry: %s (commit or stage them, use --include-working-tree, or pass --allow-dirty)", len(files), strings.Join(files, ", "))}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckReleaseBranchRequiresAllowDirty(t *testing.T) {
+	savedOpts := genOpts
+	defer func() { genOpts = savedOpts }()
+	newTestRepo(t)
+	testCommit(t, "initial", map[string]string{"main.go": "v1\n", "CHANGELOG.md": "v1\n"})
+	changelog := filepath.Join(gitDir, "CHANGELOG.md")
+	if err := checkReleaseBranch(changelog, false); err != nil {
+		t.Fatalf("checkReleaseBranch() on a clean tree = %v", err)
+	}
+
+	// Changes to the changelog itself never count
+	for _, file := range []string{"main.go", "CHANGELOG.md"} {
+		if err := os.WriteFile(filepath.Join(gitDir, file), []byte("v2\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var configErr *ConfigError
+	err := checkReleaseBranch(changelog, false)
+	if !errors.As(err, &configErr) || !strings.Contains(err.Error(), "main.go") || strings.Contains(err.Error(), "CHANGELOG.md") {
+		t.Errorf("checkReleaseBranch() with an unstaged change = %v, want a ConfigError naming main.go", err)
+	}
+	if err := checkReleaseBranch(changelog, true); err != nil {
+		t.Errorf("checkReleaseBranch() with --allow-dirty = %v", err)
+	}
+
+	genOpts.IncludeWorkingTree = true
+	if err := checkReleaseBranch(changelog, false); err != nil {
+		t.Errorf("checkReleaseBranch() with --include-working-tree = %v", err)
+	}
+}
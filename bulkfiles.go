@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxBulkFileLines caps the one-line descriptions of bulk files in a prompt
+const maxBulkFileLines = 20
+
+// lockfileNames are the dependency lockfiles whose content says nothing a changelog needs
+var lockfileNames = []string{
+	"go.sum", "go.work.sum", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml",
+	"bun.lockb", "Cargo.lock", "Gemfile.lock", "poetry.lock", "Pipfile.lock", "uv.lock", "composer.lock",
+	"Podfile.lock", "pubspec.lock", "mix.lock", "flake.lock", "packages.lock.json", "gradle.lockfile",
+}
+
+// nameStatusWords describe the first letter of a name-status code
+var nameStatusWords = map[string]string{"A": "added", "M": "modified", "D": "deleted", "R": "renamed", "C": "copied", "T": "changed type"}
+
+// bulkFileKind returns "lockfile" or "minified" for files whose content is not worth the prompt
+// budget, or "" for other files; binary files are recognized from the diff instead
+func bulkFileKind(file string) string {
+	base := path.Base(file)
+	switch {
+	case slices.Contains(lockfileNames, base):
+		return "lockfile"
+	case strings.Contains(base, ".min.") || strings.HasSuffix(base, ".map"):
+		return "minified"
+	}
+	return ""
+}
+
+// numstatLines returns the changed line count of each file in from..to, or -1 for binary files
+func numstatLines(from, to string) (map[string]int, error) {
+	output, err := gitOutput("diff", "--numstat", "--no-renames", diffBase(from), to)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			continue
+		}
+		added, errAdded := strconv.Atoi(fields[0])
+		deleted, errDeleted := strconv.Atoi(fields[1])
+		if errAdded != nil || errDeleted != nil {
+			counts[fields[2]] = -1
+			continue
+		}
+		counts[fields[2]] = added + deleted
+	}
+	return counts, nil
+}
+
+// condenseBulkFiles moves lockfiles, minified assets and binary files out of a name-status list
+// into one-line descriptions with their size, such as "go.sum: 214 lines changed (lockfile)".
+// The descriptions have no tab, so code reading the list as name-status skips them. counts comes
+// from numstatLines.
+func condenseBulkFiles(nameStatus string, counts map[string]int) string {
+	var kept, bulk []string
+	for _, line := range strings.Split(nameStatus, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			kept = append(kept, line)
+			continue
+		}
+		file := fields[len(fields)-1]
+		kind, lines := bulkFileKind(file), counts[file]
+		switch {
+		case lines < 0:
+			bulk = append(bulk, fmt.Sprintf("%s: binary file %s", file, nameStatusWords[fields[0][:1]]))
+		case kind != "":
+			bulk = append(bulk, fmt.Sprintf("%s: %d lines changed (%s)", file, lines, kind))
+		default:
+			kept = append(kept, line)
+		}
+	}
+	if len(bulk) == 0 {
+		return nameStatus
+	}
+	if len(bulk) > maxBulkFileLines {
+		bulk = append(bulk[:maxBulkFileLines], fmt.Sprintf("... and %d more lockfiles, minified or binary files", len(bulk)-maxBulkFileLines))
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n") + "\n" + strings.Join(bulk, "\n"))
+}
+
+// withoutBulkFiles condenses the bulk files of the name-status list of from..to; the list is
+// returned unchanged when the line counts cannot be read
+func withoutBulkFiles(nameStatus, from, to string) string {
+	counts, err := numstatLines(from, to)
+	if err != nil {
+		return nameStatus
+	}
+	return condenseBulkFiles(nameStatus, counts)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCondenseBulkFiles(t *testing.T) {
+	nameStatus := "M\tmain.go\nM\tgo.sum\nA\tweb/app.min.js\nA\tassets/logo.png\nR100\told.lock\tyarn.lock"
+	counts := map[string]int{"main.go": 12, "go.sum": 214, "web/app.min.js": 1, "assets/logo.png": -1, "yarn.lock": 0}
+	want := "M\tmain.go\ngo.sum: 214 lines changed (lockfile)\nweb/app.min.js: 1 lines changed (minified)\nassets/logo.png: binary file added\nyarn.lock: 0 lines changed (lockfile)"
+	if got := condenseBulkFiles(nameStatus, counts); got != want {
+		t.Errorf("condenseBulkFiles() =\n%s\nwant\n%s", got, want)
+	}
+	if got := condenseBulkFiles("M\tmain.go", counts); got != "M\tmain.go" {
+		t.Errorf("condenseBulkFiles() without bulk files = %q", got)
+	}
+
+	var many []string
+	for i := 0; i < maxBulkFileLines+3; i++ {
+		many = append(many, fmt.Sprintf("A\timages/%d.png", i))
+		counts[fmt.Sprintf("images/%d.png", i)] = -1
+	}
+	got := condenseBulkFiles(strings.Join(many, "\n"), counts)
+	if !strings.HasSuffix(got, "... and 3 more lockfiles, minified or binary files") || countLines(got) != maxBulkFileLines+1 {
+		t.Errorf("condenseBulkFiles() with many files =\n%s", got)
+	}
+}
+
+func TestGetGitDiffBulkFiles(t *testing.T) {
+	savedFilter := aiFilter
+	defer func() { aiFilter = savedFilter }()
+	newTestRepo(t)
+	aiFilter = newPathFilter(nil, nil)
+
+	testCommit(t, "change", map[string]string{"main.go": "package main\n", "go.sum": "a v1\n"})
+	testGit(t, "tag", "v1.0.0")
+	testCommit(t, "change", map[string]string{"main.go": "package main\n\nfunc main() {}\n", "go.sum": "a v2\nb v1\n", "logo.png": "\x89PNG\x00\x01"})
+
+	diff, err := getGitDiff("v1.0.0", gitRefHEAD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "M\tmain.go\ngo.sum: 3 lines changed (lockfile)\nlogo.png: binary file added"; diff != want {
+		t.Errorf("getGitDiff() =\n%s\nwant\n%s", diff, want)
+	}
+}
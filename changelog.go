@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// changelogEntry is a single version section of a changelog
+type changelogEntry struct {
+	Version string
+	Heading string
+	Body    string
+}
+
+// Markdown returns the entry as it appears in the changelog
+func (e changelogEntry) Markdown() string {
+	if e.Body == "" {
+		return e.Heading
+	}
+	return e.Heading + "\n\n" + e.Body
+}
+
+// Date returns the release date from the heading, if it has one
+func (e changelogEntry) Date() (time.Time, bool) {
+	return changelogHeading.Date(e.Heading)
+}
+
+// parseChangelogEntries splits changelog content into version entries in file order
+func parseChangelogEntries(content string) []changelogEntry {
+	var entries []changelogEntry
+	var current *changelogEntry
+	var body []string
+
+	flush := func() {
+		if current != nil {
+			current.Body = strings.TrimSpace(strings.Join(body, "\n"))
+			entries = append(entries, *current)
+		}
+	}
+
+	for _, line := range strings.Split(changelogHeading.ATX(normalizeNewlines(content)), "\n") {
+		if version, ok := changelogHeading.Version(line); ok {
+			flush()
+			current = &changelogEntry{Version: version, Heading: strings.TrimSpace(line)}
+			body = nil
+			continue
+		}
+		if current != nil {
+			body = append(body, line)
+		}
+	}
+	flush()
+	return entries
+}
+
+// readChangelogEntries reads and parses a changelog file
+func readChangelogEntries(filename string) ([]changelogEntry, error) {
+	content, err := readTextFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseChangelogEntries(content), nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChangelogEntries(t *testing.T) {
+	content := "# Changelog\r\n\r\nIntro.\r\n\r\n## [v1.1.0] - 2025-09-01\r\n\r\n### 追加\r\n\r\n- Feature\r\n\r\n## [v1.0.0] - 2025-08-01\r\n"
+
+	want := []changelogEntry{
+		{Version: "v1.1.0", Heading: "## [v1.1.0] - 2025-09-01", Body: "### 追加\n\n- Feature"},
+		{Version: "v1.0.0", Heading: "## [v1.0.0] - 2025-08-01", Body: ""},
+	}
+
+	got := parseChangelogEntries(content)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseChangelogEntries() = %+v, want %+v", got, want)
+	}
+	if got[0].Markdown() != "## [v1.1.0] - 2025-09-01\n\n### 追加\n\n- Feature" {
+		t.Errorf("Markdown() = %q", got[0].Markdown())
+	}
+	if got[1].Markdown() != "## [v1.0.0] - 2025-08-01" {
+		t.Errorf("Markdown() without body = %q", got[1].Markdown())
+	}
+}
+
+func TestChangelogEntryDate(t *testing.T) {
+	d, ok := changelogEntry{Heading: "## [v1.0.0] - 2025-08-27"}.Date()
+	if !ok || d.Format("2006-01-02") != "2025-08-27" {
+		t.Errorf("Date() = %v, %v", d, ok)
+	}
+	if _, ok := (changelogEntry{Heading: "## [Unreleased]"}).Date(); ok {
+		t.Error("Date() should report no date for undated headings")
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// entryHasChanges reports whether an entry body lists anything besides headings and HTML comments
+func entryHasChanges(body string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		text := strings.TrimSpace(line)
+		if text == "" || htmlHeadingPattern.MatchString(line) || (strings.HasPrefix(text, "<!--") && strings.HasSuffix(text, "-->")) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// checkCommand fails when the changelog has no entry for the release, to gate release pipelines
+func checkCommand(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	tag := fs.String("tag", "", "Version about to be released (default: check that the Unreleased section lists changes)")
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	module := fs.String("module", "", "Directory of the Go module whose changelog is checked (default: taken from --tag, e.g. api/v1.2.0)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update check [--tag v1.2.0] [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Exits with an error when the changelog has no entry for --tag, or when the Unreleased section is empty.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	moduleTagName, err := setGoModule(*module, *tag)
+	if err != nil {
+		return err
+	}
+	*tag = moduleTagName
+	changelogSet := false
+	fs.Visit(func(f *flag.Flag) { changelogSet = changelogSet || f.Name == "changelog" })
+	if goModule != "" && !changelogSet {
+		*changelogFile = path.Join(goModule, *changelogFile)
+	}
+
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		return err
+	}
+	if changelogHeading, err = newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat); err != nil {
+		return &ConfigError{Err: err}
+	}
+
+	name := filepath.ToSlash(filepath.Clean(*changelogFile))
+	entries, err := readChangelogEntries(repoPath(filepath.FromSlash(*changelogFile)))
+	if err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+	version := *tag
+	if version == "" {
+		version = unreleasedVersion
+	}
+	for _, entry := range entries {
+		if !sameVersion(entry.Version, version) && !(version == unreleasedVersion && strings.EqualFold(entry.Version, version)) {
+			continue
+		}
+		if !entryHasChanges(entry.Body) {
+			return fmt.Errorf("the %s entry in %s is empty", entry.Version, name)
+		}
+		ui.Printf("✅ %s has an entry for %s\n", name, entry.Version)
+		return nil
+	}
+	if *tag == "" {
+		return fmt.Errorf("%s has no Unreleased section", name)
+	}
+	return fmt.Errorf("%s has no entry for %s; run changelog-update --tag %s before releasing", name, *tag, *tag)
+}
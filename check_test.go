@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEntryHasChanges(t *testing.T) {
+	tests := map[string]bool{
+		"":                             false,
+		"### 追加\n\n### 修正":             false,
+		"<!-- generated by v1.0.0 -->": false,
+		"### 追加\n\n- 新機能":              true,
+		"初回リリース":                       true,
+	}
+	for body, want := range tests {
+		if got := entryHasChanges(body); got != want {
+			t.Errorf("entryHasChanges(%q) = %v, want %v", body, got, want)
+		}
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	savedDir, savedUI := gitDir, ui
+	defer func() { gitDir, ui = savedDir, savedUI }()
+	gitDir = t.TempDir()
+	ui = &console{out: &strings.Builder{}, plain: true}
+
+	content := "# Changelog\n\n## [Unreleased]\n\n### 追加\n\n## [v1.1.0] - 2024-02-01\n\n### 修正\n\n- B\n\n## [v1.0.0] - 2024-01-01\n"
+	if err := os.WriteFile(filepath.Join(gitDir, "CHANGELOG.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--tag", "v1.1.0"}, ""},
+		{[]string{"--tag", "1.1.0"}, ""},
+		{[]string{"--tag", "v1.2.0"}, "no entry for v1.2.0"},
+		{[]string{"--tag", "v1.0.0"}, "entry in CHANGELOG.md is empty"},
+		{nil, "Unreleased entry in CHANGELOG.md is empty"},
+		{[]string{"--changelog", "missing.md"}, "failed to read changelog"},
+	}
+	for _, tt := range tests {
+		err := checkCommand(tt.args)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("check %q = %v", tt.args, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("check %q = %v, want an error containing %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+)
+
+// hasRemote reports whether the repository has any remote configured
+func hasRemote() bool {
+	output, err := gitOutput("remote")
+	return err == nil && strings.TrimSpace(output) != ""
+}
+
+// isShallowRepository reports whether the repository is a shallow clone, as created by CI checkouts with a fetch depth
+func isShallowRepository() bool {
+	output, err := gitOutput("rev-parse", "--is-shallow-repository")
+	return err == nil && strings.TrimSpace(output) == "true"
+}
+
+// isDetachedHead reports whether HEAD points to a commit instead of a branch
+func isDetachedHead() bool {
+	return hasCommits() && gitCommand("symbolic-ref", "--quiet", gitRefHEAD).Run() != nil
+}
+
+// prepareCheckout reports a detached HEAD and completes a shallow clone with the full history and
+// tags of --remote, so the range from the previous tag resolves; with offline nothing is fetched
+// and a shallow clone is only reported
+func prepareCheckout(offline bool) {
+	if isDetachedHead() {
+		head, _ := gitOutput("rev-parse", "--short", gitRefHEAD)
+		ui.Printf("ℹ️  HEAD is detached at %s; changes are read up to this commit.\n", strings.TrimSpace(head))
+	}
+	if !isShallowRepository() {
+		return
+	}
+	if offline || !hasRemote() {
+		ui.Println("⚠️  Warning: This is a shallow clone, so the previous tag and older commits may be missing. Run git fetch --unshallow --tags first.")
+		return
+	}
+	ui.Println("📥 Shallow clone detected, fetching the full history and tags...")
+	if err := runGit("fetch", "--unshallow", "--tags", gitRemote); err != nil {
+		ui.Printf("⚠️  Warning: Failed to unshallow the repository: %v\n", err)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckoutChecksOutsideRepository(t *testing.T) {
+	saved := gitDir
+	defer func() { gitDir = saved }()
+	gitDir = t.TempDir()
+
+	if hasRemote() || isShallowRepository() || isDetachedHead() {
+		t.Error("a directory that is not a repository was reported to have a remote, be shallow or have a detached HEAD")
+	}
+	// Must not try to fetch anything
+	prepareCheckout(false)
+}
+
+func TestPrepareCheckoutUnshallowsFromRemote(t *testing.T) {
+	savedRemote, savedUI := gitRemote, ui
+	defer func() { gitRemote, ui = savedRemote, savedUI }()
+	ui = &console{out: &strings.Builder{}, plain: true}
+
+	newTestRepo(t)
+	for i := 0; i < 3; i++ {
+		testCommit(t, "change", map[string]string{"main.go": strings.Repeat("x", i)})
+	}
+	source := gitDir
+	clone := filepath.Join(t.TempDir(), "clone")
+	testGit(t, "clone", "--quiet", "--depth=1", "file://"+source, clone)
+	gitDir = clone
+	// The fork's origin is unreachable here; the history comes from upstream
+	testGit(t, "remote", "set-url", "origin", filepath.Join(t.TempDir(), "missing"))
+	testGit(t, "remote", "add", "upstream", "file://"+source)
+
+	gitRemote = "upstream"
+	prepareCheckout(false)
+	if isShallowRepository() {
+		t.Error("prepareCheckout() with --remote upstream left the clone shallow")
+	}
+}
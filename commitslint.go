@@ -0,0 +1,223 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// conventionalTypes are the commit types of the Conventional Commits convention (as in commitlint's
+// config-conventional), accepted unless --types is given
+var conventionalTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// conventionalSubjectPattern splits a Conventional Commits subject into type, scope, "!" and description
+var conventionalSubjectPattern = regexp.MustCompile(`^([A-Za-z]+)(\(([^()]*)\))?(!)?:( *)(.*)$`)
+
+// suggestionPattern matches a "<hash>: <message>" line of the AI's rewrite suggestions
+var suggestionPattern = regexp.MustCompile(`^\s*[-*]?\s*([0-9a-fA-F]{7,40})\s*[:：]\s*(.+)$`)
+
+// generatedSubjectPattern matches subjects git writes itself, which are not checked like merge commits
+var generatedSubjectPattern = regexp.MustCompile(`^(Revert "|(fixup|squash|amend)! )`)
+
+// lintedCommit is a commit that does not follow Conventional Commits, with the reasons
+type lintedCommit struct {
+	Hash     string
+	Subject  string
+	Problems []string
+}
+
+// conventionalProblems lists why a commit message does not follow Conventional Commits; types
+// are the accepted commit types
+func conventionalProblems(message string, types []string) []string {
+	lines := strings.Split(strings.TrimRight(normalizeNewlines(message), "\n"), "\n")
+	subject := strings.TrimSpace(lines[0])
+	m := conventionalSubjectPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return []string{`the subject is not in the form "<type>(<scope>): <description>", e.g. "fix(parser): handle empty input"`}
+	}
+
+	var problems []string
+	if !slices.Contains(types, strings.ToLower(m[1])) {
+		problems = append(problems, fmt.Sprintf("unknown type %q (expected one of %s)", m[1], strings.Join(types, ", ")))
+	} else if m[1] != strings.ToLower(m[1]) {
+		problems = append(problems, fmt.Sprintf("the type %q should be lowercase", m[1]))
+	}
+	if m[2] != "" && strings.TrimSpace(m[3]) == "" {
+		problems = append(problems, "the scope is empty")
+	}
+	switch {
+	case strings.TrimSpace(m[6]) == "":
+		problems = append(problems, "the description is empty")
+	case m[5] != " ":
+		problems = append(problems, "the description must follow \": \" with exactly one space")
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		problems = append(problems, "the body must be separated from the subject by a blank line")
+	}
+	return problems
+}
+
+// lintCommitMessages checks the commits in revRange, leaving out merge commits and the other
+// subjects written by git and hosting services
+func lintCommitMessages(revRange string, types []string) (linted []lintedCommit, total int, err error) {
+	// Messages are separated by NUL, which cannot appear in them
+	output, err := gitOutput("log", "--no-merges", "--format=%h%x1f%B%x00", revRange)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read commits in %s: %w", revRange, err)
+	}
+	for _, record := range strings.Split(output, "\x00") {
+		hash, message, ok := strings.Cut(strings.TrimLeft(record, "\n"), "\x1f")
+		if !ok {
+			continue
+		}
+		subject, _, _ := strings.Cut(strings.TrimSpace(normalizeNewlines(message)), "\n")
+		if generatedSubjectPattern.MatchString(subject) {
+			continue
+		}
+		total++
+		if problems := conventionalProblems(message, types); len(problems) > 0 {
+			linted = append(linted, lintedCommit{Hash: hash, Subject: subject, Problems: problems})
+		}
+	}
+	return linted, total, nil
+}
+
+// commitsLintRange returns the range to check: the argument, "<ref>..HEAD" for a single ref, or
+// the commits since the latest tag (all commits when there is none)
+func commitsLintRange(arg string) string {
+	switch {
+	case strings.Contains(arg, ".."):
+		return arg
+	case arg != "":
+		return arg + ".." + gitRefHEAD
+	}
+	if tag := getLatestTag(); tag != "" {
+		return tag + ".." + gitRefHEAD
+	}
+	return gitRefHEAD
+}
+
+// commitSuggestionPrompt asks for Conventional Commits messages for the commits that do not follow it
+func commitSuggestionPrompt(linted []lintedCommit, types []string) string {
+	var b strings.Builder
+	for _, c := range linted {
+		fmt.Fprintf(&b, "%s %s\n", c.Hash, c.Subject)
+		if files, err := gitOutput("show", "--name-status", "--format=", c.Hash); err == nil {
+			for _, line := range strings.Split(firstLines(aiFilter.filterNameStatus(strings.TrimSpace(files)), 20), "\n") {
+				if line != "" {
+					fmt.Fprintf(&b, "    %s\n", line)
+				}
+			}
+		}
+	}
+	return fmt.Sprintf(`以下のコミットはConventional Commitsの形式に従っていません。各コミットの件名と変更ファイルから、Conventional Commits形式の件名を提案してください。
+
+コミット（ハッシュ 件名、その下に変更ファイル）:
+---
+%s---
+
+使用できるtype: %s
+
+出力形式：
+- 1行に1コミットずつ「<ハッシュ>: <提案する件名>」の形式で出力してください
+- 件名は英語で、元の件名の意味を保ってください
+- 前置きや説明文は一切含めないでください`, b.String(), strings.Join(types, ", "))
+}
+
+// parseCommitSuggestions reads the "<hash>: <message>" lines of the AI's response
+func parseCommitSuggestions(response string) map[string]string {
+	suggestions := map[string]string{}
+	for _, line := range strings.Split(normalizeNewlines(response), "\n") {
+		if m := suggestionPattern.FindStringSubmatch(line); m != nil {
+			suggestions[strings.ToLower(m[1])] = strings.Trim(strings.TrimSpace(m[2]), "`")
+		}
+	}
+	return suggestions
+}
+
+// suggestionFor returns the suggestion for a commit, matching abbreviated hashes of either length
+func suggestionFor(suggestions map[string]string, hash string) string {
+	hash = strings.ToLower(hash)
+	for h, message := range suggestions {
+		if strings.HasPrefix(h, hash) || strings.HasPrefix(hash, h) {
+			return message
+		}
+	}
+	return ""
+}
+
+// commitsLintCommand reports the commits in a range that do not follow Conventional Commits
+func commitsLintCommand(args []string) error {
+	fs := flag.NewFlagSet("commits-lint", flag.ContinueOnError)
+	suggest := fs.Bool("suggest", false, "Have the AI suggest Conventional Commits messages for the reported commits")
+	model := fs.String("model", "claude", "AI model used by --suggest: claude, or mock[:file.md] for a canned response without AI access")
+	typesFlag := fs.String("types", strings.Join(conventionalTypes, ","), "Comma-separated commit types to accept")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update commits-lint [flags] [<range>]\n\n")
+		fmt.Fprintf(os.Stderr, "Reports commits that do not follow Conventional Commits, e.g. v1.0.0..HEAD\n")
+		fmt.Fprintf(os.Stderr, "(default: the commits since the latest tag). Merge, revert and fixup commits are not checked.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return &ConfigError{Err: fmt.Errorf("expected at most one range")}
+	}
+	var types []string
+	for _, t := range strings.Split(*typesFlag, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		return &ConfigError{Err: fmt.Errorf("--types must list at least one commit type")}
+	}
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		return err
+	}
+	aiFilter = newPathFilter(cfg.AIAllow, cfg.AIDeny)
+
+	revRange := commitsLintRange(fs.Arg(0))
+	linted, total, err := lintCommitMessages(revRange, types)
+	if err != nil {
+		return err
+	}
+	if len(linted) == 0 {
+		ui.Printf("✅ All %d commit(s) in %s follow Conventional Commits\n", total, revRange)
+		return nil
+	}
+
+	var suggestions map[string]string
+	if *suggest {
+		executor, err := newExecutor(*model)
+		if err != nil {
+			return err
+		}
+		spin := startSpinner(ui.Sprintf("Suggesting messages for %d commit(s)", len(linted)), !ui.plain)
+		response, err := executor.Execute(commitSuggestionPrompt(linted, types))
+		spin.Stop()
+		if err != nil {
+			ui.Printf("⚠️  Warning: Failed to get suggestions: %v\n", err)
+		} else {
+			suggestions = parseCommitSuggestions(response)
+		}
+	}
+
+	for _, c := range linted {
+		ui.Printf("❌ %s %s\n", c.Hash, c.Subject)
+		for _, p := range c.Problems {
+			ui.Printf("   → %s\n", p)
+		}
+		if s := suggestionFor(suggestions, c.Hash); s != "" {
+			ui.Printf("   💡 %s\n", s)
+		}
+	}
+	return fmt.Errorf("%d of %d commit(s) in %s do not follow Conventional Commits", len(linted), total, revRange)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConventionalProblems(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"feat: add export", ""},
+		{"fix(parser)!: reject empty input\n\nBREAKING CHANGE: empty input is an error", ""},
+		{"Add export", "not in the form"},
+		{"feature: add export", `unknown type "feature"`},
+		{"Feat: add export", "should be lowercase"},
+		{"fix(): typo", "the scope is empty"},
+		{"fix:", "the description is empty"},
+		{"fix:typo", "exactly one space"},
+		{"fix: typo\nmore details", "blank line"},
+	}
+	for _, tt := range tests {
+		problems := conventionalProblems(tt.message, conventionalTypes)
+		got := strings.Join(problems, "; ")
+		if (tt.want == "") != (len(problems) == 0) || !strings.Contains(got, tt.want) {
+			t.Errorf("conventionalProblems(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestParseCommitSuggestions(t *testing.T) {
+	suggestions := parseCommitSuggestions("以下が提案です\n- abc1234: feat(export): add HTML export\nDEF5678def: `fix: handle empty input`\n")
+	if got := suggestionFor(suggestions, "abc1234"); got != "feat(export): add HTML export" {
+		t.Errorf("suggestion for abc1234 = %q", got)
+	}
+	if got := suggestionFor(suggestions, "def5678"); got != "fix: handle empty input" {
+		t.Errorf("suggestion for def5678 = %q", got)
+	}
+	if got := suggestionFor(suggestions, "1234567"); got != "" {
+		t.Errorf("suggestion for an unknown commit = %q", got)
+	}
+}
+
+func TestLintCommitMessages(t *testing.T) {
+	newTestRepo(t)
+	for _, message := range []string{"feat: add export", "Update stuff", "Revert \"feat: add export\"", "fix(ci): pin go"} {
+		testCommit(t, message, map[string]string{"file.txt": message})
+	}
+
+	linted, total, err := lintCommitMessages(commitsLintRange(""), conventionalTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(linted) != 1 || linted[0].Subject != "Update stuff" || len(linted[0].Hash) < 7 {
+		t.Errorf("lintCommitMessages() = %+v of %d", linted, total)
+	}
+	if got := commitsLintRange("v1.0.0"); got != "v1.0.0..HEAD" {
+		t.Errorf("commitsLintRange(v1.0.0) = %q", got)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// completionSubcommands are the subcommand names offered by completion scripts; main passes them
+// in before running a subcommand, since completionCommand is one of the subcommands itself
+var completionSubcommands []string
+
+// completionShells are the shells completion scripts can be generated for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// tagValueFlags are the flags whose values are completed with the existing git tags
+var tagValueFlags = []string{"tag", "from", "to"}
+
+// completionFlag describes a command-line flag for completion scripts
+type completionFlag struct {
+	Name  string
+	Usage string
+	// TakesValue is false for boolean flags
+	TakesValue bool
+}
+
+// option returns the flag as typed on the command line: -x for single letters, --name otherwise
+func (f completionFlag) option() string {
+	if len(f.Name) == 1 {
+		return "-" + f.Name
+	}
+	return "--" + f.Name
+}
+
+// completionCommand prints the completion script for a shell
+func completionCommand(args []string) error {
+	fs := flag.NewFlagSet("completion", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update completion %s\n\n", strings.Join(completionShells, "|"))
+		fmt.Fprintf(os.Stderr, "Prints a shell completion script, e.g. source <(changelog-update completion bash).\n")
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one shell")
+	}
+
+	script, err := completionScript(fs.Arg(0), commandFlags(flag.CommandLine), completionSubcommands)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.WriteString(script)
+	return err
+}
+
+// commandFlags lists the flags defined in fs
+func commandFlags(fs *flag.FlagSet) []completionFlag {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		flags = append(flags, completionFlag{Name: f.Name, Usage: f.Usage, TakesValue: !ok || !boolFlag.IsBoolFlag()})
+	})
+	return flags
+}
+
+// subcommandNames returns the sorted names of commands
+func subcommandNames(commands map[string]func(args []string) error) []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// tagFlagOptions returns the spellings of the flags completed with git tags, e.g. --tag and -tag
+func tagFlagOptions() []string {
+	var options []string
+	for _, name := range tagValueFlags {
+		options = append(options, "--"+name, "-"+name)
+	}
+	return options
+}
+
+// completionScript renders the completion script for shell; git tags are looked up when completing
+func completionScript(shell string, flags []completionFlag, subs []string) (string, error) {
+	options := make([]string, 0, len(flags))
+	for _, f := range flags {
+		options = append(options, f.option())
+	}
+	tagOptions := tagFlagOptions()
+
+	switch shell {
+	case "bash":
+		return fmt.Sprintf(`# bash completion for changelog-update
+_changelog_update() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"
+    case "$prev" in
+        %s)
+            COMPREPLY=($(compgen -W "$(git tag --list 2>/dev/null)" -- "$cur"))
+            return ;;
+        completion)
+            COMPREPLY=($(compgen -W "%s" -- "$cur"))
+            return ;;
+    esac
+    if [[ $COMP_CWORD -eq 1 && $cur != -* ]]; then
+        COMPREPLY=($(compgen -W "%s" -- "$cur"))
+    elif [[ ${COMP_WORDS[1]} == diff && $cur != -* ]]; then
+        COMPREPLY=($(compgen -W "$(git tag --list 2>/dev/null)" -- "$cur"))
+    else
+        COMPREPLY=($(compgen -W "%s" -- "$cur"))
+    fi
+}
+complete -o default -F _changelog_update changelog-update
+`, strings.Join(tagOptions, "|"), strings.Join(completionShells, " "), strings.Join(subs, " "), strings.Join(options, " ")), nil
+
+	case "zsh":
+		return fmt.Sprintf(`#compdef changelog-update
+_changelog_update() {
+    case $words[CURRENT-1] in
+        %s)
+            compadd -- ${(f)"$(git tag --list 2>/dev/null)"}
+            return ;;
+        completion)
+            compadd -- %s
+            return ;;
+    esac
+    if [[ $CURRENT -eq 2 && $PREFIX != -* ]]; then
+        compadd -- %s
+    elif [[ $words[2] == diff && $PREFIX != -* ]]; then
+        compadd -- ${(f)"$(git tag --list 2>/dev/null)"}
+    else
+        compadd -- %s
+    fi
+}
+compdef _changelog_update changelog-update
+`, strings.Join(tagOptions, "|"), strings.Join(completionShells, " "), strings.Join(subs, " "), strings.Join(options, " ")), nil
+
+	case "fish":
+		var b strings.Builder
+		b.WriteString("# fish completion for changelog-update\n")
+		b.WriteString("complete -c changelog-update -f\n")
+		fmt.Fprintf(&b, "complete -c changelog-update -n __fish_use_subcommand -a '%s'\n", strings.Join(subs, " "))
+		fmt.Fprintf(&b, "complete -c changelog-update -n '__fish_seen_subcommand_from completion' -a '%s'\n", strings.Join(completionShells, " "))
+		b.WriteString("complete -c changelog-update -n '__fish_seen_subcommand_from diff' -a '(git tag --list 2>/dev/null)'\n")
+		for _, f := range flags {
+			kind := "-l"
+			if len(f.Name) == 1 {
+				kind = "-s"
+			}
+			line := fmt.Sprintf("complete -c changelog-update %s %s -d '%s'", kind, f.Name, fishQuote(f.Usage))
+			switch {
+			case slices.Contains(tagValueFlags, f.Name):
+				line += " -x -a '(git tag --list 2>/dev/null)'"
+			case f.TakesValue:
+				line += " -r"
+			}
+			b.WriteString(line + "\n")
+		}
+		return b.String(), nil
+
+	case "powershell":
+		return fmt.Sprintf(`# PowerShell completion for changelog-update
+Register-ArgumentCompleter -Native -CommandName changelog-update -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })
+    $prev = if ($wordToComplete) { $words[-2] } else { $words[-1] }
+    if ($prev -in @(%s)) {
+        $candidates = @(git tag --list 2>$null)
+    } elseif ($prev -eq 'completion') {
+        $candidates = @(%s)
+    } elseif ($words.Count -le 2 -and -not $wordToComplete.StartsWith('-')) {
+        $candidates = @(%s)
+    } elseif ($words[1] -eq 'diff' -and -not $wordToComplete.StartsWith('-')) {
+        $candidates = @(git tag --list 2>$null)
+    } else {
+        $candidates = @(%s)
+    }
+    $candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`, powershellList(tagOptions), powershellList(completionShells), powershellList(subs), powershellList(options)), nil
+	}
+	return "", &ConfigError{Err: fmt.Errorf("unsupported shell %q (expected %s)", shell, strings.Join(completionShells, ", "))}
+}
+
+// fishQuote escapes s for a single-quoted fish string
+func fishQuote(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+// powershellList renders words as a comma-separated list of single-quoted PowerShell strings
+func powershellList(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = "'" + strings.ReplaceAll(word, "'", "''") + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
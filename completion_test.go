@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCommandFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("tag", "", "New version tag")
+	fs.Bool("yes", false, "Accept all prompts")
+	fs.String("C", "", "Repository directory")
+
+	want := []completionFlag{
+		{Name: "C", Usage: "Repository directory", TakesValue: true},
+		{Name: "tag", Usage: "New version tag", TakesValue: true},
+		{Name: "yes", Usage: "Accept all prompts"},
+	}
+	if got := commandFlags(fs); !reflect.DeepEqual(got, want) {
+		t.Errorf("commandFlags() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompletionScript(t *testing.T) {
+	flags := []completionFlag{{Name: "tag", TakesValue: true}, {Name: "yes", Usage: "Accept 'all' prompts"}, {Name: "m", TakesValue: true}}
+	subs := []string{"diff", "export"}
+
+	tests := []struct {
+		shell string
+		want  []string
+	}{
+		{"bash", []string{"--tag|-tag|--from|-from|--to|-to)", "git tag --list", `compgen -W "diff export"`, `compgen -W "--tag --yes -m"`, "complete -o default -F _changelog_update changelog-update"}},
+		{"zsh", []string{"#compdef changelog-update", "compadd -- --tag --yes -m", "compdef _changelog_update changelog-update"}},
+		{"fish", []string{"-l tag -d '' -x -a '(git tag --list 2>/dev/null)'", `-l yes -d 'Accept \'all\' prompts'`, "-s m -d '' -r", "__fish_use_subcommand -a 'diff export'"}},
+		{"powershell", []string{"Register-ArgumentCompleter -Native -CommandName changelog-update", "@('--tag', '--yes', '-m')", "@(git tag --list 2>$null)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			script, err := completionScript(tt.shell, flags, subs)
+			if err != nil {
+				t.Fatalf("completionScript() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(script, want) {
+					t.Errorf("script does not contain %q:\n%s", want, script)
+				}
+			}
+		})
+	}
+
+	if _, err := completionScript("tcsh", flags, subs); exitCode(err) != ExitConfig {
+		t.Errorf("completionScript(tcsh) error = %v, want a config error", err)
+	}
+}
+
+func TestSubcommandNames(t *testing.T) {
+	run := func([]string) error { return nil }
+	got := subcommandNames(map[string]func(args []string) error{"watch": run, "completion": run, "diff": run})
+	if want := []string{"completion", "diff", "watch"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subcommandNames() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// defaultConfigFile is the configuration file looked up in the repository root
+const defaultConfigFile = ".changelog-update.json"
+
+// config holds settings loaded from the configuration file
+type config struct {
+	// AIAllow, when non-empty, limits the files sent to the AI to paths matching these patterns
+	AIAllow []string `json:"ai_allow,omitempty"`
+	// AIDeny excludes paths matching these patterns from anything sent to the AI
+	AIDeny []string `json:"ai_deny,omitempty"`
+	// LabelSections maps PR labels to changelog sections, overriding the defaults used by --pr-labels
+	LabelSections map[string]string `json:"label_sections,omitempty"`
+	// HeadingFormat is the version heading template with {version} and {date} placeholders
+	HeadingFormat string `json:"heading_format,omitempty"`
+	// DateFormat is the Go reference layout used for {date} in version headings
+	DateFormat string `json:"date_format,omitempty"`
+	// ListStyle is the list marker and indentation of generated entries
+	ListStyle listStyleConfig `json:"list_style,omitempty"`
+	// Outputs are additional files updated together with the changelog
+	Outputs []outputTarget `json:"outputs,omitempty"`
+	// FrontMatter customizes the front matter of per-version pages written with front_matter or --split-dir
+	FrontMatter *frontMatterConfig `json:"front_matter,omitempty"`
+	// EntryTemplate is a Go text/template file used to render entries generated with --structured
+	EntryTemplate string `json:"entry_template,omitempty"`
+	// Jira maps releases to a Jira project for --jira-release
+	Jira *jiraConfig `json:"jira,omitempty"`
+	// Spec holds the structural rules used by --spec custom
+	Spec *specConfig `json:"spec,omitempty"`
+	// CredentialHelper is a command that prints the credential named by its last argument
+	CredentialHelper string `json:"credential_helper,omitempty"`
+}
+
+// configPath returns the explicit config path, or the default file in the repository directory
+func configPath(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	return filepath.Join(gitDir, defaultConfigFile)
+}
+
+// loadConfig reads the configuration file; a missing file is an error only when explicit is true
+func loadConfig(path string, explicit bool) (*config, error) {
+	cfg := &config{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return cfg, nil
+		}
+		return nil, &ConfigError{Err: fmt.Errorf("failed to read config %s: %w", path, err)}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, &ConfigError{Err: fmt.Errorf("failed to parse config %s: %w", path, err)}
+	}
+	for i, target := range cfg.Outputs {
+		if err := validOutputPath(target.Path); err != nil {
+			return nil, &ConfigError{Err: fmt.Errorf("invalid config %s: outputs[%d].path: %w", path, i, err)}
+		}
+	}
+	return cfg, nil
+}
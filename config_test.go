@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing default file", func(t *testing.T) {
+		cfg, err := loadConfig(dir+"/missing.json", false)
+		if err != nil || cfg == nil {
+			t.Fatalf("loadConfig() = %v, %v; want empty config", cfg, err)
+		}
+	})
+
+	t.Run("missing explicit file", func(t *testing.T) {
+		if _, err := loadConfig(dir+"/missing.json", true); err == nil {
+			t.Error("loadConfig() with explicit missing file should fail")
+		}
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		path := dir + "/valid.json"
+		if err := os.WriteFile(path, []byte(`{"ai_deny": ["secrets/", "*.pem"]}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := loadConfig(path, true)
+		if err != nil {
+			t.Fatalf("loadConfig() error = %v", err)
+		}
+		if len(cfg.AIDeny) != 2 || cfg.AIDeny[1] != "*.pem" {
+			t.Errorf("AIDeny = %v", cfg.AIDeny)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := dir + "/typo.json"
+		if err := os.WriteFile(path, []byte(`{"ai_denny": ["secrets/"]}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadConfig(path, true); err == nil {
+			t.Error("loadConfig() should reject unknown fields")
+		}
+	})
+	t.Run("output paths outside the repository", func(t *testing.T) {
+		for _, outputs := range []string{`[{"path": ""}]`, `[{"path": "/etc/x.md"}]`, `[{"path": "../../x.md"}]`, `[{"path": "docs/../../x.md"}]`} {
+			path := dir + "/outputs.json"
+			if err := os.WriteFile(path, []byte(`{"outputs": `+outputs+`}`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := loadConfig(path, true); err == nil {
+				t.Errorf("loadConfig() with outputs %s should fail", outputs)
+			}
+		}
+		if err := os.WriteFile(dir+"/outputs.json", []byte(`{"outputs": [{"path": "docs/releases/{version}.md"}]}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadConfig(dir+"/outputs.json", true); err != nil {
+			t.Errorf("loadConfig() with a relative output path error = %v", err)
+		}
+	})
+}
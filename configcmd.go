@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// configSetting is one effective setting printed by config show
+type configSetting struct {
+	Name   string
+	Value  string
+	Source string
+	// Note names the flags that override or enable the setting
+	Note string
+}
+
+// configKeyNotes explain how flags interact with a configuration key
+var configKeyNotes = map[string]string{
+	"label_sections":    "used with --pr-labels",
+	"heading_format":    "--version-style adds or drops the v prefix",
+	"list_style":        "--bullet, --indent and --sub-bullets override it",
+	"outputs":           "--split-dir adds a target",
+	"front_matter":      "used by --split-dir and outputs with front_matter",
+	"entry_template":    "implies --structured",
+	"jira":              "used with --jira-release",
+	"spec":              "used with --spec custom",
+	"credential_helper": "environment variables take precedence",
+}
+
+// configKeyDefaults are the values used for keys missing from the configuration file
+var configKeyDefaults = map[string]any{
+	"heading_format": defaultHeadingTemplate,
+	"date_format":    defaultDateLayout,
+}
+
+// configKeys returns the JSON keys of the configuration file in declaration order
+func configKeys() []string {
+	t := reflect.TypeOf(config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+// fileSettings returns every configuration key with its effective value and whether path sets it;
+// the file is validated like the main command does
+func fileSettings(path string, explicit bool) ([]configSetting, error) {
+	if _, err := loadConfig(path, explicit); err != nil {
+		return nil, err
+	}
+	raw := map[string]json.RawMessage{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, &ConfigError{Err: fmt.Errorf("failed to parse config %s: %w", path, err)}
+		}
+	}
+
+	var settings []configSetting
+	for _, key := range configKeys() {
+		setting := configSetting{Name: key, Value: "-", Source: "default", Note: configKeyNotes[key]}
+		if value, ok := raw[key]; ok {
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, value); err == nil {
+				setting.Value = compact.String()
+			}
+			setting.Source = path
+		} else if value, ok := configKeyDefaults[key]; ok {
+			encoded, _ := json.Marshal(value)
+			setting.Value = string(encoded)
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+// firstEnv returns the name and value of the first of names that is set
+func firstEnv(names ...string) (string, string) {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return name, value
+		}
+	}
+	return "", ""
+}
+
+// environmentSettings returns the settings taken from environment variables; secrets are only
+// reported as set
+func environmentSettings() []configSetting {
+	setting := func(name, fallback, note string, secret bool, vars ...string) configSetting {
+		s := configSetting{Name: name, Value: fallback, Source: "default", Note: note}
+		if env, value := firstEnv(vars...); env != "" {
+			s.Value, s.Source = value, env
+			if secret {
+				s.Value = "(set)"
+			}
+		}
+		return s
+	}
+
+	lang := setting("ui language", uiLangEnglish, "--ui-lang overrides it", false, "LC_ALL", "LC_MESSAGES", "LANG")
+	lang.Value = detectUILang()
+	date := setting("release date", "today", "used with --record and --replay; --date overrides it", false, "SOURCE_DATE_EPOCH")
+	if epoch, ok := sourceDateEpoch(); ok {
+		date.Value = inReleaseLocation(epoch).Format(time.DateOnly)
+	}
+	colors := setting("colors", "auto", "--no-color turns them off", false, "NO_COLOR")
+	if colors.Source != "default" {
+		colors.Value = "off"
+	}
+	settings := []configSetting{
+		setting(anthropicAPIKey, "-", "otherwise read from credential_helper or the OS keychain", true, anthropicAPIKey),
+		setting("notify webhook", "-", "--notify-webhook overrides it", true, "CHANGELOG_NOTIFY_WEBHOOK"),
+		setting("webhook secret", "-", "serve --secret overrides it", true, "CHANGELOG_WEBHOOK_SECRET"),
+		lang,
+		colors,
+		date,
+		setting("editor", "vi", "used to edit entries during catch-up review", false, "VISUAL", "EDITOR"),
+	}
+
+	// Flags of the main command set by CHANGELOG_UPDATE_ variables
+	sources := envFlagValues(flag.CommandLine)
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := os.Getenv(sources[name])
+		if slices.Contains(secretFlags, name) {
+			value = "(set)"
+		}
+		settings = append(settings, configSetting{Name: "--" + name, Value: value, Source: sources[name], Note: "the flag on the command line overrides it"})
+	}
+	return settings
+}
+
+// writeConfigSettings prints settings as an aligned table
+func writeConfigSettings(out io.Writer, title string, settings []configSetting) {
+	fmt.Fprintf(out, "%s\n\n", title)
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SETTING\tVALUE\tSOURCE\tNOTE")
+	for _, s := range settings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, s.Value, s.Source, s.Note)
+	}
+	w.Flush()
+}
+
+// configCommand validates the configuration file or shows the effective settings with their sources
+func configCommand(args []string) error {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	specName := fs.String("spec", specSimple, "With validate, the --spec the configuration is used with: keepachangelog-1.1, simple or custom")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update config [flags] validate|show\n\n")
+		fmt.Fprintf(os.Stderr, "validate checks the configuration file like the main command does, without running it.\n")
+		fmt.Fprintf(os.Stderr, "show prints every setting with its effective value and where it comes from: the\n")
+		fmt.Fprintf(os.Stderr, "configuration file, an environment variable or the default.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || (fs.Arg(0) != "validate" && fs.Arg(0) != "show") {
+		fs.Usage()
+		return &ConfigError{Err: fmt.Errorf("expected validate or show")}
+	}
+	path := configPath(*configFile)
+
+	if fs.Arg(0) == "validate" {
+		r, cfg := checkConfig(path, *configFile != "")
+		if r.Status == checkOK {
+			var custom *specConfig
+			if cfg != nil {
+				custom = cfg.Spec
+			}
+			if err := setSpec(*specName, custom); err != nil {
+				r.Status, r.Detail, r.Fix = checkFail, err.Error(), "Add a \"spec\" section with the sections of your changelog"
+			}
+		}
+		printCheck(r)
+		if r.Status == checkFail {
+			return &ConfigError{Err: fmt.Errorf("%s is invalid", path)}
+		}
+		return nil
+	}
+
+	settings, err := fileSettings(path, *configFile != "")
+	if err != nil {
+		return err
+	}
+	title := "Configuration file: " + path
+	if _, err := os.Stat(path); err != nil {
+		title += " (not found, using defaults)"
+	}
+	writeConfigSettings(os.Stdout, title, settings)
+	fmt.Println()
+	writeConfigSettings(os.Stdout, "Environment", environmentSettings())
+	fmt.Println()
+	fmt.Println("Flags of the main command override these settings for a single run.")
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConfigKeys(t *testing.T) {
+	keys := configKeys()
+	for _, key := range []string{"ai_allow", "heading_format", "list_style", "front_matter", "credential_helper"} {
+		if !slices.Contains(keys, key) {
+			t.Errorf("configKeys() = %v, missing %s", keys, key)
+		}
+	}
+}
+
+func TestFileSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"list_style": {"bullet": "*", "indent": 4}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := fileSettings(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]configSetting{}
+	for _, s := range settings {
+		byName[s.Name] = s
+	}
+	if s := byName["list_style"]; s.Value != `{"bullet":"*","indent":4}` || s.Source != path || !strings.Contains(s.Note, "--bullet") {
+		t.Errorf("list_style = %+v", s)
+	}
+	if s := byName["heading_format"]; s.Value != `"## [{version}] - {date}"` || s.Source != "default" {
+		t.Errorf("heading_format = %+v", s)
+	}
+	if s := byName["jira"]; s.Value != "-" || s.Source != "default" {
+		t.Errorf("jira = %+v", s)
+	}
+
+	if _, err := fileSettings(filepath.Join(t.TempDir(), "missing.json"), false); err != nil {
+		t.Errorf("fileSettings(missing) = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"list_styel": {}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fileSettings(path, true); err == nil {
+		t.Error("fileSettings() accepted an unknown key")
+	}
+}
+
+func TestEnvironmentSettings(t *testing.T) {
+	t.Setenv("CHANGELOG_NOTIFY_WEBHOOK", "https://hooks.example.com/secret")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano")
+	byName := map[string]configSetting{}
+	for _, s := range environmentSettings() {
+		byName[s.Name] = s
+	}
+	if s := byName["notify webhook"]; s.Value != "(set)" || s.Source != "CHANGELOG_NOTIFY_WEBHOOK" {
+		t.Errorf("notify webhook = %+v, want the secret hidden", s)
+	}
+	if s := byName["editor"]; s.Value != "nano" || s.Source != "EDITOR" {
+		t.Errorf("editor = %+v", s)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// readmeContextLines is the number of README lines included as project context by default
+const readmeContextLines = 40
+
+// readmeNames are the README files looked up in the repository root, in order of preference
+var readmeNames = []string{"README.md", "README", "README.rst", "README.txt", "readme.md"}
+
+// projectContext describes what the project is, for the generation prompts; empty disables it
+var projectContext string
+
+// loadProjectContext sets the project context from contextFile, or, when it is empty, from the
+// module name and the beginning of the README in the repository
+func loadProjectContext(contextFile string) error {
+	if contextFile != "" {
+		data, err := os.ReadFile(contextFile)
+		if err != nil {
+			return &ConfigError{Err: fmt.Errorf("cannot read context file: %w", err)}
+		}
+		projectContext = strings.TrimSpace(normalizeNewlines(string(data)))
+		return nil
+	}
+
+	var parts []string
+	if module := moduleName(); module != "" {
+		parts = append(parts, "モジュール: "+module)
+	}
+	for _, name := range readmeNames {
+		data, err := os.ReadFile(repoPath(name))
+		if err != nil {
+			continue
+		}
+		parts = append(parts, firstLines(normalizeNewlines(string(data)), readmeContextLines))
+		break
+	}
+	projectContext = strings.TrimSpace(strings.Join(parts, "\n\n"))
+	return nil
+}
+
+// moduleName returns the Go module path or the npm package name of the repository, if any
+func moduleName() string {
+	if data, err := os.ReadFile(repoPath("go.mod")); err == nil {
+		for _, line := range strings.Split(normalizeNewlines(string(data)), "\n") {
+			if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+				return strings.Trim(strings.TrimSpace(module), `"`)
+			}
+		}
+	}
+	if data, err := os.ReadFile(repoPath("package.json")); err == nil {
+		var pkg struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			return pkg.Name
+		}
+	}
+	return ""
+}
+
+// firstLines returns the first n lines of text
+func firstLines(text string, n int) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// projectContextSection formats the project context for the generation prompts
+func projectContextSection() string {
+	if projectContext == "" {
+		return ""
+	}
+	return fmt.Sprintf(`
+
+プロジェクトの概要（ライブラリ・CLI・サービスなどの性質に合った書き方で、利用者の視点から記載してください）:
+---
+%s
+---`, projectContext)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadProjectContext(t *testing.T) {
+	savedDir, savedContext := gitDir, projectContext
+	defer func() { gitDir, projectContext = savedDir, savedContext }()
+
+	dir := t.TempDir()
+	gitDir = dir
+	readme := "# Tool\r\n\r\nA CLI that does things.\r\n" + strings.Repeat("more\r\n", readmeContextLines)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/tool\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadProjectContext(""); err != nil {
+		t.Fatalf("loadProjectContext() error = %v", err)
+	}
+	if !strings.HasPrefix(projectContext, "モジュール: example.com/tool\n\n# Tool\n\nA CLI that does things.") {
+		t.Errorf("projectContext = %q", projectContext)
+	}
+	if lines := strings.Count(projectContext, "\n") + 1; lines != readmeContextLines+2 {
+		t.Errorf("projectContext has %d lines, want the module line, a blank line and %d README lines", lines, readmeContextLines)
+	}
+
+	contextFile := filepath.Join(dir, "context.md")
+	if err := os.WriteFile(contextFile, []byte("社内向けの決済API\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadProjectContext(contextFile); err != nil {
+		t.Fatalf("loadProjectContext(file) error = %v", err)
+	}
+	if section := projectContextSection(); !strings.Contains(section, "---\n社内向けの決済API\n---") {
+		t.Errorf("projectContextSection() = %q", section)
+	}
+
+	if err := loadProjectContext(filepath.Join(dir, "missing.md")); exitCode(err) != ExitConfig {
+		t.Errorf("loadProjectContext(missing) error = %v, want a config error", err)
+	}
+}
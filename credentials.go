@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// keychainService is the service name under which credentials are stored in the OS keychain
+const keychainService = "changelog-update"
+
+// credentialHelper is the command used to look up credentials; the credential name is passed as
+// its last argument and the secret is read from its standard output
+var credentialHelper string
+
+var (
+	credentialMu    sync.Mutex
+	credentialCache = map[string]string{}
+)
+
+// lookupCredential returns the named credential from the environment, the credential helper or
+// the OS keychain, in that order; an empty string means it was not found
+func lookupCredential(name string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	credentialMu.Lock()
+	defer credentialMu.Unlock()
+	if value, ok := credentialCache[name]; ok {
+		return value
+	}
+
+	var value string
+	if credentialHelper != "" {
+		v, err := helperCredential(credentialHelper, name)
+		if err != nil {
+			ui.Printf("⚠️  Warning: Credential helper failed for %s: %v\n", name, err)
+		}
+		value = v
+	}
+	if value == "" {
+		// A missing keychain entry is the common case and not worth a warning
+		value, _ = keychainCredential(name)
+	}
+	credentialCache[name] = value
+	return value
+}
+
+// helperCredential runs the credential helper for name
+func helperCredential(helper, name string) (string, error) {
+	fields := strings.Fields(helper)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty credential helper")
+	}
+	cmd := exec.Command(fields[0], append(fields[1:], name)...)
+	cmd.Dir = gitDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// keychainCredential reads name from the macOS Keychain, the Secret Service (secret-tool) or the
+// Windows Credential Manager (CredentialManager PowerShell module); replaced in tests
+var keychainCredential = func(name string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keychainService, "-a", name, "-w")
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-Command",
+			fmt.Sprintf("(Get-StoredCredential -Target '%s:%s').GetNetworkCredential().Password", keychainService, name))
+	default:
+		cmd = exec.Command("secret-tool", "lookup", "service", keychainService, "account", name)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupCredential(t *testing.T) {
+	savedHelper, savedKeychain, savedCache := credentialHelper, keychainCredential, credentialCache
+	defer func() {
+		credentialHelper, keychainCredential, credentialCache = savedHelper, savedKeychain, savedCache
+	}()
+
+	keychainCalls := 0
+	keychainCredential = func(name string) (string, error) {
+		keychainCalls++
+		if name == "FROM_KEYCHAIN" {
+			return "keychain-secret", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	t.Run("environment wins", func(t *testing.T) {
+		credentialCache = map[string]string{}
+		t.Setenv("FROM_ENV", "env-secret")
+		if got := lookupCredential("FROM_ENV"); got != "env-secret" {
+			t.Errorf("lookupCredential() = %q", got)
+		}
+	})
+
+	t.Run("credential helper receives the name", func(t *testing.T) {
+		credentialCache = map[string]string{}
+		credentialHelper = "echo helper"
+		if got := lookupCredential("FROM_HELPER"); got != "helper FROM_HELPER" {
+			t.Errorf("lookupCredential() = %q", got)
+		}
+	})
+
+	t.Run("keychain fallback is cached", func(t *testing.T) {
+		credentialCache = map[string]string{}
+		credentialHelper = ""
+		keychainCalls = 0
+		for i := 0; i < 2; i++ {
+			if got := lookupCredential("FROM_KEYCHAIN"); got != "keychain-secret" {
+				t.Errorf("lookupCredential() = %q", got)
+			}
+		}
+		if got := lookupCredential("MISSING"); got != "" {
+			t.Errorf("lookupCredential(MISSING) = %q", got)
+		}
+		if keychainCalls != 2 {
+			t.Errorf("keychain looked up %d times, want 2", keychainCalls)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// releaseDateOverride replaces the date of new entries when set with --date
+var releaseDateOverride time.Time
+
+// fixtureDates makes SOURCE_DATE_EPOCH fix the date of new entries; it is set with --record and
+// --replay, so prompts recorded on one day match again on a later day
+var fixtureDates bool
+
+// releaseLocation is the time zone release dates are given in, set with --timezone; nil keeps the
+// local time zone for new entries and the tagger's time zone for existing tags
+var releaseLocation *time.Location
+
+// setReleaseDate configures the time zone and the date override from --timezone and --date
+func setReleaseDate(date, timezone string) error {
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return &ConfigError{Err: fmt.Errorf("invalid --timezone %q: %w", timezone, err)}
+		}
+		releaseLocation = loc
+	}
+	if date != "" {
+		loc := releaseLocation
+		if loc == nil {
+			loc = time.Local
+		}
+		d, err := time.ParseInLocation(time.DateOnly, date, loc)
+		if err != nil {
+			return &ConfigError{Err: fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", date)}
+		}
+		releaseDateOverride = d
+	}
+	return nil
+}
+
+// releaseDate is the date of a new entry: the --date override, SOURCE_DATE_EPOCH when recording or
+// replaying responses, or the current time, in the --timezone time zone
+func releaseDate() time.Time {
+	if !releaseDateOverride.IsZero() {
+		return releaseDateOverride
+	}
+	if epoch, ok := sourceDateEpoch(); ok && fixtureDates {
+		return inReleaseLocation(epoch)
+	}
+	return inReleaseLocation(time.Now())
+}
+
+// sourceDateEpoch returns the time set by SOURCE_DATE_EPOCH
+func sourceDateEpoch() (time.Time, bool) {
+	epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(epoch, 0), true
+}
+
+// inReleaseLocation converts t to the configured release time zone
+func inReleaseLocation(t time.Time) time.Time {
+	if releaseLocation == nil {
+		return t
+	}
+	return t.In(releaseLocation)
+}
+
+// getTagDate returns when tag was made: the tagger date of an annotated tag, or the commit date of a
+// lightweight tag, in the --timezone time zone
+func getTagDate(tag string) (time.Time, error) {
+	// creatordate is the tagger date of a tag object and the committer date of a commit
+	output, err := gitOutput("for-each-ref", "--format=%(creatordate:iso-strict)", tagRefPrefix+tag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	dateStr := strings.TrimSpace(output)
+	if dateStr == "" {
+		return time.Time{}, fmt.Errorf("no date found for tag %s", tag)
+	}
+
+	date, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format for tag %s: %w", tag, err)
+	}
+	return inReleaseLocation(date), nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetReleaseDate(t *testing.T) {
+	defer func() { releaseDateOverride, releaseLocation = time.Time{}, nil }()
+
+	if err := setReleaseDate("2025-09-01", "Asia/Tokyo"); err != nil {
+		t.Fatal(err)
+	}
+	got := releaseDate()
+	if got.Format(time.DateOnly) != "2025-09-01" || got.Location().String() != "Asia/Tokyo" {
+		t.Errorf("releaseDate() = %v, want 2025-09-01 in Asia/Tokyo", got)
+	}
+
+	// 2025-08-31 23:30 UTC is already September 1 in Tokyo
+	tagged := time.Date(2025, 8, 31, 23, 30, 0, 0, time.UTC)
+	if got := changelogHeading.FormatDate(inReleaseLocation(tagged)); got != "2025-09-01" {
+		t.Errorf("FormatDate(inReleaseLocation()) = %s, want 2025-09-01", got)
+	}
+
+	var configErr *ConfigError
+	if err := setReleaseDate("09/01/2025", ""); !errors.As(err, &configErr) {
+		t.Errorf("setReleaseDate() with an invalid date = %v, want a ConfigError", err)
+	}
+	if err := setReleaseDate("", "Mars/Olympus"); !errors.As(err, &configErr) {
+		t.Errorf("setReleaseDate() with an invalid time zone = %v, want a ConfigError", err)
+	}
+}
+
+func TestReleaseDate(t *testing.T) {
+	defer func() { fixtureDates = false }()
+	t.Setenv("SOURCE_DATE_EPOCH", "1756684800")
+	if got := releaseDate().UTC(); got.Equal(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("releaseDate() without --record or --replay = %v, want the current time", got)
+	}
+	fixtureDates = true
+	if got := releaseDate().UTC(); !got.Equal(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("releaseDate() = %v, want 2025-09-01", got)
+	}
+}
+
+func TestReleaseDateUsesTimezoneForSourceDateEpoch(t *testing.T) {
+	defer func() { releaseLocation, fixtureDates = nil, false }()
+	t.Setenv("SOURCE_DATE_EPOCH", "1756683000") // 2025-08-31 23:30 UTC
+	fixtureDates = true
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("time zone data is not available")
+	}
+	releaseLocation = tokyo
+	if got := changelogHeading.FormatDate(releaseDate()); got != "2025-09-01" {
+		t.Errorf("releaseDate() = %s, want 2025-09-01", got)
+	}
+}
+
+func TestGetTagDate(t *testing.T) {
+	newTestRepo(t)
+	git := func(date string, args ...string) {
+		t.Helper()
+		t.Setenv("GIT_AUTHOR_DATE", date)
+		t.Setenv("GIT_COMMITTER_DATE", date)
+		testGit(t, args...)
+	}
+	git("2025-01-01T10:00:00+00:00", "commit", "--quiet", "--allow-empty", "-m", "init")
+	git("2025-01-01T10:00:00+00:00", "tag", "v1.0.0")
+	git("2025-03-01T09:00:00+09:00", "tag", "-a", "v1.1.0", "-m", "v1.1.0")
+
+	testCases := map[string]time.Time{
+		"v1.0.0": time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		"v1.1.0": time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for tag, want := range testCases {
+		if got, err := getTagDate(tag); err != nil || !got.Equal(want) {
+			t.Errorf("getTagDate(%s) = %v, %v; want %v", tag, got, err, want)
+		}
+	}
+	if _, err := getTagDate("v9.9.9"); err == nil {
+		t.Error("getTagDate() of a missing tag should fail")
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// dependencySectionTitle is the heading of the deterministic dependency section
+const dependencySectionTitle = "### 依存関係"
+
+var (
+	goModRequirePattern = regexp.MustCompile(`^\s*(?:require\s+)?([^\s()]+)\s+(v[^\s]+)`)
+	requirementPattern  = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*(==|>=|~=|<=|>|<|!=)\s*([^\s;#,]+)`)
+)
+
+// dependencyChange is a dependency added, removed or updated in a manifest
+type dependencyChange struct {
+	Manifest string
+	Name     string
+	From     string
+	To       string
+}
+
+// manifestParsers extract dependency versions from supported manifest files, keyed by base name
+var manifestParsers = map[string]func(content string) map[string]string{
+	"go.mod":           parseGoMod,
+	"package.json":     parsePackageJSON,
+	"requirements.txt": parseRequirements,
+}
+
+// detectDependencyChanges compares dependency manifests of the selected module changed between from and to
+func detectDependencyChanges(from, to string) ([]dependencyChange, error) {
+	diffFrom := from
+	if diffFrom == "" || diffFrom == gitRefHEAD {
+		diffFrom = emptyTreeHash
+	}
+	output, err := gitCommand(append([]string{"diff", "--name-only", diffFrom, to}, modulePathspec()...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	var changes []dependencyChange
+	for _, file := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parse, ok := manifestParsers[path.Base(file)]
+		if !ok || !aiFilter.allowed(file) {
+			continue
+		}
+		before := map[string]string{}
+		if diffFrom != emptyTreeHash {
+			before = parse(gitShowFile(from, file))
+		}
+		after := parse(gitShowFile(to, file))
+		changes = append(changes, diffDependencies(file, before, after)...)
+	}
+	return changes, nil
+}
+
+// gitShowFile returns the content of file at rev, or "" when it does not exist there
+func gitShowFile(rev, file string) string {
+	output, err := gitCommand("show", rev+":"+file).Output()
+	if err != nil {
+		return ""
+	}
+	return string(output)
+}
+
+// diffDependencies lists the differences between two dependency version maps, sorted by name
+func diffDependencies(manifest string, before, after map[string]string) []dependencyChange {
+	var changes []dependencyChange
+	for name, version := range after {
+		if old, ok := before[name]; !ok || old != version {
+			changes = append(changes, dependencyChange{Manifest: manifest, Name: name, From: before[name], To: version})
+		}
+	}
+	for name, version := range before {
+		if _, ok := after[name]; !ok {
+			changes = append(changes, dependencyChange{Manifest: manifest, Name: name, From: version})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+func parseGoMod(content string) map[string]string {
+	deps := map[string]string{}
+	inRequire := false
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "require ("):
+			inRequire = true
+		case inRequire && trimmed == ")":
+			inRequire = false
+		case inRequire || strings.HasPrefix(trimmed, "require "):
+			if m := goModRequirePattern.FindStringSubmatch(trimmed); m != nil {
+				deps[m[1]] = m[2]
+			}
+		}
+	}
+	return deps
+}
+
+func parsePackageJSON(content string) map[string]string {
+	var pkg map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &pkg); err != nil {
+		return map[string]string{}
+	}
+	deps := map[string]string{}
+	for _, key := range []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies"} {
+		var section map[string]string
+		if raw, ok := pkg[key]; ok && json.Unmarshal(raw, &section) == nil {
+			for name, version := range section {
+				deps[name] = version
+			}
+		}
+	}
+	return deps
+}
+
+func parseRequirements(content string) map[string]string {
+	deps := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		if m := requirementPattern.FindStringSubmatch(scanner.Text()); m != nil {
+			version := m[3]
+			if m[2] != "==" {
+				version = m[2] + m[3]
+			}
+			deps[strings.ToLower(m[1])] = version
+		}
+	}
+	return deps
+}
+
+// renderDependencySection formats dependency changes as a CHANGELOG section
+func renderDependencySection(changes []dependencyChange) string {
+	lines := []string{dependencySectionTitle, ""}
+	for _, c := range changes {
+		switch {
+		case c.From == "":
+			lines = append(lines, fmt.Sprintf("- `%s` %s を追加", c.Name, c.To))
+		case c.To == "":
+			lines = append(lines, fmt.Sprintf("- `%s` を削除", c.Name))
+		default:
+			lines = append(lines, fmt.Sprintf("- `%s` を %s から %s に更新", c.Name, c.From, c.To))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hasDependencyChanges reports whether appendDependencySection will list dependency updates for
+// the range from..to; a failed detection is reported there
+func hasDependencyChanges(from, to string) bool {
+	changes, err := detectDependencyChanges(from, to)
+	return err == nil && len(changes) > 0
+}
+
+// appendDependencySection adds the deterministic dependency section to an entry
+func appendDependencySection(entry, from, to string) string {
+	if !genOpts.DependencySection {
+		return entry
+	}
+	changes, err := detectDependencyChanges(from, to)
+	if err != nil {
+		ui.Printf("⚠️  Warning: Failed to detect dependency updates: %v\n", err)
+		return entry
+	}
+	if len(changes) == 0 {
+		return entry
+	}
+	return strings.TrimRight(entry, "\n") + "\n\n" + renderDependencySection(changes)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGoMod(t *testing.T) {
+	content := `module example.com/app
+
+go 1.21
+
+require github.com/single/dep v1.0.0
+
+require (
+	github.com/a/b v1.2.3 // indirect
+	golang.org/x/text v0.14.0
+)
+`
+	want := map[string]string{
+		"github.com/single/dep": "v1.0.0",
+		"github.com/a/b":        "v1.2.3",
+		"golang.org/x/text":     "v0.14.0",
+	}
+	if got := parseGoMod(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGoMod() = %v, want %v", got, want)
+	}
+}
+
+func TestParsePackageJSON(t *testing.T) {
+	content := `{"name":"app","dependencies":{"react":"^18.2.0"},"devDependencies":{"jest":"29.0.0"}}`
+	want := map[string]string{"react": "^18.2.0", "jest": "29.0.0"}
+	if got := parsePackageJSON(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePackageJSON() = %v, want %v", got, want)
+	}
+	if got := parsePackageJSON("not json"); len(got) != 0 {
+		t.Errorf("parsePackageJSON(invalid) = %v, want empty", got)
+	}
+}
+
+func TestParseRequirements(t *testing.T) {
+	content := "# comment\nDjango==4.2.1\nrequests>=2.31 ; python_version > '3.8'\nuvicorn[standard]==0.23.0\n-e .\n"
+	want := map[string]string{"django": "4.2.1", "requests": ">=2.31", "uvicorn": "0.23.0"}
+	if got := parseRequirements(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseRequirements() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffDependenciesAndRender(t *testing.T) {
+	before := map[string]string{"a": "v1.0.0", "b": "v2.0.0", "c": "v1.0.0"}
+	after := map[string]string{"a": "v1.1.0", "c": "v1.0.0", "d": "v0.1.0"}
+
+	changes := diffDependencies("go.mod", before, after)
+	want := []dependencyChange{
+		{Manifest: "go.mod", Name: "a", From: "v1.0.0", To: "v1.1.0"},
+		{Manifest: "go.mod", Name: "b", From: "v2.0.0"},
+		{Manifest: "go.mod", Name: "d", To: "v0.1.0"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("diffDependencies() = %+v, want %+v", changes, want)
+	}
+
+	wantSection := "### 依存関係\n\n- `a` を v1.0.0 から v1.1.0 に更新\n- `b` を削除\n- `d` v0.1.0 を追加"
+	if got := renderDependencySection(changes); got != wantSection {
+		t.Errorf("renderDependencySection() =\n%s\nwant\n%s", got, wantSection)
+	}
+}
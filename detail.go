@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Values accepted by --detail
+const (
+	detailTerse    = "terse"
+	detailNormal   = "normal"
+	detailDetailed = "detailed"
+)
+
+// detailInstructions are the prompt instructions for each --detail value; normal keeps the default prompt
+var detailInstructions = map[string]string{
+	detailTerse:    "各項目は1行の短い文にまとめ、関連する変更は1つの項目に統合して、重要な変更だけを記載してください",
+	detailNormal:   "",
+	detailDetailed: "各項目では変更の背景・影響・使い方まで具体的に説明し、必要に応じて入れ子の箇条書きで補足してください",
+}
+
+// validDetail reports whether detail is a supported --detail value
+func validDetail(detail string) bool {
+	_, ok := detailInstructions[detail]
+	return detail == "" || ok
+}
+
+// detailNotes returns the prompt instructions for the configured detail level and item limit
+func detailNotes() []string {
+	var notes []string
+	if note := detailInstructions[genOpts.Detail]; note != "" {
+		notes = append(notes, note)
+	}
+	if genOpts.MaxItemsPerSection > 0 {
+		notes = append(notes, fmt.Sprintf("各セクションの項目は最大%d件とし、超える場合は重要なものを優先して関連する変更をまとめてください", genOpts.MaxItemsPerSection))
+	}
+	return notes
+}
+
+// limitSectionItems keeps at most max top-level bullets, with their nested lines, in each section
+// of entry; max <= 0 leaves the entry unchanged
+func limitSectionItems(entry string, max int) string {
+	if max <= 0 {
+		return entry
+	}
+	var kept []string
+	count, skipping := 0, false
+	for _, line := range strings.Split(entry, "\n") {
+		switch {
+		case htmlHeadingPattern.MatchString(line):
+			count, skipping = 0, false
+		case listItemPattern.MatchString(line) && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t"):
+			count++
+			skipping = count > max
+		case strings.TrimSpace(line) == "":
+			if len(kept) == 0 || strings.TrimSpace(kept[len(kept)-1]) != "" {
+				kept = append(kept, line)
+			}
+			continue
+		case skipping && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+		default:
+			skipping = false
+		}
+		if !skipping {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
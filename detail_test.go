@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLimitSectionItems(t *testing.T) {
+	entry := "## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- 一\n- 二\n  - 補足\n- 三\n  - 三の補足\n- 四\n\n### 修正\n\n- 修正一\n- 修正二\n- 修正三"
+	want := "## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- 一\n- 二\n  - 補足\n\n### 修正\n\n- 修正一\n- 修正二"
+	if got := limitSectionItems(entry, 2); got != want {
+		t.Errorf("limitSectionItems() =\n%q\nwant\n%q", got, want)
+	}
+	if got := limitSectionItems(entry, 0); got != entry {
+		t.Errorf("limitSectionItems() with no limit changed the entry: %q", got)
+	}
+}
+
+func TestDetailNotes(t *testing.T) {
+	original := genOpts
+	defer func() { genOpts = original }()
+
+	genOpts = generationOptions{Detail: detailNormal}
+	if notes := detailNotes(); len(notes) != 0 {
+		t.Errorf("detailNotes() for normal = %q, want none", notes)
+	}
+
+	genOpts = generationOptions{Detail: detailTerse, MaxItemsPerSection: 5}
+	notes := strings.Join(detailNotes(), "\n")
+	if !strings.Contains(notes, "1行") || !strings.Contains(notes, "最大5件") {
+		t.Errorf("detailNotes() = %q", notes)
+	}
+	if validDetail("verbose") {
+		t.Error("validDetail(verbose) = true")
+	}
+}
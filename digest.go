@@ -0,0 +1,174 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// digestWindow returns the start of the since day and the end of the until day (today when
+// empty) in the local time zone
+func digestWindow(since, until string, now time.Time) (start, end time.Time, err error) {
+	start, err = time.ParseInLocation(time.DateOnly, since, time.Local)
+	if err != nil {
+		return start, end, &ConfigError{Err: fmt.Errorf("invalid --since %q: expected YYYY-MM-DD", since)}
+	}
+	if until == "" {
+		until = now.Format(time.DateOnly)
+	}
+	last, err := time.ParseInLocation(time.DateOnly, until, time.Local)
+	if err != nil {
+		return start, end, &ConfigError{Err: fmt.Errorf("invalid --until %q: expected YYYY-MM-DD", until)}
+	}
+	if last.Before(start) {
+		return start, end, &ConfigError{Err: fmt.Errorf("--until %s is before --since %s", until, since)}
+	}
+	return start, last.AddDate(0, 0, 1), nil
+}
+
+// lastCommitBefore returns the last commit of ref made before t, or "" when there is none
+func lastCommitBefore(ref string, t time.Time) (string, error) {
+	output, err := gitOutput("rev-list", "-1", "--before="+t.Format(time.RFC3339), ref)
+	return strings.TrimSpace(output), err
+}
+
+// digestChanges returns the files changed and the commits made on ref within the window; git
+// log selects commits by commit date, so rebased and cherry-picked commits count when they landed
+func digestChanges(ref string, start, end time.Time) (diff, commits string, err error) {
+	output, err := gitOutput("log", commitLogFormat, "--since="+start.Format(time.RFC3339), "--before="+end.Format(time.RFC3339), ref)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get commits: %w", err)
+	}
+	oneline, _ := parseCommitLog(output)
+	commits = commitFilter.filterOneline(oneline)
+	if strings.TrimSpace(commits) == "" {
+		return "", "", nil
+	}
+
+	base, err := lastCommitBefore(ref, start)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get commits: %w", err)
+	}
+	tip, err := lastCommitBefore(ref, end)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get commits: %w", err)
+	}
+	if diff, err = getGitDiff(base, tip); err != nil {
+		return "", "", fmt.Errorf("failed to get diff: %w", err)
+	}
+	return diff, commits, nil
+}
+
+// digestCommand summarizes the commits of a time window into a short progress report, regardless of tags
+func digestCommand(args []string) error {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	since := fs.String("since", "", "First day of the report, YYYY-MM-DD")
+	until := fs.String("until", "", "Last day of the report, YYYY-MM-DD (default: today)")
+	ref := fs.String("ref", gitRefHEAD, "Branch or commit whose history is summarized")
+	title := fs.String("title", "", "Title of the report (default: \"Progress report <since> – <until>\")")
+	model := fs.String("model", "claude", "AI model to use: claude, or mock[:file.md] for a canned entry without AI access")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update digest --since 2025-09-01 [--until 2025-09-07] [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Summarizes every commit of a time window into a short progress report, e.g. for weekly\n")
+		fmt.Fprintf(os.Stderr, "engineering updates. Tags are not needed and the changelog is not modified.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *since == "" {
+		fs.Usage()
+		return &ConfigError{Err: fmt.Errorf("--since is required")}
+	}
+	start, end, err := digestWindow(*since, *until, time.Now())
+	if err != nil {
+		return err
+	}
+	period := fmt.Sprintf("%s – %s", start.Format(time.DateOnly), end.AddDate(0, 0, -1).Format(time.DateOnly))
+	if *title == "" {
+		*title = "Progress report " + period
+	}
+
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		return err
+	}
+	aiFilter = newPathFilter(cfg.AIAllow, cfg.AIDeny)
+	if commitFilter, err = newCommitIgnoreFilter(nil, true); err != nil {
+		return &ConfigError{Err: err}
+	}
+
+	diff, commits, err := digestChanges(*ref, start, end)
+	if err != nil {
+		return err
+	}
+	if commits == "" {
+		ui.Printf("✅ No commits on %s in %s\n", *ref, period)
+		return nil
+	}
+
+	executor, err := newExecutor(*model)
+	if err != nil {
+		return err
+	}
+	spin := startSpinner(ui.Sprintf("Summarizing %d commit(s) in %s", len(strings.Split(strings.TrimSpace(commits), "\n")), period), !ui.plain)
+	report, err := executor.Execute(digestPrompt(*title, period, diff, commits))
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to generate the report: %w", err)
+	}
+	report = strings.TrimSpace(report)
+
+	if *output == "" {
+		fmt.Println(report)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(report+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+	ui.Printf("✅ Wrote the progress report for %s to %s\n", period, *output)
+	return nil
+}
+
+// digestPrompt asks for a short progress report of the commits made in period
+func digestPrompt(title, period, diff, commits string) string {
+	build := func(diff, commits, _ string) string {
+		return fmt.Sprintf(`以下は、%s の期間にリポジトリへ追加されたコミットと変更ファイルです。チーム外にも共有する週次の開発レポートのように、この期間の進捗を短くまとめてください。
+
+コミットメッセージ:
+---
+%s
+---
+
+差分情報:
+---
+%s
+---
+
+以下の形式で出力してください:
+# %s
+
+## ハイライト
+
+- 期間中の特に重要な成果を1〜3項目で要約
+
+## 進捗
+
+- 機能・修正・改善などのまとまりごとに、何が進んだかを箇条書きで記載
+
+## 今後の注意点
+
+- 未完了に見える作業やリスクがある場合のみ記載（なければこのセクションは省略）
+
+注意事項：
+- 全体で15行程度に収め、細かいリファクタリングや依存関係の更新はまとめて1項目にしてください
+- コミットにない作業を推測で追加しないでください
+- 前置きや説明文は一切含めないでください
+- 各項目は日本語で記述し、開発者以外にも伝わる表現にしてください`, period, commits, diff, title)
+	}
+	return fitPrompt(build, diff, commits, "", "")
+}
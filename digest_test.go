@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDigestWindow(t *testing.T) {
+	now := time.Date(2025, 9, 10, 15, 0, 0, 0, time.Local)
+	start, end, err := digestWindow("2025-09-01", "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 9, 1, 0, 0, 0, 0, time.Local); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2025, 9, 11, 0, 0, 0, 0, time.Local); !end.Equal(want) {
+		t.Errorf("end = %v, want the end of today %v", end, want)
+	}
+	for _, tt := range [][2]string{{"last week", ""}, {"2025-09-01", "9/7"}, {"2025-09-08", "2025-09-07"}} {
+		if _, _, err := digestWindow(tt[0], tt[1], now); err == nil {
+			t.Errorf("digestWindow(%q, %q) succeeded", tt[0], tt[1])
+		}
+	}
+}
+
+func TestDigestChanges(t *testing.T) {
+	savedFilter, savedCommitFilter := aiFilter, commitFilter
+	defer func() { aiFilter, commitFilter = savedFilter, savedCommitFilter }()
+	newTestRepo(t)
+	aiFilter = newPathFilter(nil, nil)
+	commitFilter = &commitIgnoreFilter{}
+
+	for _, c := range []struct{ file, date string }{
+		{"old.go", "2025-08-30T12:00:00"},
+		{"first.go", "2025-09-01T09:00:00"},
+		{"second.go", "2025-09-07T23:00:00"},
+		{"later.go", "2025-09-08T01:00:00"},
+	} {
+		t.Setenv("GIT_COMMITTER_DATE", c.date)
+		t.Setenv("GIT_AUTHOR_DATE", c.date)
+		testCommit(t, "Add "+c.file, map[string]string{c.file: "package main\n"})
+	}
+
+	start, end, err := digestWindow("2025-09-01", "2025-09-07", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, commits, err := digestChanges(gitRefHEAD, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "A\tfirst.go\nA\tsecond.go" {
+		t.Errorf("diff of the window = %q", diff)
+	}
+	if !strings.Contains(commits, "Add first.go") || !strings.Contains(commits, "Add second.go") ||
+		strings.Contains(commits, "old.go") || strings.Contains(commits, "later.go") {
+		t.Errorf("commits in the window:\n%s", commits)
+	}
+
+	start, end, _ = digestWindow("2025-09-20", "2025-09-21", time.Now())
+	if _, commits, err := digestChanges(gitRefHEAD, start, end); err != nil || commits != "" {
+		t.Errorf("digestChanges() outside the history = %q, %v", commits, err)
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// checkStatus is the outcome of a doctor check
+type checkStatus int
+
+const (
+	checkOK checkStatus = iota
+	checkWarn
+	checkFail
+)
+
+// checkResult is the outcome of one doctor check with a suggested fix for problems
+type checkResult struct {
+	Name   string
+	Status checkStatus
+	Detail string
+	Fix    string
+}
+
+// doctorPingPrompt is the minimal prompt used to test the AI credentials
+const doctorPingPrompt = "Reply with only the word OK."
+
+// doctorCommand checks the environment the tool depends on and suggests fixes for problems
+func doctorCommand(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	model := fs.String("model", "claude", "AI model to check")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	specName := fs.String("spec", specSimple, "Structural rules to check the changelog against: keepachangelog-1.1, simple or custom")
+	skipAI := fs.Bool("skip-ai", false, "Do not send a test prompt to the AI")
+	skipRemote := fs.Bool("skip-remote", false, "Do not compare local tags with the remote")
+	fs.StringVar(&gitRemote, "remote", gitRemote, "Remote to compare the local tags with")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update doctor [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Checks git, tags, AI credentials, the changelog and the configuration.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	results := []checkResult{checkGit()}
+	repoOK := results[0].Status != checkFail
+	if repoOK && !*skipRemote {
+		results = append(results, checkTags())
+	}
+	cfgResult, cfg := checkConfig(configPath(*configFile), *configFile != "")
+	results = append(results, cfgResult)
+	var custom *specConfig
+	if cfg != nil {
+		if heading, err := newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat); err == nil {
+			changelogHeading = heading
+		}
+		custom = cfg.Spec
+	}
+	if err := setSpec(*specName, custom); err != nil {
+		return err
+	}
+	results = append(results, checkChangelog(repoPath(filepath.FromSlash(*changelogFile))))
+	if !*skipAI {
+		results = append(results, checkAI(*model))
+	}
+
+	failed := 0
+	for _, r := range results {
+		printCheck(r)
+		if r.Status == checkFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	ui.Println("\n✅ Everything looks good.")
+	return nil
+}
+
+// printCheck prints a check result with its fix
+func printCheck(r checkResult) {
+	switch r.Status {
+	case checkOK:
+		ui.Printf("✅ %s: %s\n", r.Name, r.Detail)
+	case checkWarn:
+		ui.Printf("⚠️  %s: %s\n", r.Name, r.Detail)
+	default:
+		ui.Printf("❌ %s: %s\n", r.Name, r.Detail)
+	}
+	if r.Status != checkOK && r.Fix != "" {
+		ui.Printf("   → %s\n", r.Fix)
+	}
+}
+
+// checkGit verifies that git is installed and the directory is a repository
+func checkGit() checkResult {
+	r := checkResult{Name: "git"}
+	if _, err := exec.LookPath("git"); err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, "git was not found in PATH", "Install git and make sure it is on your PATH"
+		return r
+	}
+	top, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, "not inside a git repository", "Run in your repository or pass -C /path/to/repo"
+		return r
+	}
+	r.Detail = "repository " + strings.TrimSpace(top)
+	if !hasCommits() {
+		r.Status, r.Fix = checkWarn, "Commit your files; until then only staged changes are used"
+		r.Detail += " has no commits yet"
+	}
+	return r
+}
+
+// checkTags compares the local tags with the tags of the remote
+func checkTags() checkResult {
+	r := checkResult{Name: "tags"}
+	local, err := getAllTags()
+	if err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, fmt.Sprintf("cannot list tags: %v", err), "Check that the repository is not corrupted (git fsck)"
+		return r
+	}
+	if gitCommand("remote", "get-url", gitRemote).Run() != nil {
+		r.Status, r.Detail, r.Fix = checkWarn, fmt.Sprintf("%d local tag(s); no %s remote", len(local), gitRemote), fmt.Sprintf("Add a remote with git remote add %s <url>, or keep using --skip-pull", gitRemote)
+		return r
+	}
+	output, err := gitOutput("ls-remote", "--tags", gitRemote)
+	if err != nil {
+		r.Status, r.Detail, r.Fix = checkWarn, fmt.Sprintf("cannot reach %s: %v", gitRemote, err), "Check your network and git credentials, or use --skip-pull"
+		return r
+	}
+	missing := missingTags(local, output)
+	if len(missing) > 0 {
+		r.Status = checkWarn
+		r.Detail = fmt.Sprintf("%d tag(s) on %s are not fetched: %s", len(missing), gitRemote, strings.Join(missing, ", "))
+		r.Fix = "Run git fetch --tags (done automatically unless --skip-pull is used)"
+		return r
+	}
+	r.Detail = fmt.Sprintf("%d tag(s), up to date with %s", len(local), gitRemote)
+	return r
+}
+
+// missingTags returns the tags listed in git ls-remote --tags output that are not in local
+func missingTags(local []string, lsRemote string) []string {
+	var missing []string
+	for _, line := range strings.Split(strings.TrimSpace(lsRemote), "\n") {
+		_, ref, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		tag := strings.TrimSuffix(strings.TrimPrefix(ref, "refs/tags/"), "^{}")
+		if !slices.Contains(local, tag) && !slices.Contains(missing, tag) {
+			missing = append(missing, tag)
+		}
+	}
+	return missing
+}
+
+// checkConfig loads and validates the configuration file; the config is nil when it is invalid
+func checkConfig(path string, explicit bool) (checkResult, *config) {
+	r := checkResult{Name: "config"}
+	cfg, err := loadConfig(path, explicit)
+	if err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, err.Error(), "Fix the JSON syntax, remove unknown keys and keep outputs paths inside the repository"
+		return r, nil
+	}
+	if _, err := newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat); err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, err.Error(), "heading_format must contain {version}, e.g. \"## [{version}] - {date}\""
+		return r, nil
+	}
+	if cfg.EntryTemplate != "" {
+		if _, err := loadEntryTemplate(repoPath(filepath.FromSlash(cfg.EntryTemplate))); err != nil {
+			r.Status, r.Detail, r.Fix = checkFail, err.Error(), "Fix the template or the entry_template path (relative to the repository)"
+			return r, nil
+		}
+	}
+	if err := validListStyle(cfg.ListStyle); err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, err.Error(), "Use \"-\", \"*\" or \"+\" for list_style.bullet and 2 or 4 for list_style.indent"
+		return r, nil
+	}
+	if _, err := newFrontMatterFormat(cfg.FrontMatter); err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, err.Error(), "front_matter.format must be yaml or toml, and front_matter.template a valid template (relative to the repository)"
+		return r, nil
+	}
+	if cfg.Jira != nil && (cfg.Jira.URL == "" || cfg.Jira.Project == "") {
+		r.Status, r.Detail, r.Fix = checkFail, "the jira section needs url and project", "Set jira.url, e.g. https://example.atlassian.net, and jira.project, e.g. ABC"
+		return r, nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		r.Detail = "no configuration file, using defaults"
+	} else {
+		r.Detail = path + " is valid"
+	}
+	return r, cfg
+}
+
+// checkChangelog verifies that the version headings of the changelog can be parsed
+func checkChangelog(path string) checkResult {
+	r := checkResult{Name: "changelog"}
+	content, err := readTextFile(path)
+	if os.IsNotExist(err) {
+		r.Status, r.Detail, r.Fix = checkWarn, path+" does not exist", "It will be created on the first run"
+		return r
+	}
+	if err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, err.Error(), "Check the file permissions"
+		return r
+	}
+
+	entries := parseChangelogEntries(content)
+	var duplicates []string
+	for i, entry := range entries {
+		for _, earlier := range entries[:i] {
+			if sameVersion(entry.Version, earlier.Version) {
+				duplicates = append(duplicates, entry.Version)
+				break
+			}
+		}
+	}
+	violations := activeSpec.violations(content)
+	switch {
+	case len(entries) == 0 && strings.Contains(normalizeNewlines(content), "\n## "):
+		r.Status, r.Detail = checkFail, "no version headings were recognized"
+		r.Fix = fmt.Sprintf("Set heading_format in %s to match your headings (current: %s)", defaultConfigFile, changelogHeading.Render("{version}", releaseDate()))
+	case len(violations) > 0:
+		r.Status, r.Detail = checkWarn, fmt.Sprintf("does not follow --spec %s: %s", activeSpec.Name, strings.Join(violations, "; "))
+		r.Fix = fmt.Sprintf("Run changelog-update with --spec %s to add the Unreleased section and link references, and rename the listed sections", activeSpec.Name)
+	case len(duplicates) > 0:
+		r.Status, r.Detail = checkWarn, "duplicate versions: "+strings.Join(duplicates, ", ")
+		r.Fix = "Remove or merge the duplicate entries; only the first one is replaced on updates"
+	default:
+		r.Detail = fmt.Sprintf("%d version(s) parsed from %s", len(entries), path)
+	}
+	return r
+}
+
+// checkAI sends a minimal prompt to verify that the AI command and credentials work
+func checkAI(model string) checkResult {
+	r := checkResult{Name: "AI (" + model + ")"}
+	executor, err := newExecutor(model)
+	if err != nil {
+		r.Status, r.Detail, r.Fix = checkFail, err.Error(), "Use --model claude"
+		return r
+	}
+	if _, ok := executor.(*ClaudeExecutor); ok {
+		if _, err := exec.LookPath("claude"); err != nil {
+			r.Status, r.Detail, r.Fix = checkFail, "the claude command was not found in PATH", "Install Claude Code: npm install -g @anthropic-ai/claude-code"
+			return r
+		}
+	}
+	if _, err := executor.Execute(doctorPingPrompt); err != nil {
+		r.Status, r.Detail = checkFail, err.Error()
+		r.Fix = "Log in with claude, or set " + anthropicAPIKey + " (or store it in the keychain / credential_helper)"
+		return r
+	}
+	r.Detail = "test prompt answered"
+	return r
+}
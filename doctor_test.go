@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMissingTags(t *testing.T) {
+	lsRemote := "abc\trefs/tags/v1.0.0\ndef\trefs/tags/v1.1.0\nfed\trefs/tags/v1.1.0^{}\n"
+	if got, want := missingTags([]string{"v1.0.0"}, lsRemote), []string{"v1.1.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("missingTags() = %v, want %v", got, want)
+	}
+	if got := missingTags([]string{"v1.0.0", "v1.1.0"}, lsRemote); len(got) != 0 {
+		t.Errorf("missingTags() = %v, want none", got)
+	}
+}
+
+func TestCheckChangelog(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    checkStatus
+	}{
+		{"valid", "# Changelog\n\n## [v1.1.0] - 2025-09-01\n\n- b\n\n## [v1.0.0] - 2025-08-01\n\n- a\n", checkOK},
+		{"duplicate", "# Changelog\n\n## [v1.0.0] - 2025-09-01\n\n- b\n\n## [1.0.0] - 2025-08-01\n\n- a\n", checkWarn},
+		{"unrecognized headings", "# Changelog\n\n## Release 1.0 (August)\n\n- a\n", checkFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".md")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if got := checkChangelog(path); got.Status != tt.want {
+				t.Errorf("checkChangelog() = %+v, want status %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := checkChangelog(filepath.Join(dir, "missing.md")); got.Status != checkWarn {
+		t.Errorf("checkChangelog() for a missing file = %+v, want a warning", got)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(valid, []byte(`{"heading_format": "## {version} ({date})"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte(`{"heading_fromat": "## {version}"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, cfg := checkConfig(valid, true); r.Status != checkOK || cfg == nil {
+		t.Errorf("checkConfig(valid) = %+v", r)
+	}
+	if r, cfg := checkConfig(invalid, true); r.Status != checkFail || cfg != nil || r.Fix == "" {
+		t.Errorf("checkConfig(invalid) = %+v", r)
+	}
+	if r, _ := checkConfig(filepath.Join(dir, "missing.json"), false); r.Status != checkOK {
+		t.Errorf("checkConfig(missing) = %+v", r)
+	}
+}
+
+func TestCheckConfigSections(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"jira.json":    `{"jira": {"url": "https://example.atlassian.net"}}`,
+		"outputs.json": `{"outputs": [{"path": ""}]}`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if r, _ := checkConfig(path, true); r.Status != checkFail || r.Fix == "" {
+			t.Errorf("checkConfig(%s) = %+v, want a failure", name, r)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Bullets at least this similar to a bullet of a recent entry are reported as possible duplicates
+const duplicateThreshold = 0.6
+
+// duplicateLookback is the number of recent entries generated bullets are compared with
+const duplicateLookback = 5
+
+// duplicateBullet is a generated bullet that closely matches a bullet of an earlier entry
+type duplicateBullet struct {
+	// Line is the index of the bullet line in the generated entry
+	Line       int
+	Bullet     string
+	Version    string
+	Previous   string
+	Similarity float64
+}
+
+// bulletReferencePattern matches trailing commit and PR references such as "(abc1234)" or "([#12](url))"
+var bulletReferencePattern = regexp.MustCompile(`\s*\((?:\[[^\]]*\]\([^)]*\)|[0-9a-f]{7,40}|#\d+)(?:,\s*(?:\[[^\]]*\]\([^)]*\)|[0-9a-f]{7,40}|#\d+))*\)\s*$`)
+
+// topLevelBullets returns the text of the top-level list items of an entry keyed by line index
+func topLevelBullets(entry string) map[int]string {
+	bullets := map[int]string{}
+	for i, line := range strings.Split(entry, "\n") {
+		if m := listItemPattern.FindStringSubmatch(line); m != nil && m[1] == "" {
+			bullets[i] = m[2]
+		}
+	}
+	return bullets
+}
+
+// bulletBigrams returns the character bigrams of a bullet, ignoring case, punctuation, spacing and
+// trailing commit references; characters are used so the comparison also works for Japanese
+func bulletBigrams(text string) map[string]int {
+	text = bulletReferencePattern.ReplaceAllString(text, "")
+	var runes []rune
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			runes = append(runes, r)
+		}
+	}
+	grams := map[string]int{}
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])]++
+	}
+	return grams
+}
+
+// bulletSimilarity returns the Dice coefficient of the character bigrams of two bullets
+func bulletSimilarity(a, b string) float64 {
+	ga, gb := bulletBigrams(a), bulletBigrams(b)
+	total := 0
+	for _, n := range ga {
+		total += n
+	}
+	for _, n := range gb {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	shared := 0
+	for gram, n := range ga {
+		shared += min(n, gb[gram])
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+// findDuplicateBullets compares the bullets of entry with those of the previous entries and returns
+// the ones at least as similar as the threshold, each with its closest match
+func findDuplicateBullets(entry string, previous []changelogEntry) []duplicateBullet {
+	var duplicates []duplicateBullet
+	bullets := topLevelBullets(entry)
+	for i := range strings.Split(entry, "\n") {
+		bullet, ok := bullets[i]
+		if !ok {
+			continue
+		}
+		best := duplicateBullet{Line: i, Bullet: bullet}
+		for _, e := range previous {
+			for _, old := range topLevelBullets(e.Body) {
+				if s := bulletSimilarity(bullet, old); s > best.Similarity {
+					best.Version, best.Previous, best.Similarity = e.Version, old, s
+				}
+			}
+		}
+		if best.Similarity >= duplicateThreshold {
+			duplicates = append(duplicates, best)
+		}
+	}
+	return duplicates
+}
+
+// recentEntries returns up to n released entries of the changelog other than version, newest first
+func recentEntries(changelogFile, version string, n int) []changelogEntry {
+	entries, err := readChangelogEntries(changelogFile)
+	if err != nil {
+		return nil
+	}
+	var recent []changelogEntry
+	for _, e := range latestReleasedEntries(entries, n+1) {
+		if !sameVersion(e.Version, version) && len(recent) < n {
+			recent = append(recent, e)
+		}
+	}
+	return recent
+}
+
+// dropBullets removes the bullets at the given line indexes with their continuation lines, and the
+// section headings left without any bullet
+func dropBullets(entry string, drop []duplicateBullet) string {
+	dropped := map[int]bool{}
+	for _, d := range drop {
+		dropped[d.Line] = true
+	}
+
+	var kept []string
+	skipping := false
+	for i, line := range strings.Split(entry, "\n") {
+		switch {
+		case dropped[i]:
+			skipping = true
+			continue
+		case skipping && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+			continue
+		case strings.TrimSpace(line) != "":
+			skipping = false
+		}
+		kept = append(kept, line)
+	}
+
+	var result []string
+	for i, line := range kept {
+		if i > 0 && htmlHeadingPattern.MatchString(line) && !sectionHasContent(kept[i+1:]) {
+			continue
+		}
+		if strings.TrimSpace(line) == "" && len(result) > 0 && strings.TrimSpace(result[len(result)-1]) == "" {
+			continue
+		}
+		result = append(result, line)
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
+
+// sectionHasContent reports whether lines contain anything before the next heading
+func sectionHasContent(lines []string) bool {
+	for _, line := range lines {
+		if htmlHeadingPattern.MatchString(line) {
+			return false
+		}
+		if strings.TrimSpace(line) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// reviewDuplicates reports the bullets of entry that repeat recent entries of the changelog, which
+// happens when the previous tag is not where the last entry ended, and asks whether to drop them.
+// With --yes or --no the bullets are kept and only reported.
+func reviewDuplicates(entry, changelogFile, version string) (string, error) {
+	duplicates := findDuplicateBullets(entry, recentEntries(changelogFile, version, duplicateLookback))
+	if len(duplicates) == 0 {
+		return entry, nil
+	}
+
+	ui.Printf("\n⚠️  %d item(s) look like changes that were already released:\n", len(duplicates))
+	for _, d := range duplicates {
+		ui.Printf("  - %s\n    ≈ %s: %s (%.0f%% similar)\n", d.Bullet, d.Version, d.Previous, d.Similarity*100)
+	}
+	if assumeAnswer == answerYes {
+		// Accepting the entry keeps it as generated; --no declines dropping them as well
+		ui.Println("ℹ️  Keeping them (--yes flag); review the entry before releasing.")
+		return entry, nil
+	}
+	drop, err := confirm("Drop these items from the entry? [y/N]: ")
+	if err != nil || !drop {
+		return entry, err
+	}
+	entry = dropBullets(entry, duplicates)
+	ui.Println("\n📝 Updated CHANGELOG Entry:")
+	ui.Separator()
+	ui.Entry(entry)
+	ui.Separator()
+	return entry, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBulletSimilarity(t *testing.T) {
+	if s := bulletSimilarity("CSVエクスポート機能を追加 (abc1234)", "CSVエクスポート機能を追加しました ([#12](https://github.com/org/repo/pull/12))"); s < duplicateThreshold {
+		t.Errorf("similarity of a reworded bullet = %.2f, want at least %.2f", s, duplicateThreshold)
+	}
+	if s := bulletSimilarity("CSVエクスポート機能を追加", "ログインできない不具合を修正"); s >= duplicateThreshold {
+		t.Errorf("similarity of unrelated bullets = %.2f, want below %.2f", s, duplicateThreshold)
+	}
+}
+
+func TestFindAndDropDuplicateBullets(t *testing.T) {
+	previous := []changelogEntry{{Version: "v1.1.0", Body: "### 追加\n\n- CSVエクスポート機能を追加"}}
+	entry := "## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- CSVエクスポート機能を追加しました\n  - 区切り文字を指定可能\n\n### 修正\n\n- ログインできない不具合を修正"
+
+	duplicates := findDuplicateBullets(entry, previous)
+	if len(duplicates) != 1 || duplicates[0].Version != "v1.1.0" || duplicates[0].Line != 4 {
+		t.Fatalf("findDuplicateBullets() = %+v, want the CSV bullet matching v1.1.0", duplicates)
+	}
+
+	want := "## [v1.2.0] - 2025-09-01\n\n### 修正\n\n- ログインできない不具合を修正"
+	if got := dropBullets(entry, duplicates); got != want {
+		t.Errorf("dropBullets() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRecentEntriesSkipsVersion(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	content := "# Changelog\n\n## [Unreleased]\n\n## [v1.2.0] - 2025-09-01\n\n- C\n\n## [v1.1.0] - 2025-08-01\n\n- B\n\n## [v1.0.0] - 2025-07-01\n\n- A\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got := recentEntries(file, "v1.2.0", 2)
+	if len(got) != 2 || got[0].Version != "v1.1.0" || got[1].Version != "v1.0.0" {
+		t.Errorf("recentEntries() = %+v, want v1.1.0 and v1.0.0", got)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Byte order marks recognized at the start of text files
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// textEncoding is how a text file is stored: its byte order mark, if any, and for UTF-16 the byte order
+type textEncoding struct {
+	bom   []byte
+	utf16 binary.ByteOrder
+}
+
+// decodeText returns the text of a file without its byte order mark, decoding UTF-16, together with
+// the encoding needed to write it back the same way
+func decodeText(raw []byte) (string, textEncoding) {
+	switch {
+	case bytes.HasPrefix(raw, utf8BOM):
+		return string(raw[len(utf8BOM):]), textEncoding{bom: utf8BOM}
+	case bytes.HasPrefix(raw, utf16LEBOM):
+		return decodeUTF16(raw[len(utf16LEBOM):], binary.LittleEndian), textEncoding{bom: utf16LEBOM, utf16: binary.LittleEndian}
+	case bytes.HasPrefix(raw, utf16BEBOM):
+		return decodeUTF16(raw[len(utf16BEBOM):], binary.BigEndian), textEncoding{bom: utf16BEBOM, utf16: binary.BigEndian}
+	}
+	return string(raw), textEncoding{}
+}
+
+func decodeUTF16(raw []byte, order binary.ByteOrder) string {
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		units[i] = order.Uint16(raw[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// encode converts text to the encoding and restores the byte order mark; the result holds the raw
+// bytes to write, which for UTF-16 are not valid UTF-8
+func (e textEncoding) encode(text string) string {
+	text = strings.TrimPrefix(text, "\ufeff")
+	if e.utf16 == nil {
+		return string(e.bom) + text
+	}
+	units := utf16.Encode([]rune(text))
+	raw := make([]byte, len(e.bom)+2*len(units))
+	copy(raw, e.bom)
+	for i, unit := range units {
+		e.utf16.PutUint16(raw[len(e.bom)+2*i:], unit)
+	}
+	return string(raw)
+}
+
+// readTextFile reads a text file, dropping its byte order mark and decoding UTF-16
+func readTextFile(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	text, _ := decodeText(raw)
+	return text, nil
+}
+
+// stripBOM removes a UTF-8 byte order mark from text that did not come through decodeText
+func stripBOM(text string) string {
+	if r, size := utf8.DecodeRuneInString(text); r == '\ufeff' {
+		return text[size:]
+	}
+	return text
+}
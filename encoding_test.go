@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeTextRoundTrip(t *testing.T) {
+	text := "# Changelog\r\n\r\n## [v1.0.0] - 2025-08-01\r\n\r\n- 初回リリース 🎉\r\n"
+	encodings := map[string]textEncoding{
+		"none":     {},
+		"utf-8":    {bom: utf8BOM},
+		"utf-16le": {bom: utf16LEBOM, utf16: binary.LittleEndian},
+		"utf-16be": {bom: utf16BEBOM, utf16: binary.BigEndian},
+	}
+	for name, encoding := range encodings {
+		raw := encoding.encode(text)
+		if encoding.bom != nil && !strings.HasPrefix(raw, string(encoding.bom)) {
+			t.Errorf("%s: encode() = %q, want the byte order mark first", name, raw)
+		}
+		got, detected := decodeText([]byte(raw))
+		if got != text {
+			t.Errorf("%s: decodeText() = %q, want %q", name, got, text)
+		}
+		if detected.encode(got) != raw {
+			t.Errorf("%s: re-encoding with the detected encoding changed the bytes", name)
+		}
+	}
+}
+
+func TestUpdatedChangelogContentPreservesBOM(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	entry := "\ufeff## [v1.1.0] - 2025-09-01\n\n- 新機能\n"
+	want := "\ufeff# Changelog\n\n## [v1.1.0] - 2025-09-01\n\n- 新機能\n\n## [v1.0.0] - 2025-08-01\n\n- 初回リリース\n"
+
+	for _, existing := range []string{
+		"\ufeff# Changelog\n\n## [v1.0.0] - 2025-08-01\n\n- 初回リリース\n",
+		"\ufeff# Changelog\n\n## [v1.1.0] - 2025-08-31\n\n- 古い内容\n\n## [v1.0.0] - 2025-08-01\n\n- 初回リリース\n",
+	} {
+		if err := os.WriteFile(file, []byte(existing), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := updatedChangelogContent(file, entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("updatedChangelogContent() =\n%q\nwant\n%q", got, want)
+		}
+	}
+
+	// A BOM directly before the first version heading must not hide it
+	if err := os.WriteFile(file, []byte("\ufeff## [v1.0.0] - 2025-08-01\n\n- 初回リリース\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := getExistingVersionsFromChangelog(file)
+	if err != nil || len(versions) != 1 || versions[0] != "v1.0.0" {
+		t.Errorf("getExistingVersionsFromChangelog() = %v, %v, want [v1.0.0]", versions, err)
+	}
+}
+
+func TestUpdatedChangelogContentPreservesUTF16(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	le := textEncoding{bom: utf16LEBOM, utf16: binary.LittleEndian}
+	if err := os.WriteFile(file, []byte(le.encode("# Changelog\r\n\r\n## [v1.0.0] - 2025-08-01\r\n\r\n- 初回リリース\r\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := updatedChangelogContent(file, "## [v1.1.0] - 2025-09-01\n\n- 新機能\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := le.encode("# Changelog\r\n\r\n## [v1.1.0] - 2025-09-01\r\n\r\n- 新機能\r\n\r\n## [v1.0.0] - 2025-08-01\r\n\r\n- 初回リリース\r\n")
+	if got != want {
+		t.Errorf("updatedChangelogContent() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// readEntryFile reads a pre-written entry for --entry-file. The entry must start with a version
+// heading, which has to match tag when one is given; the entry and its version are returned.
+func readEntryFile(path, tag string) (entry, version string, err error) {
+	data, err := readTextFile(path)
+	if err != nil {
+		return "", "", &ConfigError{Err: fmt.Errorf("cannot read entry file: %w", err)}
+	}
+	entry = strings.TrimSpace(normalizeNewlines(data))
+	first, _, _ := strings.Cut(entry, "\n")
+	version, ok := changelogHeading.Version(first)
+	if !ok {
+		return "", "", &ConfigError{Err: fmt.Errorf("entry file %s must start with a version heading such as %q", path, changelogHeading.Render("v1.2.0", releaseDate()))}
+	}
+	if tag != "" && !sameVersion(version, tag) {
+		return "", "", &ConfigError{Err: fmt.Errorf("entry file %s is for version %s, not %s", path, version, tag)}
+	}
+	return entry, version, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadEntryFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "entry.md")
+	if err := os.WriteFile(file, []byte("\r\n## [v1.2.0] - 2025-09-01\r\n\r\n### 修正\r\n\r\n- 手書きの項目\r\n\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, version, err := readEntryFile(file, "1.2.0")
+	if err != nil {
+		t.Fatalf("readEntryFile() error = %v", err)
+	}
+	if want := "## [v1.2.0] - 2025-09-01\n\n### 修正\n\n- 手書きの項目"; entry != want {
+		t.Errorf("entry = %q, want %q", entry, want)
+	}
+	if version != "v1.2.0" {
+		t.Errorf("version = %q, want v1.2.0", version)
+	}
+
+	if _, _, err := readEntryFile(file, "v1.3.0"); err == nil || !strings.Contains(err.Error(), "not v1.3.0") {
+		t.Errorf("readEntryFile() with another tag error = %v", err)
+	}
+
+	noHeading := filepath.Join(dir, "no-heading.md")
+	if err := os.WriteFile(noHeading, []byte("- 見出しのない項目\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := readEntryFile(noHeading, ""); exitCode(err) != ExitConfig {
+		t.Errorf("readEntryFile() without a heading error = %v, want a config error", err)
+	}
+}
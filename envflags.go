@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// envFlagPrefix starts the environment variables that set flags of the main command and the
+// subcommands, e.g. CHANGELOG_UPDATE_MODEL for --model
+const envFlagPrefix = "CHANGELOG_UPDATE_"
+
+// envFlagAliases are variables named after a setting rather than its flag; the variable named
+// after the flag wins when both are set
+var envFlagAliases = map[string]string{
+	envFlagPrefix + "LANG": "ui-lang",
+	// The tags of a Go module are prefixed with its directory, e.g. api/ in api/v1.2.3
+	envFlagPrefix + "TAG_PREFIX": "module",
+}
+
+// secretFlags are flags whose values config show only reports as set
+var secretFlags = []string{"notify-webhook"}
+
+// envFlagName returns the environment variable of a flag, e.g. CHANGELOG_UPDATE_MAX_PROMPT_TOKENS
+// for max-prompt-tokens; shorthand and help flags have none
+func envFlagName(name string) string {
+	if len(name) == 1 || name == "help" || name == "version" {
+		return ""
+	}
+	return envFlagPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// envFlagValues returns the flags of fs set by environment variables with the variable that set each
+func envFlagValues(fs *flag.FlagSet) map[string]string {
+	sources := map[string]string{}
+	for env, name := range envFlagAliases {
+		if os.Getenv(env) != "" && fs.Lookup(name) != nil {
+			sources[name] = env
+		}
+	}
+	fs.VisitAll(func(f *flag.Flag) {
+		if env := envFlagName(f.Name); env != "" && os.Getenv(env) != "" {
+			sources[f.Name] = env
+		}
+	})
+	return sources
+}
+
+// applyEnvFlags sets the flags of fs from their environment variables; it runs before the command
+// line is parsed, so flags given there win
+func applyEnvFlags(fs *flag.FlagSet) error {
+	sources := envFlagValues(fs)
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		env := sources[name]
+		if err := fs.Set(name, os.Getenv(env)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", env, err))
+		}
+	}
+	if len(errs) > 0 {
+		return &ConfigError{Err: errors.Join(errs...)}
+	}
+	return nil
+}
+
+// parseFlags parses the flags of a subcommand after applying their environment variables; an
+// invalid variable is reported only after parsing, so -h works whatever the environment holds
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	envErr := applyEnvFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return envErr
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestEnvFlagName(t *testing.T) {
+	testCases := map[string]string{
+		"model":             "CHANGELOG_UPDATE_MODEL",
+		"max-prompt-tokens": "CHANGELOG_UPDATE_MAX_PROMPT_TOKENS",
+		"m":                 "",
+		"help":              "",
+	}
+	for name, want := range testCases {
+		if got := envFlagName(name); got != want {
+			t.Errorf("envFlagName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestApplyEnvFlags(t *testing.T) {
+	newFlags := func() (*flag.FlagSet, *string, *string, *int) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		return fs, fs.String("model", "claude", ""), fs.String("ui-lang", "", ""), fs.Int("concurrency", 4, "")
+	}
+
+	t.Setenv("CHANGELOG_UPDATE_MODEL", "mock")
+	t.Setenv("CHANGELOG_UPDATE_LANG", "ja")
+	fs, model, uiLang, concurrency := newFlags()
+	if err := applyEnvFlags(fs); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"--concurrency", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if *model != "mock" || *uiLang != "ja" || *concurrency != 2 {
+		t.Errorf("model, ui-lang, concurrency = %q, %q, %d", *model, *uiLang, *concurrency)
+	}
+
+	// The command line and the variable named after the flag win
+	t.Setenv("CHANGELOG_UPDATE_UI_LANG", "en")
+	fs, model, uiLang, _ = newFlags()
+	if err := applyEnvFlags(fs); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"--model", "claude"}); err != nil {
+		t.Fatal(err)
+	}
+	if *model != "claude" || *uiLang != "en" {
+		t.Errorf("model, ui-lang = %q, %q", *model, *uiLang)
+	}
+
+	t.Setenv("CHANGELOG_UPDATE_CONCURRENCY", "many")
+	fs, _, _, _ = newFlags()
+	if err := applyEnvFlags(fs); exitCode(err) != ExitConfig {
+		t.Errorf("applyEnvFlags() with an invalid number = %v, want a configuration error", err)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	newFlags := func() (*flag.FlagSet, *string, *int) {
+		fs := flag.NewFlagSet("notes", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		return fs, fs.String("module", "", ""), fs.Int("concurrency", 4, "")
+	}
+
+	t.Setenv("CHANGELOG_UPDATE_TAG_PREFIX", "api/")
+	fs, module, _ := newFlags()
+	if err := parseFlags(fs, nil); err != nil || *module != "api/" {
+		t.Errorf("parseFlags() = %v, module %q; want the module from CHANGELOG_UPDATE_TAG_PREFIX", err, *module)
+	}
+
+	t.Setenv("CHANGELOG_UPDATE_CONCURRENCY", "many")
+	fs, _, _ = newFlags()
+	if err := parseFlags(fs, []string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("parseFlags(-h) with an invalid variable = %v, want flag.ErrHelp", err)
+	}
+	fs, _, _ = newFlags()
+	if err := parseFlags(fs, nil); exitCode(err) != ExitConfig {
+		t.Errorf("parseFlags() with an invalid variable = %v, want a configuration error", err)
+	}
+}
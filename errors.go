@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Exit codes returned by changelog-update so CI scripts can branch on the failure cause
+const (
+	// ExitOK means the run succeeded, including runs with nothing to do
+	ExitOK = 0
+	// ExitFailure is any failure not covered by a more specific code
+	ExitFailure = 1
+	// ExitConfig means invalid flags or an invalid configuration file
+	ExitConfig = 2
+	// ExitGit means a git command failed
+	ExitGit = 3
+	// ExitAI means the AI model failed or returned an unusable response
+	ExitAI = 4
+	// ExitEmpty means there was nothing to do and --fail-on-empty was set
+	ExitEmpty = 5
+)
+
+// errNothingToDo is returned when there are no changes or missing tags to write
+var errNothingToDo = errors.New("nothing to do")
+
+// errNoCommits is returned when the repository has no commits yet
+var errNoCommits = errors.New("repository has no commits yet")
+
+// ConfigError reports invalid flags or configuration
+type ConfigError struct {
+	Err error
+}
+
+func (e *ConfigError) Error() string { return e.Err.Error() }
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+// GitError reports a failed git command
+type GitError struct {
+	Args     []string
+	ExitCode int
+	Output   string
+	Err      error
+}
+
+func (e *GitError) Error() string {
+	msg := fmt.Sprintf("git %s failed: %v", strings.Join(e.Args, " "), e.Err)
+	if e.Output != "" {
+		msg += "\nOutput: " + e.Output
+	}
+	return msg
+}
+
+func (e *GitError) Unwrap() error { return e.Err }
+
+// AIError reports a failed AI call
+type AIError struct {
+	Err error
+}
+
+func (e *AIError) Error() string { return e.Err.Error() }
+func (e *AIError) Unwrap() error { return e.Err }
+
+// newGitError wraps the error of a git command, keeping its exit code and output
+func newGitError(args []string, output []byte, err error) *GitError {
+	gitErr := &GitError{Args: args, ExitCode: -1, Output: strings.TrimSpace(string(output)), Err: err}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		gitErr.ExitCode = exitErr.ExitCode()
+		if gitErr.Output == "" {
+			gitErr.Output = strings.TrimSpace(string(exitErr.Stderr))
+		}
+	}
+	return gitErr
+}
+
+// gitOutput runs a git command inside gitDir and returns its standard output
+func gitOutput(args ...string) (string, error) {
+	output, err := gitCommand(args...).Output()
+	if err != nil {
+		return "", newGitError(args, nil, err)
+	}
+	return string(output), nil
+}
+
+// hasCommits reports whether HEAD points to a commit
+func hasCommits() bool {
+	return gitCommand("rev-parse", "--verify", "--quiet", gitRefHEAD).Run() == nil
+}
+
+// exitCode maps an error to the exit code for its cause
+func exitCode(err error) int {
+	var (
+		configErr *ConfigError
+		gitErr    *GitError
+		aiErr     *AIError
+	)
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &configErr):
+		return ExitConfig
+	case errors.As(err, &gitErr):
+		return ExitGit
+	case errors.As(err, &aiErr):
+		return ExitAI
+	default:
+		return ExitFailure
+	}
+}
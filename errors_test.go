@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain", errors.New("boom"), ExitFailure},
+		{"config", &ConfigError{Err: errors.New("bad")}, ExitConfig},
+		{"wrapped git", fmt.Errorf("failed to get all tags: %w", &GitError{Args: []string{"tag"}, Err: errors.New("exit status 128")}), ExitGit},
+		{"ai", &AIError{Err: errors.New("claude failed")}, ExitAI},
+		{"nothing to do", errNothingToDo, ExitFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGitError(t *testing.T) {
+	err := exec.Command("git", "--no-such-option").Run()
+	if err == nil {
+		t.Skip("git accepted an unknown option")
+	}
+	gitErr := newGitError([]string{"--no-such-option"}, []byte("unknown option\n"), err)
+	if gitErr.ExitCode <= 0 {
+		t.Errorf("ExitCode = %d, want the git exit status", gitErr.ExitCode)
+	}
+	if msg := gitErr.Error(); !strings.HasPrefix(msg, "git --no-such-option failed:") || !strings.HasSuffix(msg, "Output: unknown option") {
+		t.Errorf("Error() = %q", msg)
+	}
+	if !errors.Is(gitErr, err) {
+		t.Error("GitError does not unwrap to the command error")
+	}
+}
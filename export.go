@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"html/template"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var unsafeAnchorChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportVersion is a changelog entry prepared for export templates
+type exportVersion struct {
+	Version string
+	Anchor  string
+	Title   string
+	Body    template.HTML
+}
+
+var htmlExportTemplate = template.Must(template.New("changelog").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", "Hiragino Sans", "Noto Sans JP", sans-serif; line-height: 1.7; color: #1f2328; max-width: 860px; margin: 0 auto; padding: 2rem 1rem; }
+h1 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+nav ul { list-style: none; padding: 0; display: flex; flex-wrap: wrap; gap: .5rem; }
+nav a { display: inline-block; padding: .1rem .6rem; border: 1px solid #d0d7de; border-radius: 1rem; text-decoration: none; color: #0969da; font-size: .9rem; }
+section { border-top: 1px solid #d0d7de; margin-top: 2rem; }
+h2 a.anchor { color: inherit; text-decoration: none; }
+h2 a.anchor:hover::after { content: " #"; color: #8c959f; }
+h3 { font-size: 1.05rem; color: #57606a; }
+code { background: #f6f8fa; border-radius: 4px; padding: .1em .3em; font-size: .9em; }
+pre code { display: block; padding: 1em; overflow-x: auto; }
+blockquote { margin: 0; padding: 0 1em; color: #57606a; border-left: .25em solid #d0d7de; }
+a { color: #0969da; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<nav><ul>{{range .Versions}}<li><a href="#{{.Anchor}}">{{.Version}}</a></li>{{end}}</ul></nav>
+{{range .Versions}}<section id="{{.Anchor}}">
+<h2><a class="anchor" href="#{{.Anchor}}">{{.Title}}</a></h2>
+{{.Body}}</section>
+{{end}}</body>
+</html>
+`))
+
+func exportCommand(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "html", "Export format: html or atom")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	title := fs.String("title", "Changelog", "Document or feed title")
+	projectURL := fs.String("url", "", "Project URL used for feed links (default: derived from the remote)")
+	fs.StringVar(&gitRemote, "remote", gitRemote, "Remote the project URL is derived from")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	entries, err := readChangelogEntries(repoPath(*changelogFile))
+	if err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+
+	var rendered []byte
+	switch *format {
+	case "html":
+		rendered, err = renderHTMLExport(*title, entries)
+	case "atom":
+		if *projectURL == "" {
+			*projectURL = remoteWebURL(gitRemote)
+		}
+		if *projectURL == "" {
+			return fmt.Errorf("--url is required when the project URL cannot be derived from the remote")
+		}
+		rendered, err = renderAtomExport(*title, *projectURL, entries, time.Now())
+	default:
+		return fmt.Errorf("unsupported export format: %s", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(rendered)
+		return err
+	}
+	if err := os.WriteFile(*output, rendered, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+	ui.Printf("✅ Exported %d version(s) to %s\n", len(entries), *output)
+	return nil
+}
+
+// exportVersions converts parsed entries into template data
+func exportVersions(entries []changelogEntry) []exportVersion {
+	versions := make([]exportVersion, 0, len(entries))
+	for _, entry := range entries {
+		versions = append(versions, exportVersion{
+			Version: entry.Version,
+			Anchor:  versionAnchor(entry.Version),
+			Title:   strings.TrimSpace(strings.TrimLeft(entry.Heading, "#")),
+			Body:    template.HTML(markdownToHTML(entry.Body)), //nolint:gosec // markdownToHTML escapes all text
+		})
+	}
+	return versions
+}
+
+// versionAnchor returns the HTML id used for a version
+func versionAnchor(version string) string {
+	return unsafeAnchorChars.ReplaceAllString(strings.TrimSpace(version), "-")
+}
+
+// renderHTMLExport renders the changelog as a standalone HTML page
+func renderHTMLExport(title string, entries []changelogEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	data := struct {
+		Title    string
+		Versions []exportVersion
+	}{Title: title, Versions: exportVersions(entries)}
+	if err := htmlExportTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// atomFeed is the root element of an Atom feed
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// renderAtomExport renders the changelog as an Atom feed with one entry per version;
+// versions without a date in their heading use now as their updated time
+func renderAtomExport(title, projectURL string, entries []changelogEntry, now time.Time) ([]byte, error) {
+	projectURL = strings.TrimSuffix(projectURL, "/")
+	feed := atomFeed{
+		Title:   title,
+		ID:      projectURL,
+		Updated: now.UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: projectURL},
+	}
+
+	var latest time.Time
+	for i, v := range exportVersions(entries) {
+		updated := now
+		if d, ok := entries[i].Date(); ok {
+			updated = d
+		}
+		if updated.After(latest) {
+			latest = updated
+		}
+		link := projectURL + "#" + v.Anchor
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   v.Title,
+			ID:      link,
+			Updated: updated.UTC().Format(time.RFC3339),
+			Link:    atomLink{Href: link},
+			Content: atomContent{Type: "html", Body: string(v.Body)},
+		})
+	}
+	if !latest.IsZero() {
+		feed.Updated = latest.UTC().Format(time.RFC3339)
+	}
+
+	out, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Atom feed: %w", err)
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderHTMLExport(t *testing.T) {
+	entries := parseChangelogEntries("# Changelog\n\n## [v1.1.0] - 2025-09-01\n\n### 追加\n\n- Feature\n\n## [v1.0.0] - 2025-08-01\n\n### 修正\n\n- Fix")
+
+	out, err := renderHTMLExport("My <Project>", entries)
+	if err != nil {
+		t.Fatalf("renderHTMLExport() error = %v", err)
+	}
+
+	html := string(out)
+	for _, want := range []string{
+		"<title>My &lt;Project&gt;</title>",
+		`<section id="v1.1.0">`,
+		`<a href="#v1.0.0">v1.0.0</a>`,
+		`<a class="anchor" href="#v1.1.0">[v1.1.0] - 2025-09-01</a>`,
+		"<li>Feature</li>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("exported HTML does not contain %q", want)
+		}
+	}
+	if strings.Index(html, `id="v1.1.0"`) > strings.Index(html, `id="v1.0.0"`) {
+		t.Error("versions should keep changelog order")
+	}
+}
+
+func TestVersionAnchor(t *testing.T) {
+	if got := versionAnchor(" v2.0.0 beta "); got != "v2.0.0-beta" {
+		t.Errorf("versionAnchor() = %q", got)
+	}
+}
+
+func TestRenderAtomExport(t *testing.T) {
+	entries := parseChangelogEntries("## [v1.1.0] - 2025-09-01\n\n- **New** thing\n\n## [Unreleased]\n\n- Pending")
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	out, err := renderAtomExport("Project releases", "https://github.com/org/repo/", entries, now)
+	if err != nil {
+		t.Fatalf("renderAtomExport() error = %v", err)
+	}
+
+	feed := string(out)
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		"<title>Project releases</title>",
+		"<id>https://github.com/org/repo#v1.1.0</id>",
+		"<updated>2025-09-01T00:00:00Z</updated>",
+		"<updated>2025-10-01T12:00:00Z</updated>",
+		`<content type="html">&lt;ul&gt;`,
+		`<link href="https://github.com/org/repo"></link>`,
+	} {
+		if !strings.Contains(feed, want) {
+			t.Errorf("feed does not contain %q\n%s", want, feed)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"path"
+	"strings"
+)
+
+// aiFilter decides which files may appear in content sent to the AI
+var aiFilter = &pathFilter{}
+
+// pathFilter matches repository paths against allow and deny glob patterns
+type pathFilter struct {
+	allow []string
+	deny  []string
+}
+
+func newPathFilter(allow, deny []string) *pathFilter {
+	return &pathFilter{allow: allow, deny: deny}
+}
+
+// allowed reports whether p may be sent to the AI
+func (f *pathFilter) allowed(p string) bool {
+	for _, pattern := range f.deny {
+		if matchPathPattern(pattern, p) {
+			return false
+		}
+	}
+	if len(f.allow) == 0 {
+		return true
+	}
+	for _, pattern := range f.allow {
+		if matchPathPattern(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterNameStatus drops lines of `git diff --name-status` output that mention a disallowed path
+func (f *pathFilter) filterNameStatus(output string) string {
+	if len(f.allow) == 0 && len(f.deny) == 0 {
+		return output
+	}
+
+	lines := strings.Split(output, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.Split(line, "\t")
+		ok := true
+		for _, p := range fields[1:] {
+			if !f.allowed(p) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// matchPathPattern matches a gitignore-like pattern: "dir/" matches a directory anywhere,
+// patterns without a slash match any path segment, and other patterns match the full path or a parent directory
+func matchPathPattern(pattern, p string) bool {
+	p = strings.TrimPrefix(path.Clean(strings.ReplaceAll(p, "\\", "/")), "./")
+	segments := strings.Split(p, "/")
+
+	if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+		if strings.Contains(dir, "/") {
+			return p == dir || strings.HasPrefix(p, dir+"/")
+		}
+		for _, segment := range segments[:len(segments)-1] {
+			if matched, _ := path.Match(dir, segment); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !strings.Contains(pattern, "/") {
+		for _, segment := range segments {
+			if matched, _ := path.Match(pattern, segment); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	pattern = strings.TrimPrefix(pattern, "/")
+	for i := len(segments); i > 0; i-- {
+		if matched, _ := path.Match(pattern, strings.Join(segments[:i], "/")); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestMatchPathPattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"secrets/", "secrets/api.key", true},
+		{"secrets/", "config/secrets/api.key", true},
+		{"secrets/", "secrets.go", false},
+		{"*.pem", "certs/server.pem", true},
+		{"*.pem", "server.pem.go", false},
+		{"customer-data/", "customer-data/2025/export.csv", true},
+		{"internal/private/", "internal/private/x.go", true},
+		{"internal/private/", "other/internal/private/x.go", false},
+		{"docs/*.md", "docs/guide.md", true},
+		{"docs/*.md", "docs/sub/guide.md", false},
+		{"vendor", "vendor/pkg/file.go", true},
+		{"/build/out", "build/out/bin", true},
+	}
+
+	for _, tc := range testCases {
+		if got := matchPathPattern(tc.pattern, tc.path); got != tc.want {
+			t.Errorf("matchPathPattern(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestFilterNameStatus(t *testing.T) {
+	input := "M\tmain.go\nA\tsecrets/token.txt\nR100\tkeys/old.pem\tkeys/new.txt\nA\tdocs/guide.md"
+
+	tests := []struct {
+		name   string
+		filter *pathFilter
+		want   string
+	}{
+		{
+			name:   "no patterns keeps everything",
+			filter: newPathFilter(nil, nil),
+			want:   input,
+		},
+		{
+			name:   "deny list",
+			filter: newPathFilter(nil, []string{"secrets/", "*.pem"}),
+			want:   "M\tmain.go\nA\tdocs/guide.md",
+		},
+		{
+			name:   "allow list",
+			filter: newPathFilter([]string{"*.go"}, nil),
+			want:   "M\tmain.go",
+		},
+		{
+			name:   "deny wins over allow",
+			filter: newPathFilter([]string{"*.go", "*.md"}, []string{"docs/"}),
+			want:   "M\tmain.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.filterNameStatus(input); got != tt.want {
+				t.Errorf("filterNameStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
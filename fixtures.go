@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fixturePath is the file holding the recorded response to prompt in dir, named by the prompt hash
+func fixturePath(dir, prompt string) string {
+	return filepath.Join(dir, promptHash(prompt)+".txt")
+}
+
+// recordingExecutor runs the wrapped executor and saves each response in dir, keyed by the prompt hash
+type recordingExecutor struct {
+	AIExecutor
+	dir string
+}
+
+// Execute runs the wrapped executor and records a successful response
+func (r *recordingExecutor) Execute(prompt string) (string, error) {
+	result, err := r.AIExecutor.Execute(prompt)
+	if err != nil {
+		return result, err
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to record AI response: %w", err)
+	}
+	if err := os.WriteFile(fixturePath(r.dir, prompt), []byte(result), 0o644); err != nil {
+		return "", fmt.Errorf("failed to record AI response: %w", err)
+	}
+	return result, nil
+}
+
+// replayExecutor answers prompts with the responses recorded in dir instead of calling the AI
+type replayExecutor struct {
+	dir string
+}
+
+// Execute returns the recorded response to prompt
+func (r *replayExecutor) Execute(prompt string) (string, error) {
+	path := fixturePath(r.dir, prompt)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", &AIError{Err: fmt.Errorf("no recorded response for prompt %s in %s (record it with --record)", promptHash(prompt), r.dir)}
+	}
+	if err != nil {
+		return "", &AIError{Err: fmt.Errorf("failed to read recorded response: %w", err)}
+	}
+	return string(data), nil
+}
+
+// fixtureExecutor wraps base for --record or --replay; with neither directory set it returns base
+func fixtureExecutor(base AIExecutor, recordDir, replayDir string) AIExecutor {
+	switch {
+	case replayDir != "":
+		return &replayExecutor{dir: replayDir}
+	case recordDir != "":
+		return &recordingExecutor{AIExecutor: base, dir: recordDir}
+	}
+	return base
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type countingExecutor struct {
+	calls    int
+	response string
+}
+
+func (c *countingExecutor) Execute(prompt string) (string, error) {
+	c.calls++
+	return c.response, nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	ai := &countingExecutor{response: "## [v1.0.0] - 2025-09-01\n\n- 項目"}
+
+	recorder := fixtureExecutor(ai, dir, "")
+	if _, err := recorder.Execute("prompt"); err != nil {
+		t.Fatalf("record Execute() error = %v", err)
+	}
+
+	replayer := fixtureExecutor(ai, "", dir)
+	got, err := replayer.Execute("prompt")
+	if err != nil {
+		t.Fatalf("replay Execute() error = %v", err)
+	}
+	if got != ai.response {
+		t.Errorf("replay Execute() = %q, want %q", got, ai.response)
+	}
+	if ai.calls != 1 {
+		t.Errorf("AI called %d times, want 1", ai.calls)
+	}
+
+	_, err = replayer.Execute("other prompt")
+	var aiErr *AIError
+	if !errors.As(err, &aiErr) || !strings.Contains(err.Error(), promptHash("other prompt")) {
+		t.Errorf("replay of an unrecorded prompt error = %v, want an AIError naming the prompt hash", err)
+	}
+}
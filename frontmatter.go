@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Front matter formats accepted by the front_matter configuration
+const (
+	frontMatterYAML = "yaml"
+	frontMatterTOML = "toml"
+)
+
+// defaultFrontMatterTemplates render the fields of per-version pages in each format
+var defaultFrontMatterTemplates = map[string]string{
+	frontMatterYAML: `title: {{quote .Title}}
+slug: {{quote .Slug}}
+version: {{quote .Version}}
+{{- if .Date}}
+date: {{.Date}}
+{{- end}}
+{{- if .Tags}}
+tags: {{list .Tags}}
+{{- end}}
+`,
+	frontMatterTOML: `title = {{quote .Title}}
+slug = {{quote .Slug}}
+version = {{quote .Version}}
+{{- if .Date}}
+date = {{.Date}}
+{{- end}}
+{{- if .Tags}}
+tags = {{list .Tags}}
+{{- end}}
+`,
+}
+
+// frontMatterDelimiters enclose the front matter as Hugo, Docusaurus and Jekyll expect it
+var frontMatterDelimiters = map[string]string{frontMatterYAML: "---", frontMatterTOML: "+++"}
+
+// frontMatterFuncs quote values so that they are valid in both YAML and TOML
+var frontMatterFuncs = template.FuncMap{
+	"quote": strconv.Quote,
+	"list": func(values []string) string {
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = strconv.Quote(v)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	},
+}
+
+var slugUnsafePattern = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// frontMatterConfig customizes the front matter of per-version pages
+type frontMatterConfig struct {
+	// Format is "yaml" (the default) or "toml"
+	Format string `json:"format,omitempty"`
+	// Template is a Go text/template file rendering the fields between the delimiters
+	Template string `json:"template,omitempty"`
+	// Tags are listed in the tags field of every page
+	Tags []string `json:"tags,omitempty"`
+}
+
+// frontMatterFormat renders the front matter of per-version pages
+type frontMatterFormat struct {
+	delimiter string
+	template  *template.Template
+	tags      []string
+}
+
+// frontMatter is the front matter format from the configuration file
+var frontMatter = mustFrontMatterFormat(nil)
+
+// frontMatterData is passed to the front matter template; Date is empty for unreleased entries
+type frontMatterData struct {
+	Title   string
+	Slug    string
+	Version string
+	Date    string
+	Tags    []string
+}
+
+// newFrontMatterFormat builds the front matter format of cfg; the template file is read relative
+// to the repository, and a nil cfg selects YAML with the default fields
+func newFrontMatterFormat(cfg *frontMatterConfig) (*frontMatterFormat, error) {
+	if cfg == nil {
+		cfg = &frontMatterConfig{}
+	}
+	format := strings.ToLower(cfg.Format)
+	if format == "" {
+		format = frontMatterYAML
+	}
+	source, ok := defaultFrontMatterTemplates[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown front matter format %q (expected %s or %s)", cfg.Format, frontMatterYAML, frontMatterTOML)
+	}
+	if cfg.Template != "" {
+		content, err := os.ReadFile(repoPath(filepath.FromSlash(cfg.Template)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read front matter template: %w", err)
+		}
+		source = string(content)
+	}
+	tmpl, err := template.New("front matter").Funcs(frontMatterFuncs).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse front matter template: %w", err)
+	}
+	return &frontMatterFormat{delimiter: frontMatterDelimiters[format], template: tmpl, tags: cfg.Tags}, nil
+}
+
+func mustFrontMatterFormat(cfg *frontMatterConfig) *frontMatterFormat {
+	f, err := newFrontMatterFormat(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// versionSlug returns a URL-safe slug for a version, e.g. "v1.2.0" for "V1.2.0" and "api-v1.2.0" for "api/v1.2.0"
+func versionSlug(version string) string {
+	return strings.Trim(slugUnsafePattern.ReplaceAllString(strings.ToLower(version), "-"), "-")
+}
+
+// render returns the front matter of an entry, enclosed in the delimiters of the format
+func (f *frontMatterFormat) render(e changelogEntry) (string, error) {
+	data := frontMatterData{Title: e.Version, Slug: versionSlug(e.Version), Version: e.Version, Tags: f.tags}
+	if date, ok := e.Date(); ok {
+		data.Date = date.Format("2006-01-02")
+	}
+	var b bytes.Buffer
+	if err := f.template.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render front matter for %s: %w", e.Version, err)
+	}
+	fields := strings.TrimRight(b.String(), "\n")
+	if fields == "" {
+		return f.delimiter + "\n" + f.delimiter + "\n", nil
+	}
+	return f.delimiter + "\n" + fields + "\n" + f.delimiter + "\n", nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVersionSlug(t *testing.T) {
+	for version, want := range map[string]string{
+		"v1.2.0":        "v1.2.0",
+		"V2.0.0-RC.1":   "v2.0.0-rc.1",
+		"api/v1.2.0":    "api-v1.2.0",
+		"Release 1.0 !": "release-1.0",
+	} {
+		if got := versionSlug(version); got != want {
+			t.Errorf("versionSlug(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestFrontMatterFormats(t *testing.T) {
+	savedDir := gitDir
+	defer func() { gitDir = savedDir }()
+	gitDir = t.TempDir()
+	entry := changelogEntry{Version: "v1.2.0", Heading: "## [v1.2.0] - 2025-09-01", Body: "- B"}
+
+	toml, err := newFrontMatterFormat(&frontMatterConfig{Format: "TOML", Tags: []string{"release", `"quoted"`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "+++\ntitle = \"v1.2.0\"\nslug = \"v1.2.0\"\nversion = \"v1.2.0\"\ndate = 2025-09-01\ntags = [\"release\", \"\\\"quoted\\\"\"]\n+++\n"
+	if got, err := toml.render(entry); err != nil || got != want {
+		t.Errorf("TOML render() = %q, %v, want %q", got, err, want)
+	}
+
+	template := "title: \"Release {{.Version}}\"\nsidebar_label: {{quote .Version}}\nslug: /releases/{{.Slug}}\n"
+	if err := os.WriteFile(filepath.Join(gitDir, "front-matter.tmpl"), []byte(template), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	custom, err := newFrontMatterFormat(&frontMatterConfig{Template: "front-matter.tmpl"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "---\ntitle: \"Release v1.2.0\"\nsidebar_label: \"v1.2.0\"\nslug: /releases/v1.2.0\n---\n"
+	if got, err := custom.render(entry); err != nil || got != want {
+		t.Errorf("custom render() = %q, %v, want %q", got, err, want)
+	}
+
+	for _, cfg := range []*frontMatterConfig{{Format: "json"}, {Template: "missing.tmpl"}} {
+		if _, err := newFrontMatterFormat(cfg); err == nil {
+			t.Errorf("newFrontMatterFormat(%+v) succeeded", *cfg)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testCommitter is the identity of test commits; signing configured for the user is turned off
+var testCommitter = []string{"-c", "user.name=t", "-c", "user.email=t@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}
+
+// newTestRepo points gitDir at a new repository in a temporary directory until the test ends
+func newTestRepo(t *testing.T) {
+	t.Helper()
+	saved := gitDir
+	t.Cleanup(func() { gitDir = saved })
+	gitDir = t.TempDir()
+	testGit(t, "init", "--quiet")
+}
+
+// testGit runs git in gitDir as the test committer and returns its trimmed output
+func testGit(t *testing.T, args ...string) string {
+	t.Helper()
+	output, err := gitOutput(append(append([]string{}, testCommitter...), args...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(output)
+}
+
+// testCommit writes files, keyed by slash-separated paths relative to gitDir, and commits every
+// change in the repository with message; it returns the hash of the new commit
+func testCommit(t *testing.T, message string, files map[string]string) string {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(gitDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testGit(t, "add", "-A")
+	testGit(t, "commit", "--quiet", "--allow-empty", "-m", message)
+	return testGit(t, "rev-parse", gitRefHEAD)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// goModule is the directory of the Go module released with prefixed tags such as "api/v1.2.3",
+// relative to the repository root; empty for the root module
+var goModule string
+
+// moduleTagPattern splits a Go module tag into the module directory and the version
+var moduleTagPattern = regexp.MustCompile(`^(.+)/(v\d[^/]*)$`)
+
+// splitModuleTag returns the module directory and version of a tag like "api/v1.2.3"; tags
+// without a directory belong to the root module
+func splitModuleTag(tag string) (dir, version string) {
+	if m := moduleTagPattern.FindStringSubmatch(tag); m != nil {
+		return m[1], m[2]
+	}
+	return "", tag
+}
+
+// nestedGoModules returns the directories of the Go modules below the repository root
+func nestedGoModules() []string {
+	output, err := gitOutput("ls-files", "--", "*/go.mod")
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, file := range strings.Fields(output) {
+		dirs = append(dirs, path.Dir(file))
+	}
+	return dirs
+}
+
+// setGoModule selects the module released with --module, or the module named by the directory
+// of a --tag like "api/v1.2.3" when that directory has a go.mod; it returns the tag with the
+// module directory, which --tag may leave out when --module is given
+func setGoModule(module, tag string) (string, error) {
+	module = strings.Trim(filepath.ToSlash(filepath.Clean(module)), "/")
+	if module == "." {
+		module = ""
+	}
+	dir, version := splitModuleTag(tag)
+	switch {
+	case module == "" && dir == "":
+		return tag, nil
+	case module == "":
+		if _, err := os.Stat(repoPath(filepath.Join(filepath.FromSlash(dir), "go.mod"))); err != nil {
+			// Not a module directory, e.g. a "release/v1.0" tag naming scheme
+			return tag, nil
+		}
+		module = dir
+	case dir != "" && dir != module:
+		return "", &ConfigError{Err: fmt.Errorf("--tag %s is not a tag of module %s", tag, module)}
+	}
+	if _, err := os.Stat(repoPath(filepath.Join(filepath.FromSlash(module), "go.mod"))); err != nil {
+		return "", &ConfigError{Err: fmt.Errorf("--module %s has no go.mod", module)}
+	}
+	goModule = module
+	if tag == "" {
+		return "", nil
+	}
+	return module + "/" + version, nil
+}
+
+// tagInModule reports whether a tag belongs to the selected module. Without --module, tags of
+// nested modules are left out, so the root module of a multi-module repository only sees its own.
+func tagInModule(tag string, nested []string) bool {
+	dir, _ := splitModuleTag(tag)
+	if goModule != "" {
+		return dir == goModule
+	}
+	return dir == "" || !slices.Contains(nested, dir)
+}
+
+// describeMatchArgs limits git describe to the tags of the selected module
+func describeMatchArgs() []string {
+	if goModule != "" {
+		return []string{"--match", goModule + "/v[0-9]*"}
+	}
+	var args []string
+	for _, dir := range nestedGoModules() {
+		args = append(args, "--exclude", dir+"/v[0-9]*")
+	}
+	return args
+}
+
+// moduleVersion returns the version of a tag of the selected module without the module directory
+func moduleVersion(tag string) string {
+	if goModule == "" {
+		return tag
+	}
+	return strings.TrimPrefix(tag, goModule+"/")
+}
+
+// moduleTag returns the tag of a version of the selected module
+func moduleTag(version string) string {
+	if goModule == "" || strings.HasPrefix(version, goModule+"/") {
+		return version
+	}
+	return goModule + "/" + version
+}
+
+// modulePathspec limits git commands to the files of the selected module; the root module of a
+// multi-module repository leaves out the nested modules
+func modulePathspec() []string {
+	if goModule != "" {
+		return []string{"--", goModule}
+	}
+	nested := nestedGoModules()
+	if len(nested) == 0 {
+		return nil
+	}
+	args := []string{"--", "."}
+	for _, dir := range nested {
+		args = append(args, ":(exclude)"+dir)
+	}
+	return args
+}
+
+// inModule reports whether a file belongs to the selected module, given the nested modules
+func inModule(file string, nested []string) bool {
+	if goModule != "" {
+		return strings.HasPrefix(file, goModule+"/")
+	}
+	for _, dir := range nested {
+		if strings.HasPrefix(file, dir+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+// moduleFiles keeps the name-status lines of files in the selected module
+func moduleFiles(nameStatus string) string {
+	var nested []string
+	if goModule == "" {
+		if nested = nestedGoModules(); len(nested) == 0 {
+			return nameStatus
+		}
+	}
+	var kept []string
+	for _, line := range strings.Split(nameStatus, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) >= 2 && inModule(fields[len(fields)-1], nested) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitModuleTag(t *testing.T) {
+	tests := map[string][2]string{
+		"api/v1.2.3":        {"api", "v1.2.3"},
+		"tools/gen/v0.1.0":  {"tools/gen", "v0.1.0"},
+		"v1.2.3":            {"", "v1.2.3"},
+		"release/candidate": {"", "release/candidate"},
+	}
+	for tag, want := range tests {
+		if dir, version := splitModuleTag(tag); dir != want[0] || version != want[1] {
+			t.Errorf("splitModuleTag(%q) = %q, %q, want %q", tag, dir, version, want)
+		}
+	}
+}
+
+func TestGoModuleTags(t *testing.T) {
+	savedModule, savedHeading := goModule, changelogHeading
+	defer func() { goModule, changelogHeading = savedModule, savedHeading }()
+	newTestRepo(t)
+	goModule = ""
+
+	commit := func(file, message string) {
+		t.Helper()
+		testCommit(t, message, map[string]string{file: message})
+	}
+	commit("go.mod", "feat: root module")
+	commit("api/go.mod", "feat: api module")
+	for _, tag := range []string{"v1.0.0", "api/v0.1.0"} {
+		testGit(t, "tag", tag)
+	}
+	commit("api/handler.go", "feat(api)!: add handler")
+	commit("main.go", "feat: add main")
+
+	// The root module does not see the tags of the api module
+	if tags, err := getAllTags(); err != nil || !reflect.DeepEqual(tags, []string{"v1.0.0"}) {
+		t.Errorf("root getAllTags() = %q, %v", tags, err)
+	}
+	if tag := getLatestTag(); tag != "v1.0.0" {
+		t.Errorf("root getLatestTag() = %q", tag)
+	}
+	// Nor the files and commits of the api module
+	if diff, err := getGitDiff("v1.0.0", gitRefHEAD); err != nil || strings.TrimSpace(diff) != "A\tmain.go" {
+		t.Errorf("root getGitDiff() = %q, %v", diff, err)
+	}
+	if commits, err := getGitCommits("v1.0.0", gitRefHEAD); err != nil || strings.Contains(commits, "add handler") || !strings.Contains(commits, "add main") {
+		t.Errorf("root getGitCommits() = %q, %v", commits, err)
+	}
+	if stats, err := computeReleaseStats("v1.0.0", gitRefHEAD); err != nil || stats.Commits != 1 || stats.FilesChanged != 1 {
+		t.Errorf("root computeReleaseStats() = %+v, %v", stats, err)
+	}
+	if breaking, err := hasBreakingChanges("v1.0.0", gitRefHEAD); err != nil || breaking {
+		t.Errorf("root hasBreakingChanges() = %v, %v; want the api module's breaking change left out", breaking, err)
+	}
+
+	var configErr *ConfigError
+	if _, err := setGoModule("", "release/v1.0.0"); err != nil || goModule != "" {
+		t.Errorf("setGoModule() for a directory without go.mod = %v, module %q", err, goModule)
+	}
+	if _, err := setGoModule("web", ""); !errors.As(err, &configErr) {
+		t.Errorf("setGoModule(web) without go.mod = %v, want a ConfigError", err)
+	}
+	if _, err := setGoModule("api", "tools/v1.0.0"); !errors.As(err, &configErr) {
+		t.Errorf("setGoModule(api) with another module's tag = %v, want a ConfigError", err)
+	}
+	tag, err := setGoModule("api/", "v0.2.0")
+	if err != nil || tag != "api/v0.2.0" || goModule != "api" {
+		t.Fatalf("setGoModule(api/, v0.2.0) = %q, %v, module %q", tag, err, goModule)
+	}
+	if tag, err := setGoModule("", "api/v0.2.0"); err != nil || tag != "api/v0.2.0" || goModule != "api" {
+		t.Errorf("setGoModule() from the tag = %q, %v, module %q", tag, err, goModule)
+	}
+
+	if tags, err := getAllTags(); err != nil || !reflect.DeepEqual(tags, []string{"api/v0.1.0"}) {
+		t.Errorf("api getAllTags() = %q, %v", tags, err)
+	}
+	if tag := getLatestTag(); tag != "api/v0.1.0" {
+		t.Errorf("api getLatestTag() = %q", tag)
+	}
+	if stats, err := computeReleaseStats("api/v0.1.0", gitRefHEAD); err != nil || stats.Commits != 1 || stats.FilesChanged != 1 {
+		t.Errorf("api computeReleaseStats() = %+v, %v", stats, err)
+	}
+	if breaking, err := hasBreakingChanges("api/v0.1.0", gitRefHEAD); err != nil || !breaking {
+		t.Errorf("api hasBreakingChanges() = %v, %v", breaking, err)
+	}
+	diff, err := getGitDiff("api/v0.1.0", gitRefHEAD)
+	if err != nil || strings.TrimSpace(diff) != "A\tapi/handler.go" {
+		t.Errorf("api getGitDiff() = %q, %v", diff, err)
+	}
+	commits, err := getGitCommits("api/v0.1.0", gitRefHEAD)
+	if err != nil || !strings.Contains(commits, "add handler") || strings.Contains(commits, "add main") {
+		t.Errorf("api getGitCommits() = %q, %v", commits, err)
+	}
+
+	changelogHeading = mustHeadingFormat("", "")
+	if got := changelogHeading.FormatVersion("api/v0.2.0"); got != "v0.2.0" {
+		t.Errorf("FormatVersion(api/v0.2.0) = %q", got)
+	}
+	if !sameVersion("api/v0.2.0", "0.2.0") || sameVersion("api/v0.2.0", "v0.1.0") {
+		t.Error("sameVersion() does not ignore the module directory")
+	}
+	if got := moduleTag("v0.1.0"); got != "api/v0.1.0" {
+		t.Errorf("moduleTag(v0.1.0) = %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Values accepted by --group-by
+const (
+	groupByScope     = "scope"
+	groupByDirectory = "directory"
+)
+
+// rootComponent is the component name used for files at the repository root
+const rootComponent = "root"
+
+// conventionalScopePattern matches the scope of a conventional commit in `git log --oneline` output
+var conventionalScopePattern = regexp.MustCompile(`^\S+\s+\w+\(([^)]+)\)!?:`)
+
+// validGroupBy reports whether mode is a supported --group-by value
+func validGroupBy(mode string) bool {
+	return mode == "" || mode == groupByScope || mode == groupByDirectory
+}
+
+// commitScopes returns the distinct conventional commit scopes in one-line commit output
+func commitScopes(commits string) []string {
+	seen := map[string]bool{}
+	for _, line := range strings.Split(commits, "\n") {
+		if m := conventionalScopePattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			seen[strings.TrimSpace(m[1])] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// topLevelDirectories returns the distinct top-level directories touched by a name-status diff
+func topLevelDirectories(diff string) []string {
+	seen := map[string]bool{}
+	for _, line := range strings.Split(diff, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		dir, _, nested := strings.Cut(fields[len(fields)-1], "/")
+		if !nested {
+			dir = rootComponent
+		}
+		seen[dir] = true
+	}
+	return sortedKeys(seen)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// groupingNotes returns the prompt instructions for grouping bullets by component
+func groupingNotes(diff, commits string) []string {
+	var source string
+	var components []string
+	switch genOpts.GroupBy {
+	case groupByScope:
+		source = "コンベンショナルコミットのスコープ（`feat(api):` の `api`）"
+		components = commitScopes(commits)
+	case groupByDirectory:
+		source = "変更されたファイルのトップレベルディレクトリ（ルート直下のファイルは `" + rootComponent + "`）"
+		components = topLevelDirectories(diff)
+	default:
+		return nil
+	}
+
+	notes := []string{
+		fmt.Sprintf("各セクション内の項目を、%sをコンポーネントとしてグループ化し、`- **コンポーネント名**` の下に各項目をインデントしたリストとして記載してください", source),
+		"どのコンポーネントにも該当しない項目は、各セクションの最後にグループ化せずに記載してください",
+	}
+	if len(components) > 0 {
+		notes = append(notes, "コンポーネント名には次のいずれかを使用してください: "+strings.Join(components, ", "))
+	}
+	return notes
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCommitScopes(t *testing.T) {
+	commits := "abc1234 feat(api): add endpoint\ndef5678 fix(cli)!: rename flag\n0123456 feat(api): paginate\n89abcde docs: update README"
+	if got := commitScopes(commits); !reflect.DeepEqual(got, []string{"api", "cli"}) {
+		t.Errorf("commitScopes() = %v", got)
+	}
+}
+
+func TestTopLevelDirectories(t *testing.T) {
+	diff := "M\tcmd/server/main.go\nA\tinternal/db/db.go\nM\tREADME.md\nR100\told/a.go\tpkg/a.go"
+	want := []string{"cmd", "internal", "pkg", rootComponent}
+	if got := topLevelDirectories(diff); !reflect.DeepEqual(got, want) {
+		t.Errorf("topLevelDirectories() = %v, want %v", got, want)
+	}
+}
+
+func TestPromptExtrasGroupBy(t *testing.T) {
+	original := genOpts
+	defer func() { genOpts = original }()
+
+	genOpts = generationOptions{GroupBy: groupByScope}
+	got := promptExtras("", "", "M\tcmd/main.go", "abc1234 feat(api): add endpoint")
+	if !strings.Contains(got, "スコープ") || !strings.Contains(got, ": api") {
+		t.Errorf("promptExtras() = %q, want scope grouping with api component", got)
+	}
+
+	genOpts = generationOptions{GroupBy: groupByDirectory}
+	if got := promptExtras("", "", "M\tcmd/main.go", ""); !strings.Contains(got, ": cmd") {
+		t.Errorf("promptExtras() = %q, want directory grouping with cmd component", got)
+	}
+}
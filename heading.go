@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Default version heading template and date layout, e.g. "## [v1.0.0] - 2025-08-27"
+const (
+	defaultHeadingTemplate = "## [{version}] - {date}"
+	defaultDateLayout      = "2006-01-02"
+)
+
+// Values accepted by --version-style; an empty style writes versions as tagged
+const (
+	versionStyleVPrefix = "v-prefix"
+	versionStyleBare    = "bare"
+)
+
+// changelogHeading is the version heading format used for generation and parsing
+var changelogHeading = mustHeadingFormat(defaultHeadingTemplate, defaultDateLayout)
+
+// layoutTokens maps Go reference layout elements to the patterns they produce, longest first
+var layoutTokens = strings.NewReplacer(
+	"2006", `\d{4}`,
+	"January", `[A-Za-z]+`,
+	"Monday", `[A-Za-z]+`,
+	"Jan", `[A-Za-z]{3}`,
+	"Mon", `[A-Za-z]{3}`,
+	"MST", `[A-Z]+`,
+	"_2", `[ \d]\d`,
+	"01", `\d{2}`,
+	"02", `\d{2}`,
+	"06", `\d{2}`,
+	"15", `\d{2}`,
+	"04", `\d{2}`,
+	"05", `\d{2}`,
+	"1", `\d{1,2}`,
+	"2", `\d{1,2}`,
+	"3", `\d{1,2}`,
+)
+
+// headingFormat renders and recognizes version headings built from a template
+// with {version} and {date} placeholders and a Go date layout
+type headingFormat struct {
+	template     string
+	dateLayout   string
+	versionStyle string
+	versionExpr  *regexp.Regexp
+	bareExpr     *regexp.Regexp
+	dateExpr     *regexp.Regexp
+}
+
+// newHeadingFormat validates template and dateLayout; empty values select the defaults
+func newHeadingFormat(template, dateLayout string) (*headingFormat, error) {
+	if template == "" {
+		template = defaultHeadingTemplate
+	}
+	if dateLayout == "" {
+		dateLayout = defaultDateLayout
+	}
+
+	level := len(template) - len(strings.TrimLeft(template, "#"))
+	if level == 0 || level > 6 || !strings.HasPrefix(template[level:], " ") {
+		return nil, fmt.Errorf("heading format %q must start with a Markdown heading such as \"## \"", template)
+	}
+	prefix, suffix, ok := strings.Cut(template, "{version}")
+	if !ok {
+		return nil, fmt.Errorf("heading format %q must contain {version}", template)
+	}
+
+	// A bracketed version may contain anything but the closing bracket; a bare one must look like
+	// a version and ends at the first character that follows {version} in the template
+	capture := `([^\s\[\]()]*\d[^\s\[\]()]*)`
+	switch {
+	case strings.HasSuffix(prefix, "["):
+		capture = `([^\]]+)`
+	case strings.HasPrefix(suffix, " "):
+		capture = `([^\s\[\]()]*?\d[^\s\[\]()]*?)\s`
+	case suffix != "":
+		_, size := utf8.DecodeRuneInString(suffix)
+		capture = `([^\s\[\]()]*?\d[^\s\[\]()]*?)` + regexp.QuoteMeta(suffix[:size])
+	}
+	literal := regexp.QuoteMeta(strings.TrimSpace(prefix))
+	if strings.HasSuffix(prefix, " ") {
+		literal += `\s+`
+	}
+	literal = strings.ReplaceAll(literal, " ", `\s+`)
+
+	return &headingFormat{
+		template:    template,
+		dateLayout:  dateLayout,
+		versionExpr: regexp.MustCompile("^" + literal + capture),
+		bareExpr:    regexp.MustCompile(fmt.Sprintf(`^#{1,%d}\s+\[?(v?\d[^\s\[\]()]*?)\]?(?:\s|$)`, level)),
+		dateExpr:    regexp.MustCompile(layoutTokens.Replace(regexp.QuoteMeta(dateLayout))),
+	}, nil
+}
+
+func mustHeadingFormat(template, dateLayout string) *headingFormat {
+	h, err := newHeadingFormat(template, dateLayout)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// Level returns the Markdown heading level of version headings
+func (h *headingFormat) Level() int {
+	return len(h.template) - len(strings.TrimLeft(h.template, "#"))
+}
+
+// FormatDate formats a release date with the configured layout
+func (h *headingFormat) FormatDate(date time.Time) string {
+	return date.Format(h.dateLayout)
+}
+
+// Render returns the heading for version released on date
+func (h *headingFormat) Render(version string, date time.Time) string {
+	return strings.NewReplacer("{version}", h.FormatVersion(version), "{date}", h.FormatDate(date)).Replace(h.template)
+}
+
+// FormatVersion applies the version style to a tag name: "bare" drops a leading "v"
+// and "v-prefix" adds one to versions starting with a digit. The module directory of a
+// --module tag is left out.
+func (h *headingFormat) FormatVersion(version string) string {
+	version = moduleVersion(version)
+	numeric := strings.TrimPrefix(version, "v")
+	if numeric == "" || numeric[0] < '0' || numeric[0] > '9' {
+		return version
+	}
+	switch h.versionStyle {
+	case versionStyleBare:
+		return numeric
+	case versionStyleVPrefix:
+		return "v" + numeric
+	}
+	return version
+}
+
+// validVersionStyle reports whether style is a supported --version-style value
+func validVersionStyle(style string) bool {
+	return style == "" || style == versionStyleVPrefix || style == versionStyleBare
+}
+
+// Version returns the version named by a heading line. Besides the configured format it recognizes
+// common headings such as "## v1.2.0 - 2025-01-01", "## 1.2.0 (2025-01-01)" and "# [v1.2.0]" at the
+// same or a higher level, so sub-headings inside an entry are never taken for versions
+func (h *headingFormat) Version(line string) (string, bool) {
+	m := h.versionExpr.FindStringSubmatch(line)
+	if m == nil {
+		m = h.bareExpr.FindStringSubmatch(line)
+	}
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// sameVersion reports whether two versions match, ignoring surrounding spaces, a leading "v"
+// and the module directory of --module tags
+func sameVersion(a, b string) bool {
+	return strings.TrimPrefix(moduleVersion(strings.TrimSpace(a)), "v") == strings.TrimPrefix(moduleVersion(strings.TrimSpace(b)), "v")
+}
+
+// Date returns the release date in a heading line
+func (h *headingFormat) Date(line string) (time.Time, bool) {
+	for _, candidate := range h.dateExpr.FindAllString(line, -1) {
+		if d, err := time.Parse(h.dateLayout, candidate); err == nil {
+			return d, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// setextUnderlinePattern matches the underline of a setext heading
+var setextUnderlinePattern = regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`)
+
+// ATX rewrites setext version headings in LF-normalized content, such as "1.2.0" underlined with
+// "-----" in older hand-written changelogs, as headings at the version level so they are parsed
+// like any other version heading. Other setext headings and horizontal rules are left unchanged.
+func (h *headingFormat) ATX(content string) string {
+	lines, _ := h.atxLines(content)
+	return strings.Join(lines, "\n")
+}
+
+// setextHeadings returns the setext version headings of LF-normalized content keyed by the line
+// ATX rewrites each to, so the original heading can be put back with restoreSetext
+func (h *headingFormat) setextHeadings(content string) map[string]string {
+	lines := strings.Split(content, "\n")
+	out, numbers := h.atxLines(content)
+	headings := map[string]string{}
+	for i, line := range out {
+		if n := numbers[i] - 1; line != lines[n] {
+			headings[line] = lines[n] + "\n" + lines[n+1]
+		}
+	}
+	return headings
+}
+
+// restoreSetext puts back the setext headings that ATX rewrote in content and that are still
+// unchanged, so an update only rewrites the lines it touches
+func restoreSetext(content string, headings map[string]string) string {
+	if len(headings) == 0 {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if original, ok := headings[line]; ok {
+			lines[i] = original
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// atxLines splits content into lines like ATX and returns the 1-based number of each line in the
+// original content, so problems found in the rewritten lines can be reported where they are
+func (h *headingFormat) atxLines(content string) ([]string, []int) {
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+	numbers := make([]int, 0, len(lines))
+	prefix := strings.Repeat("#", h.Level()) + " "
+	for i := 0; i < len(lines); i++ {
+		numbers = append(numbers, i+1)
+		text := strings.TrimSpace(lines[i])
+		if text != "" && !strings.HasPrefix(text, "#") && i+1 < len(lines) && setextUnderlinePattern.MatchString(lines[i+1]) &&
+			(i == 0 || strings.TrimSpace(lines[i-1]) == "") {
+			if _, ok := h.Version(prefix + text); ok {
+				out = append(out, prefix+text)
+				i++
+				continue
+			}
+		}
+		out = append(out, lines[i])
+	}
+	return out, numbers
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHeadingFormatRenderAndParse(t *testing.T) {
+	date := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		template string
+		layout   string
+		heading  string
+		level    int
+	}{
+		{"", "", "## [v1.2.0] - 2025-09-01", 2},
+		{"## {version} ({date})", "", "## v1.2.0 (2025-09-01)", 2},
+		{"## {version} — {date}", "", "## v1.2.0 — 2025-09-01", 2},
+		{"# Version {version}, {date}", "Jan 2, 2006", "# Version v1.2.0, Sep 1, 2025", 1},
+		{"### {version}—{date}", "2006/01/02", "### v1.2.0—2025/09/01", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			h, err := newHeadingFormat(tt.template, tt.layout)
+			if err != nil {
+				t.Fatalf("newHeadingFormat() error = %v", err)
+			}
+			if got := h.Render("v1.2.0", date); got != tt.heading {
+				t.Errorf("Render() = %q, want %q", got, tt.heading)
+			}
+			if got := h.Level(); got != tt.level {
+				t.Errorf("Level() = %d, want %d", got, tt.level)
+			}
+			if v, ok := h.Version(tt.heading); !ok || v != "v1.2.0" {
+				t.Errorf("Version(%q) = %q, %v", tt.heading, v, ok)
+			}
+			if d, ok := h.Date(tt.heading); !ok || !d.Equal(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("Date(%q) = %v, %v", tt.heading, d, ok)
+			}
+		})
+	}
+}
+
+func TestHeadingFormatVersion(t *testing.T) {
+	bracketed := mustHeadingFormat(defaultHeadingTemplate, defaultDateLayout)
+	if v, ok := bracketed.Version("## [Unreleased]"); !ok || v != "Unreleased" {
+		t.Errorf("Version() = %q, %v, want Unreleased", v, ok)
+	}
+
+	for line, want := range map[string]string{
+		"## v1.2.0 - 2025-01-01": "v1.2.0",
+		"## 1.2.0 (2025-01-01)":  "1.2.0",
+		"## [1.2.0]":             "1.2.0",
+		"## v2.0.0-rc.1":         "v2.0.0-rc.1",
+	} {
+		if v, ok := bracketed.Version(line); !ok || v != want {
+			t.Errorf("Version(%q) = %q, %v, want %q", line, v, ok, want)
+		}
+	}
+	if _, ok := bracketed.Version("## Notes"); ok {
+		t.Error("a heading without a version should not match")
+	}
+
+	bare := mustHeadingFormat("# {version} ({date})", "")
+	if _, ok := bare.Version("# Changelog"); ok {
+		t.Error("a bare version heading should require a version number")
+	}
+	if _, ok := bare.Version("## v1.0.0 (2025-09-01)"); ok {
+		t.Error("a heading of a different level should not match")
+	}
+}
+
+func TestNewHeadingFormatErrors(t *testing.T) {
+	for _, template := range []string{"[{version}] - {date}", "## Release {date}", "##{version}"} {
+		if _, err := newHeadingFormat(template, ""); err == nil {
+			t.Errorf("newHeadingFormat(%q) should fail", template)
+		}
+	}
+}
+
+func TestHeadingFormatVersionStyle(t *testing.T) {
+	date := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		style, tag, want string
+	}{
+		{"", "v1.2.0", "## [v1.2.0] - 2025-09-01"},
+		{versionStyleBare, "v1.2.0", "## [1.2.0] - 2025-09-01"},
+		{versionStyleBare, "1.2.0", "## [1.2.0] - 2025-09-01"},
+		{versionStyleVPrefix, "1.2.0", "## [v1.2.0] - 2025-09-01"},
+		{versionStyleVPrefix, "v1.2.0", "## [v1.2.0] - 2025-09-01"},
+		{versionStyleBare, "release-1", "## [release-1] - 2025-09-01"},
+	}
+	for _, tt := range tests {
+		h := mustHeadingFormat("", "")
+		h.versionStyle = tt.style
+		if got := h.Render(tt.tag, date); got != tt.want {
+			t.Errorf("Render(%q) with style %q = %q, want %q", tt.tag, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestSameVersion(t *testing.T) {
+	if !sameVersion("1.2.0", "v1.2.0") || !sameVersion(" v1.2.0 ", "v1.2.0") {
+		t.Error("sameVersion() should ignore a leading v and spaces")
+	}
+	if sameVersion("v1.2.0", "v1.2.1") {
+		t.Error("sameVersion() should not match different versions")
+	}
+}
+
+func TestHeadingFormatATX(t *testing.T) {
+	h := mustHeadingFormat("", "")
+	content := "Changelog\n=========\n\n1.2.0 (2025-09-01)\n------------------\n\n- 新機能\n\nv1.1.0\n======\n\n- 修正\n\n---\n\nNotes\n-----\n"
+	want := "Changelog\n=========\n\n## 1.2.0 (2025-09-01)\n\n- 新機能\n\n## v1.1.0\n\n- 修正\n\n---\n\nNotes\n-----\n"
+	if got := h.ATX(content); got != want {
+		t.Errorf("ATX() =\n%q\nwant\n%q", got, want)
+	}
+
+	var versions []string
+	for _, entry := range parseChangelogEntries(content) {
+		versions = append(versions, entry.Version)
+	}
+	if len(versions) != 2 || versions[0] != "1.2.0" || versions[1] != "v1.1.0" {
+		t.Errorf("parseChangelogEntries() versions = %v, want [1.2.0 v1.1.0]", versions)
+	}
+}
+
+func TestHeadingFormatVersionAtHigherLevel(t *testing.T) {
+	h := mustHeadingFormat("", "")
+	if v, ok := h.Version("# [v1.2.0]"); !ok || v != "v1.2.0" {
+		t.Errorf("Version(# [v1.2.0]) = %q, %v", v, ok)
+	}
+	if v, ok := h.Version("### 1.2.0"); ok {
+		t.Errorf("Version(### 1.2.0) = %q, want a sub-heading to be ignored", v)
+	}
+	if _, ok := h.Version("# Changelog"); ok {
+		t.Error("Version(# Changelog) recognized the title as a version")
+	}
+}
+
+func TestUpdatedChangelogContentKeepsSetextHeadings(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	initial := "Changelog\n=========\n\n1.0.0 (2025-08-01)\n------------------\n\n- 初回リリース\n"
+	if err := os.WriteFile(file, []byte(initial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := updatedChangelogContent(file, "## 1.1.0 (2025-09-01)\n\n- 新機能\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Changelog\n=========\n\n## 1.1.0 (2025-09-01)\n\n- 新機能\n\n1.0.0 (2025-08-01)\n------------------\n\n- 初回リリース\n"
+	if got != want {
+		t.Errorf("updatedChangelogContent() =\n%q\nwant\n%q", got, want)
+	}
+}
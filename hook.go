@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stagedPaths returns the paths in git diff --name-status output; renames and copies list the new path
+func stagedPaths(nameStatus string) []string {
+	var paths []string
+	for _, line := range strings.Split(strings.TrimSpace(nameStatus), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) >= 2 {
+			paths = append(paths, fields[len(fields)-1])
+		}
+	}
+	return paths
+}
+
+// unreleasedEntry returns the Unreleased section of changelog content, if there is one
+func unreleasedEntry(content string) (changelogEntry, bool) {
+	for _, entry := range parseChangelogEntries(content) {
+		if strings.EqualFold(entry.Version, unreleasedVersion) {
+			return entry, true
+		}
+	}
+	return changelogEntry{}, false
+}
+
+// checkStagedChangelog validates a changelog the developer staged themselves: it has to follow
+// the spec and describe the changes in an Unreleased section
+func checkStagedChangelog(path, content string) error {
+	problems := lintProblems(content)
+	for _, p := range problems {
+		ui.Printf("⚠️  %s:%d: %s [%s]\n", path, p.Line, p.Message, p.Rule)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) found in %s (--spec %s)", len(problems), path, activeSpec.Name)
+	}
+	if entry, ok := unreleasedEntry(content); !ok || !entryHasChanges(entry.Body) {
+		return fmt.Errorf("%s has no Unreleased section describing the staged changes", path)
+	}
+	ui.Printf("✅ %s is staged and has an Unreleased section\n", path)
+	return nil
+}
+
+// hookMode runs as a pre-commit hook on the staged changes only. When the changelog is staged it is
+// validated; otherwise the staged changes are added to its Unreleased section, and the hook fails so
+// the developer can review the changelog, stage it and commit again.
+func hookMode(executor AIExecutor, changelogFile string) error {
+	// Commit ranges do not exist yet, so the options that read them are turned off
+	genOpts.LinkCommits = false
+	genOpts.AttributeAuthors = false
+	genOpts.DependencySection = false
+	genOpts.SecurityAdvisories = false
+	genOpts.Stats = false
+
+	nameStatus, err := gitOutput(nameStatusArgs("--cached")...)
+	if err != nil {
+		return fmt.Errorf("failed to read staged changes: %w", err)
+	}
+	path := filepath.ToSlash(filepath.Clean(changelogFile))
+	filename := repoPath(filepath.FromSlash(changelogFile))
+	content, err := readTextFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+
+	// The changelog is looked for before filtering, since ai_deny may hide it from the AI
+	for _, p := range stagedPaths(nameStatus) {
+		if p == path {
+			return checkStagedChangelog(path, content)
+		}
+	}
+	staged := aiFilter.filterNameStatus(strings.TrimSpace(nameStatus))
+	if staged == "" {
+		ui.Println("ℹ️  No staged changes to describe.")
+		return nil
+	}
+
+	existing, hasUnreleased := unreleasedEntry(content)
+	heading := existing.Heading
+	if !hasUnreleased {
+		// Like the section --spec keepachangelog-1.1 adds, a new Unreleased heading has no date
+		heading = strings.Repeat("#", changelogHeading.Level()) + " [" + unreleasedVersion + "]"
+	}
+	spin := startSpinner(ui.Sprintf("Describing %d staged file(s) in the Unreleased section", countLines(staged)), !ui.plain)
+	response, err := executor.Execute(hookPrompt(staged, existing.Body, heading))
+	spin.Stop()
+	if err != nil {
+		return &AIError{Err: fmt.Errorf("failed to generate the Unreleased section: %w", err)}
+	}
+	entry, err := validateEntry(executor, response, heading)
+	if err != nil {
+		return &AIError{Err: fmt.Errorf("failed to generate the Unreleased section: %w", err)}
+	}
+	if _, err := writeChangelogOutputs(filename, entry); err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+
+	ui.Printf("\n📝 Unreleased section of %s:\n", path)
+	ui.Separator()
+	ui.Entry(entry)
+	ui.Separator()
+	return fmt.Errorf("%s was updated for the staged changes; review it, stage it with git add %s and commit again", path, path)
+}
+
+// hookPrompt asks for the Unreleased section with the staged changes added to the items it already has
+func hookPrompt(staged, existing, heading string) string {
+	if existing == "" {
+		existing = "（まだ項目はありません）"
+	}
+	build := func(_, _, stagedDiff string) string {
+		return fmt.Sprintf(`これからコミットされるステージ済みの変更を、CHANGELOGのUnreleasedセクションに追記してください。
+
+現在のUnreleasedセクションの内容:
+---
+%s
+---
+
+ステージ済みの変更（git diff --cached --name-status）:
+---
+%s
+---
+
+以下の見出しから開始し、既存の項目をすべて残したうえでステージ済みの変更を追加したUnreleasedセクション全体を出力してください:
+%s
+
+セクションは ### %s の順序で、該当する変更がある場合のみ記載してください。
+
+注意事項：
+- 各セクションヘッダーの後には必ず空行を入れてください
+- 各セクションの内容は箇条書き（- ）のみで記載してください
+- 前置きや説明文は一切含めないでください
+- 既存の項目と同じ変更は重複して追加しないでください
+- 各項目は日本語で記述し、ユーザーにとって価値のある情報を具体的に記載してください
+- 技術的な詳細よりも、ユーザーへの影響を重視してください`, existing, stagedDiff, heading, strings.Join(changelogSections, " / ### "))
+	}
+	return fitPrompt(build, "", "", staged, promptExtras("", "", staged, ""))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStagedPaths(t *testing.T) {
+	got := stagedPaths("M\tmain.go\nR100\told.go\tnew.go\nA\tdocs/CHANGELOG.md")
+	if want := []string{"main.go", "new.go", "docs/CHANGELOG.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stagedPaths() = %q, want %q", got, want)
+	}
+	if got := stagedPaths(""); got != nil {
+		t.Errorf("stagedPaths(\"\") = %q", got)
+	}
+}
+
+func TestHookMode(t *testing.T) {
+	savedOpts, savedUI := genOpts, ui
+	defer func() { genOpts, ui = savedOpts, savedUI }()
+	newTestRepo(t)
+	ui = &console{out: &strings.Builder{}, plain: true}
+
+	write := func(file, content string) {
+		if err := os.WriteFile(filepath.Join(gitDir, file), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testCommit(t, "init", nil)
+	write("CHANGELOG.md", "# Changelog\n\n## [Unreleased]\n\n### 追加\n\n- 既存の機能\n\n## [v1.0.0] - 2024-01-01\n\n- A\n")
+
+	executor := &MockExecutor{}
+	if err := hookMode(executor, "CHANGELOG.md"); err != nil || len(executor.prompts) != 0 {
+		t.Fatalf("hookMode() without staged changes = %v after %d prompt(s)", err, len(executor.prompts))
+	}
+
+	// Staged changes are added to the Unreleased section, and the hook fails until it is staged
+	write("export.go", "package main\n")
+	testGit(t, "add", "export.go")
+	executor.response = "## [Unreleased]\n\n### 追加\n\n- 既存の機能\n- エクスポート機能"
+	if err := hookMode(executor, "CHANGELOG.md"); err == nil || !strings.Contains(err.Error(), "git add CHANGELOG.md") {
+		t.Fatalf("hookMode() = %v, want an error asking to stage the changelog", err)
+	}
+	if prompt := executor.prompts[0]; !strings.Contains(prompt, "A\texport.go") || !strings.Contains(prompt, "- 既存の機能") {
+		t.Errorf("prompt lacks the staged changes or the existing items:\n%s", prompt)
+	}
+	content, err := readTextFile(filepath.Join(gitDir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(content, "- エクスポート機能\n\n## [v1.0.0]") || strings.Count(content, "[Unreleased]") != 1 {
+		t.Errorf("changelog after the hook:\n%s", content)
+	}
+
+	// A staged changelog is validated instead of regenerated
+	testGit(t, "add", "CHANGELOG.md")
+	if err := hookMode(executor, "CHANGELOG.md"); err != nil || len(executor.prompts) != 1 {
+		t.Errorf("hookMode() with the changelog staged = %v after %d prompt(s)", err, len(executor.prompts))
+	}
+	write("CHANGELOG.md", "# Changelog\n\n## [v1.0.0] - 2024-01-01\n\n- A\n")
+	testGit(t, "add", "CHANGELOG.md")
+	if err := hookMode(executor, "CHANGELOG.md"); err == nil || !strings.Contains(err.Error(), "no Unreleased section") {
+		t.Errorf("hookMode() with a staged changelog without Unreleased = %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClientTrustsCACert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	saved := caCertFile
+	defer func() { caCertFile = saved }()
+
+	caCertFile = ""
+	client, err := newHTTPClient(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Fatal("request to a server with an untrusted certificate succeeded")
+	}
+
+	caCertFile = filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, certPEM, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client, err = newHTTPClient(5 * time.Second)
+	if err != nil {
+		t.Fatalf("newHTTPClient() error = %v", err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request with --ca-cert failed: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestLoadCertPoolRejectsNonPEM(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(file, []byte("not a certificate"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCertPool(file); err == nil {
+		t.Error("loadCertPool() accepted a file without certificates")
+	}
+}
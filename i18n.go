@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Languages of the status messages, selected with --ui-lang
+const (
+	uiLangEnglish  = "en"
+	uiLangJapanese = "ja"
+)
+
+// messageCatalogs translate the status messages, keyed by their English format string including
+// emoji and newlines; messages missing from a catalog are printed in English
+var messageCatalogs = map[string]map[string]string{
+	uiLangJapanese: jaMessages,
+}
+
+// detectUILang returns the language of the first of LC_ALL, LC_MESSAGES and LANG that is set,
+// e.g. ja for ja_JP.UTF-8; anything else is English
+func detectUILang() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if strings.HasPrefix(value, uiLangJapanese) {
+			return uiLangJapanese
+		}
+		return uiLangEnglish
+	}
+	return uiLangEnglish
+}
+
+// setUILang switches the status messages of the console to lang
+func (c *console) setUILang(lang string) error {
+	switch lang {
+	case uiLangEnglish:
+		c.messages = nil
+	case uiLangJapanese:
+		c.messages = messageCatalogs[lang]
+	default:
+		return fmt.Errorf("--ui-lang must be %q or %q, not %q", uiLangEnglish, uiLangJapanese, lang)
+	}
+	return nil
+}
+
+// translate returns the message of the console language for an English message or format string
+func (c *console) translate(format string) string {
+	if translated, ok := c.messages[format]; ok {
+		return translated
+	}
+	return format
+}
+
+// Sprintf formats a message in the console language without writing it, e.g. for spinner labels
+// and questions
+func (c *console) Sprintf(format string, a ...any) string {
+	return fmt.Sprintf(c.translate(format), a...)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDetectUILang(t *testing.T) {
+	testCases := []struct {
+		lcAll, lcMessages, lang string
+		want                    string
+	}{
+		{"", "", "", uiLangEnglish},
+		{"", "", "ja_JP.UTF-8", uiLangJapanese},
+		{"", "", "en_US.UTF-8", uiLangEnglish},
+		{"C", "", "ja_JP.UTF-8", uiLangEnglish},
+		{"", "ja_JP.UTF-8", "en_US.UTF-8", uiLangJapanese},
+	}
+	for _, tc := range testCases {
+		t.Setenv("LC_ALL", tc.lcAll)
+		t.Setenv("LC_MESSAGES", tc.lcMessages)
+		t.Setenv("LANG", tc.lang)
+		if got := detectUILang(); got != tc.want {
+			t.Errorf("detectUILang() with LC_ALL=%q LC_MESSAGES=%q LANG=%q = %q, want %q", tc.lcAll, tc.lcMessages, tc.lang, got, tc.want)
+		}
+	}
+}
+
+func TestConsoleUILang(t *testing.T) {
+	var out strings.Builder
+	c := &console{out: &out}
+	if err := c.setUILang(uiLangJapanese); err != nil {
+		t.Fatal(err)
+	}
+	c.Printf("📌 Previous tag: %s\n", "v1.0.0")
+	c.Println("📥 Fetching latest tags from remote...")
+	c.Printf("✨ Untranslated %s\n", "message")
+	want := "📌 前のタグ: v1.0.0\n📥 リモートから最新のタグを取得しています...\n✨ Untranslated message\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if got := c.Sprintf("Verifying entry for %s", "v1.0.0"); got != "v1.0.0 のエントリーを検証しています" {
+		t.Errorf("Sprintf() = %q", got)
+	}
+
+	if err := c.setUILang(uiLangEnglish); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Sprintf("Verifying entry for %s", "v1.0.0"); got != "Verifying entry for v1.0.0" {
+		t.Errorf("Sprintf() in English = %q", got)
+	}
+	if err := c.setUILang("fr"); err == nil {
+		t.Error("setUILang(fr) succeeded, want an error")
+	}
+}
+
+// formatVerbPattern matches a formatting verb with an optional explicit argument index
+var formatVerbPattern = regexp.MustCompile(`%(?:\[(\d+)\])?[-+# 0]*[\d.]*([a-zA-Z%])`)
+
+// formatVerbs returns the verb of each argument of a format string in argument order
+func formatVerbs(format string) []string {
+	var verbs []string
+	next := 0
+	for _, m := range formatVerbPattern.FindAllStringSubmatch(format, -1) {
+		if m[2] == "%" {
+			continue
+		}
+		if m[1] != "" {
+			next, _ = strconv.Atoi(m[1])
+			next--
+		}
+		for len(verbs) <= next {
+			verbs = append(verbs, "")
+		}
+		verbs[next] = m[2]
+		next++
+	}
+	return verbs
+}
+
+func TestJapaneseCatalogVerbs(t *testing.T) {
+	for english, japanese := range jaMessages {
+		want, got := formatVerbs(english), formatVerbs(japanese)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("verbs of %q = %v, want %v as in %q", japanese, got, want, english)
+		}
+		if strings.HasSuffix(english, "\n") != strings.HasSuffix(japanese, "\n") {
+			t.Errorf("%q and its translation %q differ in the trailing newline", english, japanese)
+		}
+	}
+}
+
+// untranslatedMessages are printed as they are in every language
+var untranslatedMessages = map[string]bool{
+	"  1. git push && git push %s %s\n": true,
+}
+
+func TestJapaneseCatalogCoverage(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := regexp.MustCompile(`[A-Za-z]{2,} [A-Za-z]{2,}`)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			name := ""
+			switch fun := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				if x, ok := fun.X.(*ast.Ident); ok && x.Name == "ui" {
+					name = fun.Sel.Name
+				}
+			case *ast.Ident:
+				if fun.Name == "confirm" {
+					name = fun.Name
+				}
+			}
+			if !strings.HasPrefix(name, "Print") && name != "Sprintf" && name != "confirm" {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			message, _ := strconv.Unquote(lit.Value)
+			if name == "Println" {
+				message += "\n"
+			}
+			if words.MatchString(message) && !untranslatedMessages[message] && jaMessages[message] == "" {
+				t.Errorf("%s: %q has no Japanese translation", fset.Position(lit.Pos()), message)
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultIgnoredCommitPatterns match merge and version-bump commits, which add noise to entries
+var defaultIgnoredCommitPatterns = []string{
+	`^Merge (pull request|branch|remote-tracking branch|tag) `,
+	`^Merge .* into `,
+	`(?i)^(chore|build)\(release\)!?: `,
+	`(?i)^(chore|build)(\([^)]*\))?!?: (bump|prepare) (version|release)\b`,
+	`(?i)^(release|bump version):?\s+v?\d`,
+	`^v?\d+\.\d+\.\d+\S*$`,
+}
+
+// commitFilter decides which commits are passed to the AI
+var commitFilter = &commitIgnoreFilter{}
+
+// commitIgnoreFilter drops commits whose subject matches any of its patterns
+type commitIgnoreFilter struct {
+	patterns []*regexp.Regexp
+}
+
+// stringList is a flag.Value collecting every occurrence of a repeatable flag
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ", ")
+}
+
+// Set appends a flag occurrence
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// newCommitIgnoreFilter compiles patterns, prepending the defaults unless withDefaults is false
+func newCommitIgnoreFilter(patterns []string, withDefaults bool) (*commitIgnoreFilter, error) {
+	if withDefaults {
+		patterns = append(append([]string{}, defaultIgnoredCommitPatterns...), patterns...)
+	}
+	f := &commitIgnoreFilter{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit ignore pattern %q: %w", p, err)
+		}
+		f.patterns = append(f.patterns, re)
+	}
+	return f, nil
+}
+
+// ignored reports whether a commit subject matches an ignore pattern
+func (f *commitIgnoreFilter) ignored(subject string) bool {
+	for _, re := range f.patterns {
+		if re.MatchString(subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterOneline removes ignored commits from `git log --oneline` output
+func (f *commitIgnoreFilter) filterOneline(output string) string {
+	if len(f.patterns) == 0 {
+		return output
+	}
+	var kept []string
+	for _, line := range strings.SplitAfter(output, "\n") {
+		_, subject, _ := strings.Cut(strings.TrimRight(line, "\n"), " ")
+		if line != "" && !f.ignored(subject) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "")
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestCommitIgnoreFilter(t *testing.T) {
+	f, err := newCommitIgnoreFilter([]string{`^chore\(deps\):`}, true)
+	if err != nil {
+		t.Fatalf("newCommitIgnoreFilter() error = %v", err)
+	}
+
+	output := "a1 feat: add export\n" +
+		"b2 Merge pull request #12 from org/feature\n" +
+		"c3 chore(release): 1.2.0\n" +
+		"d4 1.2.0\n" +
+		"e5 chore(deps): bump golang.org/x/net\n" +
+		"f6 Merge branch 'main' into feature\n" +
+		"a7 fix: handle bump version edge case\n" +
+		"b8 Release v1.3.0\n"
+	want := "a1 feat: add export\na7 fix: handle bump version edge case\n"
+	if got := f.filterOneline(output); got != want {
+		t.Errorf("filterOneline() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCommitIgnoreFilterWithoutDefaults(t *testing.T) {
+	f, err := newCommitIgnoreFilter(nil, false)
+	if err != nil {
+		t.Fatalf("newCommitIgnoreFilter() error = %v", err)
+	}
+	output := "b2 Merge pull request #12 from org/feature\n"
+	if got := f.filterOneline(output); got != output {
+		t.Errorf("filterOneline() = %q, want unchanged", got)
+	}
+
+	if _, err := newCommitIgnoreFilter([]string{"("}, false); err == nil {
+		t.Error("newCommitIgnoreFilter() should reject invalid patterns")
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+// initialReleaseMode decides whether the entry is written as the first release of the project and
+// reports the choice. An explicit --initial-release wins; otherwise it is the first release when no
+// earlier tag exists.
+func initialReleaseMode(override *bool, previousTag string) bool {
+	switch {
+	case override != nil && *override:
+		ui.Println("📌 Writing the entry as the initial release (--initial-release).")
+		return true
+	case override != nil:
+		ui.Println("📌 Writing a regular entry for the changes (--initial-release=false).")
+		return false
+	case previousTag == "":
+		ui.Println("📌 Writing the entry as the initial release because no earlier tag exists (use --initial-release=false to describe it as regular changes).")
+		return true
+	default:
+		ui.Printf("📌 Writing a regular entry for the changes since %s (use --initial-release to describe the whole project).\n", previousTag)
+		return false
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInitialReleaseMode(t *testing.T) {
+	yes, no := true, false
+	for _, tt := range []struct {
+		name        string
+		override    *bool
+		previousTag string
+		want        bool
+	}{
+		{"no earlier tag", nil, "", true},
+		{"earlier tag", nil, "v1.0.0", false},
+		{"forced on", &yes, "v1.0.0", true},
+		{"forced off", &no, "", false},
+	} {
+		if got := initialReleaseMode(tt.override, tt.previousTag); got != tt.want {
+			t.Errorf("%s: initialReleaseMode() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateChangelogEntryInitialReleasePrompt(t *testing.T) {
+	for _, initial := range []bool{true, false} {
+		executor := &MockExecutor{response: "## [v1.0.0] - 2025-08-27\n\n### 追加\n\n- Test"}
+		// Modified files no longer decide the mode; the caller does
+		if _, err := generateChangelogEntry(executor, "", "v1.0.0", "M\tmain.go", "abc1234 feat: x", "", "", initial); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(executor.prompts[0], "これは初回リリースです"); got != initial {
+			t.Errorf("initialRelease=%v: initial release prompt used = %v", initial, got)
+		}
+	}
+}
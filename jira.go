@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// jiraDescriptionLimit is the maximum length of a Jira version description
+const jiraDescriptionLimit = 255
+
+// jiraConfig maps releases to a Jira project
+type jiraConfig struct {
+	// URL is the Jira site, e.g. https://example.atlassian.net
+	URL string `json:"url"`
+	// Project is the project key, e.g. ABC
+	Project string `json:"project"`
+	// VersionFormat names the Jira version; {version} is replaced by the tag (default: the tag itself)
+	VersionFormat string `json:"version_format,omitempty"`
+}
+
+// jiraVersion is a project version returned by the Jira REST API
+type jiraVersion struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Project     string `json:"project,omitempty"`
+	Description string `json:"description"`
+	Released    bool   `json:"released"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+}
+
+// jiraClient calls the Jira REST API v2
+type jiraClient struct {
+	baseURL string
+	project string
+	auth    string
+	http    *http.Client
+}
+
+// newJiraClient authenticates with JIRA_EMAIL and JIRA_API_TOKEN (Jira Cloud) or a JIRA_TOKEN personal access token,
+// looked up with lookupCredential
+func newJiraClient(cfg *jiraConfig) (*jiraClient, error) {
+	if cfg == nil || cfg.URL == "" || cfg.Project == "" {
+		return nil, fmt.Errorf("jira.url and jira.project must be set in the configuration file")
+	}
+
+	var auth string
+	if token := lookupCredential("JIRA_TOKEN"); token != "" {
+		auth = "Bearer " + token
+	} else if email, apiToken := lookupCredential("JIRA_EMAIL"), lookupCredential("JIRA_API_TOKEN"); email != "" && apiToken != "" {
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(email, apiToken)
+		auth = req.Header.Get("Authorization")
+	} else {
+		return nil, fmt.Errorf("set JIRA_EMAIL and JIRA_API_TOKEN, or JIRA_TOKEN, to publish Jira releases")
+	}
+
+	client, err := newHTTPClient(30 * time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &jiraClient{
+		baseURL: strings.TrimSuffix(cfg.URL, "/"),
+		project: cfg.Project,
+		auth:    auth,
+		http:    client,
+	}, nil
+}
+
+func (c *jiraClient) do(method, path string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", c.auth)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("jira request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("jira %s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+// upsertVersion creates the released version, or updates it when the project already has one with the same name
+func (c *jiraClient) upsertVersion(v jiraVersion) error {
+	var existing []jiraVersion
+	if err := c.do(http.MethodGet, "/rest/api/2/project/"+url.PathEscape(c.project)+"/versions", nil, &existing); err != nil {
+		return err
+	}
+	for _, e := range existing {
+		if e.Name == v.Name {
+			return c.do(http.MethodPut, "/rest/api/2/version/"+url.PathEscape(e.ID), v, nil)
+		}
+	}
+	v.Project = c.project
+	return c.do(http.MethodPost, "/rest/api/2/version", v, nil)
+}
+
+// addFixVersion links an issue to the version
+func (c *jiraClient) addFixVersion(issue, version string) error {
+	body := map[string]any{
+		"update": map[string]any{
+			"fixVersions": []any{map[string]any{"add": map[string]string{"name": version}}},
+		},
+	}
+	return c.do(http.MethodPut, "/rest/api/2/issue/"+url.PathEscape(issue), body, nil)
+}
+
+// jiraIssueKeys returns the distinct issue keys of project mentioned in commits, in order of appearance
+func jiraIssueKeys(project, commits string) []string {
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(project) + `-\d+\b`)
+	seen := map[string]bool{}
+	var keys []string
+	for _, key := range pattern.FindAllString(commits, -1) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// jiraDescription flattens an entry into a plain-text description within Jira's length limit
+func jiraDescription(entry string) string {
+	var lines []string
+	for _, line := range strings.Split(normalizeNewlines(entry), "\n") {
+		if _, isHeading := changelogHeading.Version(line); isHeading || strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(strings.TrimLeft(line, "#")))
+	}
+	description := strings.Join(lines, "\n")
+	if runes := []rune(description); len(runes) > jiraDescriptionLimit {
+		description = string(runes[:jiraDescriptionLimit-1]) + "…"
+	}
+	return description
+}
+
+// publishJiraRelease creates or updates the Jira version for tag and links the issues mentioned in commits
+func publishJiraRelease(cfg *jiraConfig, tag, entry, commits string) error {
+	client, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	name := tag
+	if cfg.VersionFormat != "" {
+		name = strings.ReplaceAll(cfg.VersionFormat, versionPlaceholder, tag)
+	}
+	version := jiraVersion{
+		Name:        name,
+		Description: jiraDescription(entry),
+		Released:    true,
+		ReleaseDate: releaseDate().Format("2006-01-02"),
+	}
+	if err := client.upsertVersion(version); err != nil {
+		return err
+	}
+
+	for _, issue := range jiraIssueKeys(cfg.Project, commits) {
+		if err := client.addFixVersion(issue, name); err != nil {
+			ui.Printf("⚠️  Warning: Failed to link %s to %s: %v\n", issue, name, err)
+		}
+	}
+	ui.Printf("✅ Published Jira release %s\n", name)
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJiraIssueKeys(t *testing.T) {
+	commits := "abc1234 feat: add export (ABC-12)\ndef5678 fix: ABC-7 and XYZ-3\n0123456 chore: follow-up for ABC-12"
+	want := []string{"ABC-12", "ABC-7"}
+	if got := jiraIssueKeys("ABC", commits); !reflect.DeepEqual(got, want) {
+		t.Errorf("jiraIssueKeys() = %v, want %v", got, want)
+	}
+	if got := jiraIssueKeys("AB", commits); got != nil {
+		t.Errorf("jiraIssueKeys() matched a longer project key: %v", got)
+	}
+}
+
+func TestJiraDescription(t *testing.T) {
+	entry := "## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- 新機能"
+	if got := jiraDescription(entry); got != "追加\n- 新機能" {
+		t.Errorf("jiraDescription() = %q", got)
+	}
+	long := jiraDescription(strings.Repeat("あ", jiraDescriptionLimit+10))
+	if n := len([]rune(long)); n != jiraDescriptionLimit {
+		t.Errorf("jiraDescription() length = %d, want %d", n, jiraDescriptionLimit)
+	}
+}
+
+func TestPublishJiraRelease(t *testing.T) {
+	t.Setenv("JIRA_TOKEN", "secret")
+
+	var requests []string
+	var created jiraVersion
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`[{"id":"1","name":"app 1.1.0"}]`))
+		case r.URL.Path == "/rest/api/2/version":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("invalid payload: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &jiraConfig{URL: srv.URL + "/", Project: "ABC", VersionFormat: "app {version}"}
+	if err := publishJiraRelease(cfg, "1.2.0", "## [1.2.0]\n\n- item", "abc1234 feat: ABC-1"); err != nil {
+		t.Fatalf("publishJiraRelease() error = %v", err)
+	}
+
+	want := []string{
+		"GET /rest/api/2/project/ABC/versions",
+		"POST /rest/api/2/version",
+		"PUT /rest/api/2/issue/ABC-1",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+	if created.Name != "app 1.2.0" || created.Project != "ABC" || !created.Released || created.Description != "- item" {
+		t.Errorf("created version = %+v", created)
+	}
+}
+
+func TestNewJiraClientRequiresCredentials(t *testing.T) {
+	savedKeychain := keychainCredential
+	keychainCredential = func(string) (string, error) { return "", nil }
+	defer func() { keychainCredential = savedKeychain }()
+	t.Setenv("JIRA_TOKEN", "")
+	t.Setenv("JIRA_EMAIL", "")
+	t.Setenv("JIRA_API_TOKEN", "")
+	if _, err := newJiraClient(&jiraConfig{URL: "https://example.atlassian.net", Project: "ABC"}); err == nil {
+		t.Error("newJiraClient() succeeded without credentials")
+	}
+}
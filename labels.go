@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// defaultLabelSections maps common PR labels to Keep a Changelog sections
+var defaultLabelSections = map[string]string{
+	"enhancement": "追加",
+	"feature":     "追加",
+	"bug":         "修正",
+	"breaking":    "変更",
+	"deprecation": "非推奨",
+	"security":    "セキュリティ",
+}
+
+// pullRequestRefPattern matches PR references such as "#123" in commit subjects
+var pullRequestRefPattern = regexp.MustCompile(`#(\d+)\b`)
+
+// fetchPRLabels returns the label names of a pull request; replaced in tests
+var fetchPRLabels = func(number string) ([]string, error) {
+	output, err := ghCommand("", "pr", "view", number, "--json", "labels", "--jq", ".labels[].name").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch labels for #%s: %w", number, err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// labelSections returns the default label mapping overridden by configured entries; labels are case-insensitive
+func labelSections(configured map[string]string) map[string]string {
+	sections := make(map[string]string, len(defaultLabelSections)+len(configured))
+	for label, section := range defaultLabelSections {
+		sections[label] = section
+	}
+	for label, section := range configured {
+		sections[strings.ToLower(label)] = section
+	}
+	return sections
+}
+
+// pullRequestNumbers returns the distinct PR numbers referenced in commits, in ascending order
+func pullRequestNumbers(commits string) []string {
+	seen := map[string]bool{}
+	for _, m := range pullRequestRefPattern.FindAllStringSubmatch(commits, -1) {
+		seen[m[1]] = true
+	}
+	numbers := sortedKeys(seen)
+	sort.SliceStable(numbers, func(i, j int) bool { return len(numbers[i]) < len(numbers[j]) })
+	return numbers
+}
+
+// categorizePullRequests maps each referenced PR to a section using its labels; PRs without a mapped label are omitted
+func categorizePullRequests(commits string, sections map[string]string) []string {
+	var lines []string
+	for _, number := range pullRequestNumbers(commits) {
+		labels, err := fetchPRLabels(number)
+		if err != nil {
+			ui.Printf("⚠️  Warning: %v\n", err)
+			continue
+		}
+		for _, label := range labels {
+			if section, ok := sections[strings.ToLower(label)]; ok {
+				lines = append(lines, fmt.Sprintf("#%s → %s（ラベル: %s）", number, section, label))
+				break
+			}
+		}
+	}
+	return lines
+}
+
+// labelNotes returns the prompt instructions carrying the label-based categorization of PRs in commits
+func labelNotes(commits string) []string {
+	if !genOpts.PRLabels {
+		return nil
+	}
+	lines := categorizePullRequests(commits, genOpts.LabelSections)
+	if len(lines) == 0 {
+		return nil
+	}
+	return []string{"以下のPRはラベルによって分類が確定しています。該当するPRの変更は必ず指定のセクションに記載してください:\n  - " + strings.Join(lines, "\n  - ")}
+}
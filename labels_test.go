@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPullRequestNumbers(t *testing.T) {
+	commits := "abc1234 feat: add export (#120)\ndef5678 fix: crash (#9)\n0123456 Merge pull request #120 from org/branch"
+	if got := pullRequestNumbers(commits); !reflect.DeepEqual(got, []string{"9", "120"}) {
+		t.Errorf("pullRequestNumbers() = %v", got)
+	}
+}
+
+func TestLabelSections(t *testing.T) {
+	sections := labelSections(map[string]string{"Type: Bug": "修正", "enhancement": "変更"})
+	if sections["type: bug"] != "修正" || sections["enhancement"] != "変更" || sections["security"] != "セキュリティ" {
+		t.Errorf("labelSections() = %v", sections)
+	}
+}
+
+func TestLabelNotes(t *testing.T) {
+	originalOpts, originalFetch := genOpts, fetchPRLabels
+	defer func() { genOpts, fetchPRLabels = originalOpts, originalFetch }()
+
+	fetchPRLabels = func(number string) ([]string, error) {
+		switch number {
+		case "1":
+			return []string{"documentation", "Bug"}, nil
+		case "2":
+			return []string{"documentation"}, nil
+		}
+		return nil, errors.New("not found")
+	}
+	genOpts = generationOptions{PRLabels: true, LabelSections: labelSections(nil)}
+
+	notes := labelNotes("a fix: crash (#1)\nb docs: typo (#2)\nc feat: gone (#3)")
+	if len(notes) != 1 || !strings.Contains(notes[0], "#1 → 修正（ラベル: Bug）") || strings.Contains(notes[0], "#2") {
+		t.Errorf("labelNotes() = %q", notes)
+	}
+
+	genOpts.PRLabels = false
+	if notes := labelNotes("a fix: crash (#1)"); notes != nil {
+		t.Errorf("labelNotes() without --pr-labels = %q, want nil", notes)
+	}
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Output formats of lint
+const (
+	lintFormatText   = "text"
+	lintFormatSARIF  = "sarif"
+	lintFormatGitHub = "github"
+	lintFormatGitLab = "gitlab"
+)
+
+// ruleDuplicateVersion is reported for a version heading that appears more than once
+const ruleDuplicateVersion = "duplicate-version"
+
+// lintRules describes the rules lint checks, in the order they are listed in SARIF output
+var lintRules = []struct{ ID, Description string }{
+	{ruleUnreleasedSection, "The spec requires an Unreleased section above the releases"},
+	{ruleSectionVocabulary, "Section headings must be in the section vocabulary of the spec"},
+	{ruleLinkReference, "The spec requires a link reference definition for every bracketed version heading"},
+	{ruleDuplicateVersion, "Each version has one entry; only the first one is replaced on updates"},
+}
+
+// lintProblems lists the problems of changelog content: the spec problems and duplicate versions
+func lintProblems(content string) []specProblem {
+	problems := activeSpec.problems(content)
+	lines, numbers := changelogHeading.atxLines(normalizeNewlines(content))
+	var versions []string
+	for i, line := range lines {
+		v, ok := changelogHeading.Version(line)
+		if !ok {
+			continue
+		}
+		for _, earlier := range versions {
+			if sameVersion(v, earlier) {
+				problems = append(problems, specProblem{Line: numbers[i], Rule: ruleDuplicateVersion, Message: "duplicate version " + v})
+				break
+			}
+		}
+		versions = append(versions, v)
+	}
+	return problems
+}
+
+// lintCommand checks the changelog against the spec and reports the problems for editors and CI
+func lintCommand(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	specName := fs.String("spec", specSimple, "Structural rules to check the changelog against: keepachangelog-1.1, simple or custom")
+	format := fs.String("format", lintFormatText, "Output format: text, sarif, github (workflow annotations) or gitlab (code quality report)")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update lint [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Checks the changelog against --spec and exits with an error when problems are found.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	switch *format {
+	case lintFormatText, lintFormatSARIF, lintFormatGitHub, lintFormatGitLab:
+	default:
+		return &ConfigError{Err: fmt.Errorf("unsupported lint format: %s", *format)}
+	}
+
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		return err
+	}
+	heading, err := newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat)
+	if err != nil {
+		return &ConfigError{Err: err}
+	}
+	changelogHeading = heading
+	if err := setSpec(*specName, cfg.Spec); err != nil {
+		return err
+	}
+
+	content, err := readTextFile(repoPath(filepath.FromSlash(*changelogFile)))
+	if err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+	problems := lintProblems(content)
+	// Reports name the file as given, relative to the repository root, so annotations find it
+	path := filepath.ToSlash(filepath.Clean(*changelogFile))
+
+	if *format == lintFormatText {
+		for _, p := range problems {
+			ui.Printf("⚠️  %s:%d: %s [%s]\n", path, p.Line, p.Message, p.Rule)
+		}
+	} else {
+		var rendered []byte
+		switch *format {
+		case lintFormatSARIF:
+			rendered, err = renderSARIF(path, problems)
+		case lintFormatGitHub:
+			rendered = []byte(renderGitHubAnnotations(path, problems))
+		case lintFormatGitLab:
+			rendered, err = renderCodeQuality(path, problems)
+		}
+		if err != nil {
+			return err
+		}
+		if *output == "" {
+			if _, err := os.Stdout.Write(rendered); err != nil {
+				return err
+			}
+		} else if err := os.WriteFile(*output, rendered, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", *output, err)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) found in %s (--spec %s)", len(problems), path, activeSpec.Name)
+	}
+	if *format == lintFormatText {
+		ui.Printf("✅ %s follows --spec %s\n", path, activeSpec.Name)
+	}
+	return nil
+}
+
+// renderSARIF formats problems as a SARIF 2.1.0 log, which GitHub code scanning shows on pull requests
+func renderSARIF(path string, problems []specProblem) ([]byte, error) {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type rule struct {
+		ID               string  `json:"id"`
+		ShortDescription message `json:"shortDescription"`
+	}
+	type location struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine int `json:"startLine"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	}
+	type result struct {
+		RuleID    string     `json:"ruleId"`
+		Level     string     `json:"level"`
+		Message   message    `json:"message"`
+		Locations []location `json:"locations"`
+	}
+
+	rules := make([]rule, len(lintRules))
+	for i, r := range lintRules {
+		rules[i] = rule{ID: r.ID, ShortDescription: message{r.Description}}
+	}
+	results := make([]result, len(problems))
+	for i, p := range problems {
+		var loc location
+		loc.PhysicalLocation.ArtifactLocation.URI = path
+		loc.PhysicalLocation.Region.StartLine = p.Line
+		results[i] = result{RuleID: p.Rule, Level: "error", Message: message{p.Message}, Locations: []location{loc}}
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{map[string]any{
+			"tool": map[string]any{"driver": map[string]any{
+				"name":           "changelog-update",
+				"version":        version,
+				"informationUri": "https://github.com/shivase/changelog",
+				"rules":          rules,
+			}},
+			"results": results,
+		}},
+	}
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode SARIF: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// renderGitHubAnnotations formats problems as GitHub Actions workflow commands, which show up as
+// annotations on the pull request when printed by a workflow step
+func renderGitHubAnnotations(path string, problems []specProblem) string {
+	var b strings.Builder
+	for _, p := range problems {
+		fmt.Fprintf(&b, "::error file=%s,line=%d,title=%s::%s\n",
+			escapeAnnotationProperty(path), p.Line, escapeAnnotationProperty("changelog "+p.Rule), escapeAnnotationData(p.Message))
+	}
+	return b.String()
+}
+
+// escapeAnnotationData escapes the message of a workflow command
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeAnnotationData(s))
+}
+
+// renderCodeQuality formats problems as a GitLab Code Quality report, which GitLab shows in merge
+// requests when it is uploaded as a codequality artifact
+func renderCodeQuality(path string, problems []specProblem) ([]byte, error) {
+	type issue struct {
+		Description string `json:"description"`
+		CheckName   string `json:"check_name"`
+		Fingerprint string `json:"fingerprint"`
+		Severity    string `json:"severity"`
+		Location    struct {
+			Path  string `json:"path"`
+			Lines struct {
+				Begin int `json:"begin"`
+			} `json:"lines"`
+		} `json:"location"`
+	}
+
+	issues := make([]issue, len(problems))
+	for i, p := range problems {
+		// The fingerprint leaves out the line so an issue is recognized after lines above it change
+		sum := sha256.Sum256([]byte(path + "\x00" + p.Rule + "\x00" + p.Message))
+		issues[i] = issue{Description: p.Message, CheckName: p.Rule, Fingerprint: hex.EncodeToString(sum[:]), Severity: "major"}
+		issues[i].Location.Path = path
+		issues[i].Location.Lines.Begin = p.Line
+	}
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the code quality report: %w", err)
+	}
+	return append(data, '\n'), nil
+}
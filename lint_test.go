@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintProblems(t *testing.T) {
+	defer func() { activeSpec = changelogSpecs[specSimple] }()
+	activeSpec = changelogSpecs[specKeepAChangelog]
+
+	// The setext heading takes two lines, so later problems must still point at the original lines
+	content := "# Changelog\n\n## [v1.1.0] - 2024-02-01\n\n### その他\n\n- C\n\nv1.0.0\n------\n\n- A\n\n## [v1.1.0] - 2024-02-01\n\n- B\n"
+	var got []string
+	for _, p := range lintProblems(content) {
+		got = append(got, fmt.Sprintf("%s@%d", p.Rule, p.Line))
+	}
+	want := []string{"unreleased-section@3", "link-reference@3", "section-vocabulary@5", "link-reference@14", "duplicate-version@14"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lintProblems = %q, want %q", got, want)
+	}
+}
+
+func TestRenderSARIF(t *testing.T) {
+	data, err := renderSARIF("docs/CHANGELOG.md", []specProblem{{Line: 7, Rule: ruleSectionVocabulary, Message: `section "その他" in v1.1.0 is not allowed`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != len(lintRules) || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("SARIF = %s", data)
+	}
+	r := log.Runs[0].Results[0]
+	if loc := r.Locations[0].PhysicalLocation; r.RuleID != ruleSectionVocabulary || loc.ArtifactLocation.URI != "docs/CHANGELOG.md" || loc.Region.StartLine != 7 {
+		t.Errorf("SARIF result = %+v", r)
+	}
+}
+
+func TestRenderGitHubAnnotations(t *testing.T) {
+	got := renderGitHubAnnotations("CHANGELOG.md", []specProblem{{Line: 3, Rule: ruleLinkReference, Message: "no link reference for v1.0.0\n100%"}})
+	want := "::error file=CHANGELOG.md,line=3,title=changelog link-reference::no link reference for v1.0.0%0A100%25\n"
+	if got != want {
+		t.Errorf("annotations = %q, want %q", got, want)
+	}
+	if got := escapeAnnotationProperty("a:b,c"); got != "a%3Ab%2Cc" {
+		t.Errorf("escapeAnnotationProperty = %q", got)
+	}
+}
+
+func TestRenderCodeQuality(t *testing.T) {
+	problems := []specProblem{{Line: 3, Rule: ruleDuplicateVersion, Message: "duplicate version v1.0.0"}}
+	first, err := renderCodeQuality("CHANGELOG.md", problems)
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems[0].Line = 10
+	moved, err := renderCodeQuality("CHANGELOG.md", problems)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var a, b []struct {
+		CheckName   string `json:"check_name"`
+		Fingerprint string `json:"fingerprint"`
+		Location    struct {
+			Path  string `json:"path"`
+			Lines struct {
+				Begin int `json:"begin"`
+			} `json:"lines"`
+		} `json:"location"`
+	}
+	if err := json.Unmarshal(first, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(moved, &b); err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != 1 || a[0].CheckName != ruleDuplicateVersion || a[0].Location.Path != "CHANGELOG.md" || a[0].Location.Lines.Begin != 3 {
+		t.Errorf("code quality = %s", first)
+	}
+	if a[0].Fingerprint == "" || a[0].Fingerprint != b[0].Fingerprint || !strings.Contains(string(moved), `"begin": 10`) {
+		t.Errorf("fingerprint changed when the problem moved: %s / %s", first, moved)
+	}
+}
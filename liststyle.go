@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// listStyleConfig is the list formatting of a repository's changelog, from the list_style
+// configuration; the --bullet, --indent and --sub-bullets flags override it
+type listStyleConfig struct {
+	// Bullet is the list marker: "-", "*" or "+"
+	Bullet string `json:"bullet,omitempty"`
+	// Indent is the number of spaces per nesting level: 2 or 4
+	Indent int `json:"indent,omitempty"`
+	// SubBullets asks for the details of an item as nested bullets below a one-line summary
+	SubBullets bool `json:"sub_bullets,omitempty"`
+}
+
+// validListStyle reports why a list style cannot be used; zero values keep what the AI returns
+func validListStyle(style listStyleConfig) error {
+	if style.Bullet != "" && !slices.Contains([]string{"-", "*", "+"}, style.Bullet) {
+		return fmt.Errorf("--bullet (list_style.bullet) must be \"-\", \"*\" or \"+\", not %q", style.Bullet)
+	}
+	if style.Indent != 0 && style.Indent != 2 && style.Indent != 4 {
+		return fmt.Errorf("--indent (list_style.indent) must be 2 or 4, not %d", style.Indent)
+	}
+	return nil
+}
+
+// subBulletNote asks the AI to split the details of an item into nested bullets
+func subBulletNote() []string {
+	if !genOpts.ListStyle.SubBullets {
+		return nil
+	}
+	return []string{"各項目の1行目は変更の要約だけにし、背景・影響・使い方などの詳細は、その項目の下に入れ子の箇条書きで記載してください"}
+}
+
+// applyListStyle rewrites the list markers and the indentation of nested items and their
+// continuation lines in entry, whatever markers and indentation the AI used. Nesting is taken from
+// the relative indentation of the items, so an AI that indents by 3 spaces or a tab still nests
+// correctly. Code fences are left untouched.
+func applyListStyle(entry string, style listStyleConfig) string {
+	if style.Bullet == "" && style.Indent == 0 {
+		return entry
+	}
+	// indents holds the original indentation of the open list levels
+	var indents []int
+	depth, inFence := -1, false
+	lines := strings.Split(entry, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		width := indentWidth(line)
+		m := listItemPattern.FindStringSubmatch(line)
+		switch {
+		case m != nil:
+			// Close the levels indented deeper than this item
+			for len(indents) > 0 && indents[len(indents)-1] > width {
+				indents = indents[:len(indents)-1]
+			}
+			if len(indents) == 0 || indents[len(indents)-1] < width {
+				indents = append(indents, width)
+			}
+			depth = len(indents) - 1
+			marker := line[len(m[1]) : len(m[1])+1]
+			if style.Bullet != "" {
+				marker = style.Bullet
+			}
+			lines[i] = listIndent(depth, style, m[1]) + marker + " " + m[2]
+		case trimmed == "":
+		case width > 0 && depth >= 0:
+			// A continuation line is aligned with the text of its item
+			if style.Indent != 0 {
+				lines[i] = listIndent(depth, style, "") + "  " + trimmed
+			}
+		default:
+			indents, depth = nil, -1
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentWidth returns the indentation of line, counting a tab as 4 spaces
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// listIndent returns the indentation of a list item at depth; without a configured indent the
+// original indentation is kept
+func listIndent(depth int, style listStyleConfig, original string) string {
+	if style.Indent == 0 {
+		return original
+	}
+	return strings.Repeat(" ", depth*style.Indent)
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestApplyListStyle(t *testing.T) {
+	entry := "## [v1.0.0] - 2025-01-01\n\n### 追加\n\n- 機能A\n   * 詳細1\n     続きの行\n   + 詳細2\n- 機能B\n\n```\n- コード\n```\n\n### 修正\n\n* 修正A"
+	tests := []struct {
+		name  string
+		style listStyleConfig
+		want  string
+	}{
+		{"unchanged", listStyleConfig{}, entry},
+		{"bullet", listStyleConfig{Bullet: "*"}, "## [v1.0.0] - 2025-01-01\n\n### 追加\n\n* 機能A\n   * 詳細1\n     続きの行\n   * 詳細2\n* 機能B\n\n```\n- コード\n```\n\n### 修正\n\n* 修正A"},
+		{"indent", listStyleConfig{Bullet: "-", Indent: 4}, "## [v1.0.0] - 2025-01-01\n\n### 追加\n\n- 機能A\n    - 詳細1\n      続きの行\n    - 詳細2\n- 機能B\n\n```\n- コード\n```\n\n### 修正\n\n- 修正A"},
+		{"two spaces", listStyleConfig{Indent: 2}, "## [v1.0.0] - 2025-01-01\n\n### 追加\n\n- 機能A\n  * 詳細1\n    続きの行\n  + 詳細2\n- 機能B\n\n```\n- コード\n```\n\n### 修正\n\n* 修正A"},
+	}
+	for _, tt := range tests {
+		if got := applyListStyle(entry, tt.style); got != tt.want {
+			t.Errorf("%s: applyListStyle() =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+
+	deep := "- A\n\t- B\n\t\t- C\n\t- D\n- E"
+	if got, want := applyListStyle(deep, listStyleConfig{Indent: 4}), "- A\n    - B\n        - C\n    - D\n- E"; got != want {
+		t.Errorf("applyListStyle() with three levels = %q, want %q", got, want)
+	}
+}
+
+func TestValidListStyle(t *testing.T) {
+	for _, style := range []listStyleConfig{{}, {Bullet: "*", Indent: 2}, {Bullet: "+", Indent: 4}} {
+		if err := validListStyle(style); err != nil {
+			t.Errorf("validListStyle(%+v) = %v", style, err)
+		}
+	}
+	for _, style := range []listStyleConfig{{Bullet: "•"}, {Indent: 3}, {Indent: -2}} {
+		if err := validListStyle(style); err == nil {
+			t.Errorf("validListStyle(%+v) succeeded", style)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+)
+
+// lockSuffix is appended to the changelog path to name its lock file
+const lockSuffix = ".lock"
+
+// lockTimeout is how long to wait for another run to release the lock; set by --lock-timeout
+var lockTimeout = 30 * time.Second
+
+// lockRetryInterval is how often the lock file is retried while waiting
+var lockRetryInterval = 200 * time.Millisecond
+
+// fileLock is an advisory lock held by creating a lock file next to the locked file
+type fileLock struct {
+	path string
+}
+
+// acquireLock locks target against concurrent runs, waiting up to lockTimeout for another run to finish
+func acquireLock(target string) (*fileLock, error) {
+	path := target + lockSuffix
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			host, _ := os.Hostname()
+			_, writeErr := fmt.Fprintf(f, "pid %d on %s since %s\n", os.Getpid(), host, time.Now().Format(time.RFC3339))
+			if closeErr := f.Close(); writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock %s: %w", path, writeErr)
+			}
+			return &fileLock{path: path}, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock %s: %w", path, err)
+		}
+		if !time.Now().Before(deadline) {
+			owner, _ := os.ReadFile(path)
+			return nil, fmt.Errorf("another run is in progress (%s); remove %s if that run no longer exists",
+				strings.TrimSpace(string(owner)), path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// release removes the lock file
+func (l *fileLock) release() error {
+	return os.Remove(l.path)
+}
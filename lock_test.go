@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcquireLock(t *testing.T) {
+	savedTimeout, savedInterval := lockTimeout, lockRetryInterval
+	defer func() { lockTimeout, lockRetryInterval = savedTimeout, savedInterval }()
+	lockTimeout, lockRetryInterval = 50*time.Millisecond, 10*time.Millisecond
+
+	changelog := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	lock, err := acquireLock(changelog)
+	if err != nil {
+		t.Fatalf("acquireLock() error = %v", err)
+	}
+
+	_, err = acquireLock(changelog)
+	if err == nil || !strings.Contains(err.Error(), "another run is in progress") || !strings.Contains(err.Error(), "pid ") {
+		t.Errorf("second acquireLock() error = %v, want an in-progress error naming the owner", err)
+	}
+
+	if err := lock.release(); err != nil {
+		t.Fatalf("release() error = %v", err)
+	}
+	if _, err := os.Stat(changelog + lockSuffix); !os.IsNotExist(err) {
+		t.Errorf("lock file still exists after release: %v", err)
+	}
+
+	lock, err = acquireLock(changelog)
+	if err != nil {
+		t.Fatalf("acquireLock() after release error = %v", err)
+	}
+	lock.release()
+}
+
+func TestAcquireLockWaitsForRelease(t *testing.T) {
+	savedTimeout, savedInterval := lockTimeout, lockRetryInterval
+	defer func() { lockTimeout, lockRetryInterval = savedTimeout, savedInterval }()
+	lockTimeout, lockRetryInterval = 2*time.Second, 10*time.Millisecond
+
+	changelog := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	first, err := acquireLock(changelog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		first.release()
+	}()
+
+	second, err := acquireLock(changelog)
+	if err != nil {
+		t.Fatalf("acquireLock() did not wait for the lock: %v", err)
+	}
+	second.release()
+}
@@ -2,16 +2,16 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
-	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,29 +20,58 @@ type AIExecutor interface {
 	Execute(prompt string) (string, error)
 }
 
+// anthropicAPIKey is the environment variable the claude command reads its API key from
+const anthropicAPIKey = "ANTHROPIC_API_KEY"
+
 // ClaudeExecutor implements AIExecutor for the Claude model
-type ClaudeExecutor struct{}
+type ClaudeExecutor struct {
+	// Model is passed to the claude command as --model when set
+	Model string
+}
 
 // Execute runs the claude command with the given prompt
 func (e *ClaudeExecutor) Execute(prompt string) (string, error) {
-	cmd := exec.Command("claude", "-p", prompt)
+	args := []string{"-p", prompt}
+	if e.Model != "" {
+		args = append(args, "--model", e.Model)
+	}
+	cmd := exec.Command("claude", args...)
+	var env []string
+	if os.Getenv(anthropicAPIKey) == "" {
+		// Pass a key stored in the keychain or credential helper on to the claude command
+		if key := lookupCredential(anthropicAPIKey); key != "" {
+			env = append(env, anthropicAPIKey+"="+key)
+		}
+	}
+	if caCertFile != "" {
+		env = append(env, "NODE_EXTRA_CA_CERTS="+caCertFile)
+	}
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	output, err := cmd.Output()
 	if err != nil {
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
-			return "", fmt.Errorf("claude execution failed: %w: %s", err, string(exitErr.Stderr))
+			return "", &AIError{Err: fmt.Errorf("claude execution failed: %w: %s", err, string(exitErr.Stderr))}
 		}
-		return "", fmt.Errorf("failed to run claude command: %w", err)
+		return "", &AIError{Err: fmt.Errorf("failed to run claude command: %w", err)}
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
 var newExecutor = func(model string) (AIExecutor, error) {
-	switch model {
-	case "claude":
+	switch {
+	case model == "claude":
 		return &ClaudeExecutor{}, nil
+	case isMockModel(model):
+		executor, err := newCannedExecutor(model)
+		if err != nil {
+			return nil, err
+		}
+		return executor, nil
 	default:
-		return nil, fmt.Errorf("invalid model specified: %s", model)
+		return nil, &ConfigError{Err: fmt.Errorf("invalid model specified: %s", model)}
 	}
 }
 
@@ -54,315 +83,921 @@ const (
 	gitRefHEAD  = "HEAD"
 )
 
+// subcommands maps subcommand names to their entry points
+var subcommands = map[string]func(args []string) error{
+	"serve":         serveCommand,
+	"sync-releases": syncReleasesCommand,
+	"export":        exportCommand,
+	"watch":         watchCommand,
+	"diff":          diffCommand,
+	"aggregate":     aggregateCommand,
+	"completion":    completionCommand,
+	"doctor":        doctorCommand,
+	"notes":         notesCommand,
+	"pr":            prCommand,
+	"rollup":        rollupCommand,
+	"lint":          lintCommand,
+	"check":         checkCommand,
+	"commits-lint":  commitsLintCommand,
+	"since":         sinceCommand,
+	"digest":        digestCommand,
+	"config":        configCommand,
+}
+
 func main() {
-	model := flag.String("model", "claude", "AI model to use (currently only claude)")
+	ui.plain = !isTerminal(os.Stdout)
+	ui.color = colorEnabled(os.Stdout)
+	// Subcommands follow the locale; the main command can override it with --ui-lang
+	_ = ui.setUILang(detectUILang())
+
+	args := os.Args[1:]
+	if len(args) >= 2 && args[0] == "-C" {
+		// A leading -C applies to subcommands as well, like git -C
+		if err := setRepoDir(args[1]); err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		args = args[2:]
+	}
+
+	model := flag.String("model", "claude", "AI model to use: claude, or mock[:file.md] for a canned entry without AI access")
 	modelShort := flag.String("m", "", "AI model to use (shorthand for -model)")
 	newTag := flag.String("tag", "", "New version tag to create (e.g., v1.0.3)")
 	showHelp := flag.Bool("h", false, "Show help message")
 	showHelpLong := flag.Bool("help", false, "Show help message")
 	showVersion := flag.Bool("version", false, "Show version information")
-	changelogFile := flag.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	changelogFile := flag.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file, relative to the repository (default with --module: CHANGELOG.md in the module directory)")
+	module := flag.String("module", "", "Directory of the Go module to release in a multi-module repository; its tags are prefixed with it, e.g. api/v1.2.3 (default: taken from --tag)")
+	specName := flag.String("spec", specSimple, "Structural rules of the changelog: keepachangelog-1.1 (Unreleased section, link references and standard sections), simple (standard sections) or custom (\"spec\" in the configuration file)")
+	repoDir := flag.String("C", gitDir, "Run as if started in this repository directory")
 	skipPull := flag.Bool("skip-pull", false, "Skip git pull --tags")
+	flag.StringVar(&gitRemote, "remote", gitRemote, "Remote to fetch tags from and derive commit links from, e.g. upstream in a fork")
+	offline := flag.Bool("offline", false, "Never fetch from remotes: implies --skip-pull and leaves shallow clones as they are")
 	catchUp := flag.Bool("catch-up", false, "Add missing tags to CHANGELOG")
+	hookModeFlag := flag.Bool("hook-mode", false, "Run as a pre-commit hook: add the staged changes to the Unreleased section, or validate the changelog when it is staged")
+	initialReleaseFlag := flag.Bool("initial-release", false, "Write the entry as the first release describing the whole project; =false forces a regular entry (default: only when no earlier tag exists)")
+	commitTemplate := flag.String("commit-message-template", defaultCommitMessageTemplate, "Message of commits that update the changelog (--create-tag, --push-branch and the next steps); {version} is replaced by the tag")
 	autoYes := flag.Bool("yes", false, "Automatically accept all prompts")
+	autoNo := flag.Bool("no", false, "Automatically decline all prompts, e.g. to preview entries without writing anything")
+	flag.BoolVar(autoNo, "assume-no", false, "Automatically decline all prompts (same as --no)")
+	concurrency := flag.Int("concurrency", 4, "Number of tags to generate in parallel during catch-up")
+	verbose := flag.Bool("verbose", false, "Show per-tag timing and token usage")
+	noEmoji := flag.Bool("no-emoji", false, "Print output without emoji and decorative separators")
+	plain := flag.Bool("plain", false, "Alias for --no-emoji")
+	uiLang := flag.String("ui-lang", "", "Language of status messages and questions: en or ja (default: ja when LC_ALL, LC_MESSAGES or LANG starts with ja, otherwise en)")
+	noColor := flag.Bool("no-color", false, "Disable colored output (also honors NO_COLOR)")
+	configFile := flag.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	linkCommits := flag.Bool("link-commits", false, "Append commit and PR links to each generated bullet")
+	attributeAuthorsFlag := flag.Bool("attribute-authors", false, "Credit the authors of the commits behind each bullet, e.g. (by @alice)")
+	stats := flag.Bool("stats", false, "Append commit, file and contributor statistics to each entry")
+	noDependencySection := flag.Bool("no-dependency-section", false, "Do not add the detected dependency updates section")
+	noSecurityAdvisories := flag.Bool("no-security-advisories", false, "Do not list the CVE and GHSA advisories referenced by commits in the security section")
+	upgradeGuideFlag := flag.Bool("upgrade-guide", false, "Generate an UPGRADING.md section when the release contains breaking changes")
+	maxTokens := flag.Int("max-prompt-tokens", defaultMaxPromptTokens, "Trim prompts estimated above this many tokens (0 disables)")
+	audience := flag.String("audience", "", "Write entries for end-user, developer or ops readers")
+	tone := flag.String("tone", "", "Writing style of entries: formal or casual")
+	detail := flag.String("detail", detailNormal, "How much to write per item: terse, normal or detailed")
+	maxItems := flag.Int("max-items-per-section", 0, "Keep at most this many bullets in each section of generated entries (0 means no limit)")
+	bullet := flag.String("bullet", "", "List marker enforced in generated entries: -, * or + (default: list_style.bullet in the config, or as the AI writes it)")
+	indent := flag.Int("indent", 0, "Spaces per nesting level of generated lists: 2 or 4 (default: list_style.indent in the config, or as the AI writes it)")
+	subBullets := flag.Bool("sub-bullets", false, "Write the details of each item as nested bullets below a one-line summary")
+	instructions := flag.String("instructions", "", "Extra instructions for the AI in this run, e.g. \"don't mention internal refactors\"")
+	contextFile := flag.String("context-file", "", "File describing the project for the AI (default: the module name and the beginning of the README)")
+	noProjectContext := flag.Bool("no-project-context", false, "Do not describe the project to the AI")
+	noStyleExamples := flag.Bool("no-style-examples", false, "Do not show recent changelog entries to the AI as style examples")
+	mdlint := flag.Bool("mdlint", false, "Fix blank lines and trailing spaces so entries pass common markdownlint rules")
+	structured := flag.Bool("structured", false, "Have the AI return JSON items and render the entry with a Go template")
+	versionStyle := flag.String("version-style", "", "Write versions in headings as v-prefix (v1.2.0) or bare (1.2.0); default keeps the tag name")
+	groupBy := flag.String("group-by", "", "Group bullets by component within each section: scope or directory")
+	prLabels := flag.Bool("pr-labels", false, "Categorize changes from referenced PRs by their labels (requires gh)")
+	expandSquash := flag.Bool("expand-squash-merges", false, "Pass the description and commits of squash-merged PRs (\"Subject (#123)\") to the AI (requires gh)")
+	var ignoreCommits stringList
+	flag.Var(&ignoreCommits, "ignore-commits", "Regex for commit subjects to leave out of the AI input (repeatable)")
+	noDefaultIgnores := flag.Bool("no-default-ignore-commits", false, "Keep merge and version-bump commits that are ignored by default")
+	botCommits := flag.String("bot-commits", botCommitsCollapse, "Handle dependabot/renovate commits: collapse, exclude or keep")
+	mapReduce := flag.Bool("map-reduce", false, "Summarize the most changed files individually with --map-model before composing the entry")
+	mapModel := flag.String("map-model", defaultMapModel, "Claude model used for per-file summaries with --map-reduce")
+	mapMaxFiles := flag.Int("map-max-files", defaultMapMaxFiles, "Maximum number of files summarized individually with --map-reduce")
+	noDuplicateCheck := flag.Bool("no-duplicate-check", false, "Do not compare generated items with recent entries to catch already released changes")
+	verify := flag.Bool("verify", false, "Review the generated entry against the diff with a second AI pass before accepting")
+	entryFile := flag.String("entry-file", "", "Insert or replace this pre-written entry in the changelog without generating one")
+	printPrompt := flag.Bool("print-prompt", false, "Print the generation prompt to stdout and exit without calling the AI (status messages go to stderr)")
+	showPrompt := flag.Bool("show-prompt", false, "Show each prompt and ask for approval before sending it to the AI")
+	raw := flag.Bool("raw", false, "Preview generated entries as raw Markdown")
+	toStdout := flag.Bool("stdout", false, "Print the generated entry to stdout instead of updating the changelog (status messages go to stderr)")
+	quiet := flag.Bool("quiet", false, "Print only warnings, errors and questions, without entry previews; with --stdout, stdout holds nothing but the entry")
+	notifyURL := flag.String("notify-webhook", os.Getenv("CHANGELOG_NOTIFY_WEBHOOK"), "Post the new entry to this Slack or Discord webhook after a successful update")
+	jiraRelease := flag.Bool("jira-release", false, "Create or update the Jira version configured under \"jira\" and link the issues mentioned in commits")
+	includeStaged := flag.Bool("include-staged", true, "Include staged changes in the entry (use --include-staged=false to ignore them)")
+	includeWorkingTree := flag.Bool("include-working-tree", false, "Include unstaged changes to tracked files in the entry")
+	verifySignaturesFlag := flag.String("verify-signatures", "", "Check GPG/SSH signatures of the tags and commits in range: warn, or require to stop on problems")
+	allowDirty := flag.Bool("allow-dirty", false, "Generate the --tag entry even if uncommitted changes to tracked files would not be included")
+	includeUntracked := flag.Bool("include-untracked", false, "Include untracked files that are not ignored in the entry")
+	createTag := flag.Bool("create-tag", false, "After updating, commit the changelog and create the --tag tag on that commit")
+	annotateTag := flag.Bool("annotate-tag", false, "Use the generated entry as the annotated tag message (updates an existing tag in place)")
+	promptVersion := flag.Int("prompt-version", 0, "Version of the built-in prompts, to keep the style of a project's entries across upgrades (default: the version recorded by --metadata in the changelog, otherwise the current one)")
+	metadata := flag.Bool("metadata", false, "Add an HTML comment under each generated entry recording the tool version, model, prompt hash and version, and commit range")
+	caCert := flag.String("ca-cert", "", "PEM file with extra CA certificates to trust, e.g. for a TLS-intercepting proxy")
+	flag.DurationVar(&lockTimeout, "lock-timeout", lockTimeout, "How long to wait for another run to finish updating the changelog")
+	releaseDateFlag := flag.String("date", "", "Date of the new entry as YYYY-MM-DD (default: today)")
+	timezone := flag.String("timezone", "", "Time zone for entry dates, e.g. Asia/Tokyo (default: the local time zone for new entries, the tagger's for existing tags)")
+	force := flag.Bool("force", false, "Regenerate the --tag entry even if the changelog already has it for the tag's commit")
+	stdinMode := flag.Bool("stdin", false, "Read the commits and diff from stdin (JSON or --- commits --- / --- diff --- sections) instead of running git")
+	recordDir := flag.String("record", "", "Save each AI response in this directory, keyed by the prompt hash")
+	replayDir := flag.String("replay", "", "Answer prompts with the responses saved by --record in this directory instead of calling the AI")
+	failOnEmpty := flag.Bool("fail-on-empty", false, "Exit with status 5 instead of 0 when there is nothing to add")
+	remoteRepo := flag.String("repo", "", "Clone this repository URL into a temporary directory and run there, printing the new entry")
+	pushBranch := flag.String("push-branch", "", "With --repo, commit the updated changelog and push it to this branch instead of printing the entry")
+	summaryJSON := flag.String("summary-json", "", "Write a machine-readable run summary to this file")
+	splitDir := flag.String("split-dir", "", "Also write each version entry to <dir>/<version>.md with version and date front matter, e.g. for Hugo or Docusaurus")
+	flag.BoolVar(&splitOnly, "split-only", false, "With --split-dir, write only the per-version files and leave the changelog file untouched")
+
+	// Subcommands are dispatched after the flags are defined so completion can list them
+	if len(args) > 0 {
+		if run, ok := subcommands[args[0]]; ok {
+			completionSubcommands = subcommandNames(subcommands)
+			if err := run(args[1:]); err != nil {
+				ui.Printf("❌ Error: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			return
+		}
+	}
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "changelog-update: AI-powered CHANGELOG.md generator.\n\n")
 		fmt.Fprintf(os.Stderr, "Usage:\n")
 		fmt.Fprintf(os.Stderr, "  changelog-update --tag v1.0.3 [flags]\n")
 		fmt.Fprintf(os.Stderr, "  changelog-update --catch-up [flags]\n")
-		fmt.Fprintf(os.Stderr, "  changelog-update --catch-up --tag v1.0.3 [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update --catch-up --tag v1.0.3 [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update --hook-mode [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update --repo https://github.com/org/project.git --tag v1.0.3 [--push-branch name] [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update serve [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update sync-releases [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update export --format html|atom [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update watch --interval 5m [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update diff <from-version> [<to-version>]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update since [--to v1.4.0] [flags] <version>\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update rollup --from v1.0.0 [--to v1.4.0] [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update notes --from origin/main --to origin/release-2.0 [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update digest --since 2025-09-01 [--until 2025-09-07] [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update pr <number> [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update aggregate [<name>=]<repository>...\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update doctor [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update config validate|show [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update lint [--format text|sarif|github|gitlab] [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update check [--tag v1.2.0] [flags]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update commits-lint [--suggest] [<range>]\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update completion bash|zsh|fish|powershell\n")
+		fmt.Fprintf(os.Stderr, "  changelog-update -C /path/to/repo <subcommand or flags>\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
 		flag.PrintDefaults()
 	}
 
-	flag.Parse()
-
-	if *modelShort != "" {
-		*model = *modelShort
-	}
+	envErr := applyEnvFlags(flag.CommandLine)
+	_ = flag.CommandLine.Parse(args)
 
+	// Help and the version work whatever the environment and the configuration file contain
 	if *showHelp || *showHelpLong {
 		flag.Usage()
 		os.Exit(0)
 	}
-
 	if *showVersion {
-		fmt.Printf("changelog-update version %s\n", version)
+		ui.Printf("changelog-update version %s\n", version)
 		os.Exit(0)
 	}
+	if envErr != nil {
+		ui.Printf("❌ Error: %v\n", envErr)
+		os.Exit(ExitConfig)
+	}
+	if *uiLang != "" {
+		if err := ui.setUILang(*uiLang); err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitConfig)
+		}
+	}
 
-	if !*catchUp && *newTag == "" {
-		fmt.Println("❌ Error: --tag flag is required (or use --catch-up, or both)")
-		flag.Usage()
-		os.Exit(1)
+	if *remoteRepo != "" {
+		switch {
+		case gitDir != "" || *repoDir != "":
+			ui.Println("❌ Error: --repo cannot be used with -C")
+			os.Exit(ExitConfig)
+		case *stdinMode || *createTag || *annotateTag:
+			ui.Println("❌ Error: --repo cannot be used with --stdin, --create-tag or --annotate-tag")
+			os.Exit(ExitConfig)
+		}
+		os.Exit(runRemoteRepo(*remoteRepo, *pushBranch, *summaryJSON))
+	}
+	if *pushBranch != "" {
+		ui.Println("❌ Error: --push-branch requires --repo")
+		os.Exit(ExitConfig)
 	}
 
-	fmt.Printf("🚀 Starting CHANGELOG update process using %s...\n", *model)
+	if err := setRepoDir(*repoDir); err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
 
-	// Pull latest tags from remote
-	if !*skipPull {
-		fmt.Println("📥 Fetching latest tags from remote...")
-		if err := pullTags(); err != nil {
-			fmt.Printf("⚠️  Warning: Failed to pull tags: %v\n", err)
-		}
+	if *modelShort != "" {
+		*model = *modelShort
 	}
 
-	executor, err := newExecutor(*model)
+	moduleTagName, err := setGoModule(*module, *newTag)
 	if err != nil {
-		fmt.Printf("❌ Error: %v\n", err)
-		os.Exit(1)
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	*newTag = moduleTagName
+	changelogSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "changelog" {
+			changelogSet = true
+		}
+	})
+	if goModule != "" && !changelogSet {
+		*changelogFile = path.Join(goModule, *changelogFile)
 	}
 
-	// Handle catch-up mode
-	if *catchUp {
-		if catchUpErr := catchUpMode(executor, *changelogFile); catchUpErr != nil {
-			fmt.Printf("❌ Error during catch-up: %v\n", catchUpErr)
-			os.Exit(1)
+	// The path as given, relative to the repository, is what git commands in the hints expect
+	changelogName := filepath.Clean(filepath.FromSlash(*changelogFile))
+	*changelogFile = repoPath(changelogName)
+
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	aiFilter = newPathFilter(cfg.AIAllow, cfg.AIDeny)
+	outputTargets = cfg.Outputs
+	if frontMatter, err = newFrontMatterFormat(cfg.FrontMatter); err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	switch {
+	case *splitDir != "":
+		outputTargets = append(outputTargets, splitTarget(*splitDir))
+		if splitOnly {
+			// Messages and next steps name the files that are actually written
+			changelogName = filepath.Clean(filepath.FromSlash(*splitDir))
 		}
-		// If --tag is also specified, continue to process the new tag
-		if *newTag == "" {
-			os.Exit(0)
+	case splitOnly:
+		ui.Println("❌ Error: --split-only requires --split-dir")
+		os.Exit(ExitConfig)
+	}
+	credentialHelper = cfg.CredentialHelper
+	if *caCert != "" {
+		if _, err := loadCertPool(*caCert); err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitConfig)
+		}
+		caCertFile, _ = filepath.Abs(*caCert)
+	}
+	changelogHeading, err = newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat)
+	if err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	if err := setSpec(*specName, cfg.Spec); err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	if !validVersionStyle(*versionStyle) {
+		ui.Printf("❌ Error: --version-style must be %q or %q\n", versionStyleVPrefix, versionStyleBare)
+		os.Exit(ExitConfig)
+	}
+	changelogHeading.versionStyle = *versionStyle
+	commitFilter, err = newCommitIgnoreFilter(ignoreCommits, !*noDefaultIgnores)
+	if err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	if err := setReleaseDate(*releaseDateFlag, *timezone); err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	maxPromptTokens = *maxTokens
+	genOpts.LinkCommits = *linkCommits
+	genOpts.AttributeAuthors = *attributeAuthorsFlag
+	genOpts.Stats = *stats
+	genOpts.DependencySection = !*noDependencySection
+	genOpts.SecurityAdvisories = !*noSecurityAdvisories
+	if !validGroupBy(*groupBy) {
+		ui.Printf("❌ Error: --group-by must be %q or %q\n", groupByScope, groupByDirectory)
+		os.Exit(ExitConfig)
+	}
+	genOpts.GroupBy = *groupBy
+	if !validAudience(*audience) {
+		ui.Printf("❌ Error: --audience must be %q, %q or %q\n", audienceEndUser, audienceDeveloper, audienceOps)
+		os.Exit(ExitConfig)
+	}
+	if !validTone(*tone) {
+		ui.Printf("❌ Error: --tone must be %q or %q\n", toneFormal, toneCasual)
+		os.Exit(ExitConfig)
+	}
+	genOpts.Audience, genOpts.Tone = *audience, *tone
+	if !validDetail(*detail) {
+		ui.Printf("❌ Error: --detail must be %q, %q or %q\n", detailTerse, detailNormal, detailDetailed)
+		os.Exit(ExitConfig)
+	}
+	if *maxItems < 0 {
+		ui.Println("❌ Error: --max-items-per-section must not be negative")
+		os.Exit(ExitConfig)
+	}
+	genOpts.Detail, genOpts.MaxItemsPerSection = *detail, *maxItems
+	genOpts.ListStyle = cfg.ListStyle
+	if *bullet != "" {
+		genOpts.ListStyle.Bullet = *bullet
+	}
+	if *indent != 0 {
+		genOpts.ListStyle.Indent = *indent
+	}
+	genOpts.ListStyle.SubBullets = genOpts.ListStyle.SubBullets || *subBullets
+	if err := validListStyle(genOpts.ListStyle); err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	genOpts.Instructions = *instructions
+	if !validBotCommits(*botCommits) {
+		ui.Printf("❌ Error: --bot-commits must be %q, %q or %q\n", botCommitsCollapse, botCommitsExclude, botCommitsKeep)
+		os.Exit(ExitConfig)
+	}
+	genOpts.BotCommits = *botCommits
+	if err := setCommitMessageTemplate(*commitTemplate); err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	switch {
+	case *autoYes && *autoNo:
+		ui.Println("❌ Error: --yes cannot be used with --no")
+		os.Exit(ExitConfig)
+	case *autoYes:
+		assumeAnswer = answerYes
+	case *autoNo:
+		assumeAnswer = answerNo
+	}
+	if !validSignaturePolicy(*verifySignaturesFlag) {
+		ui.Printf("❌ Error: --verify-signatures must be %q or %q\n", signaturesWarn, signaturesRequire)
+		os.Exit(ExitConfig)
+	}
+	signaturePolicy = *verifySignaturesFlag
+	genOpts.PRLabels = *prLabels
+	genOpts.ExpandSquashMerges = *expandSquash
+	genOpts.Structured = *structured || cfg.EntryTemplate != ""
+	genOpts.MarkdownLint = *mdlint
+	genOpts.Metadata = *metadata
+	existing, _ := readTextFile(*changelogFile)
+	var recorded bool
+	if genOpts.PromptVersion, recorded, err = resolvePromptVersion(*promptVersion, existing); err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitConfig)
+	}
+	if recorded && genOpts.PromptVersion != currentPromptVersion {
+		ui.Printf("ℹ️  Using prompt version %d recorded in %s; pass --prompt-version %d to switch to the current prompts.\n", genOpts.PromptVersion, changelogName, currentPromptVersion)
+	}
+	genOpts.Model = *model
+	genOpts.IncludeStaged = *includeStaged
+	genOpts.IncludeWorkingTree = *includeWorkingTree
+	genOpts.IncludeUntracked = *includeUntracked
+	if cfg.EntryTemplate != "" {
+		entryTemplate, err = loadEntryTemplate(filepath.Join(gitDir, filepath.FromSlash(cfg.EntryTemplate)))
+		if err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitConfig)
+		}
+	}
+	genOpts.LabelSections = labelSections(cfg.LabelSections)
+	if *recordDir != "" && *replayDir != "" {
+		ui.Println("❌ Error: --record and --replay cannot be used together")
+		os.Exit(ExitConfig)
+	}
+	fixtureDates = *recordDir != "" || *replayDir != ""
+	if *jiraRelease && cfg.Jira == nil {
+		ui.Printf("❌ Error: --jira-release requires a \"jira\" section in the configuration file\n")
+		os.Exit(ExitConfig)
+	}
+	if genOpts.LinkCommits || activeSpec.RequireLinkReferences {
+		genOpts.RepoURL = remoteWebURL(gitRemote)
+	}
+	if !*noStyleExamples {
+		loadStyleExamples(*changelogFile)
+	}
+	if !*noProjectContext {
+		if err := loadProjectContext(*contextFile); err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitConfig)
 		}
-		fmt.Println() // Add a blank line between catch-up and new tag processing
 	}
 
-	// Normal mode - generate entry for new tag
-	// Get the latest tag
-	previousTag := getLatestTag()
+	if *printPrompt || *toStdout {
+		// Keep stdout for the prompt or the entry alone so it can be piped
+		ui.out = os.Stderr
+		ui.plain = !isTerminal(os.Stderr)
+		ui.color = colorEnabled(os.Stderr)
+	}
+	if *noEmoji || *plain {
+		ui.plain = true
+	}
+	if *noColor {
+		ui.color = false
+	}
+	ui.raw = *raw
+	ui.quiet = *quiet
 
-	// Check if new tag already exists
-	if previousTag == *newTag {
-		fmt.Printf("⚠️  Tag %s already exists. Generating CHANGELOG from previous tag.\n", *newTag)
-		// Find the tag before the current one
-		var allTags []string
-		allTags, err = getAllTags()
+	if *hookModeFlag && (*catchUp || *newTag != "" || *entryFile != "" || *stdinMode || *printPrompt) {
+		ui.Println("❌ Error: --hook-mode cannot be used with --tag, --catch-up, --entry-file, --stdin or --print-prompt")
+		os.Exit(ExitConfig)
+	}
+	if !*catchUp && *newTag == "" && *entryFile == "" && !*hookModeFlag {
+		ui.Println("❌ Error: --tag flag is required (or use --catch-up, or both)")
+		flag.Usage()
+		os.Exit(ExitConfig)
+	}
+
+	if *entryFile != "" && (*catchUp || *stdinMode || *printPrompt) {
+		ui.Println("❌ Error: --entry-file cannot be used with --catch-up, --stdin or --print-prompt")
+		os.Exit(ExitConfig)
+	}
+	if *toStdout && (*catchUp || *hookModeFlag || *entryFile != "" || *printPrompt || *upgradeGuideFlag || *createTag || *annotateTag) {
+		ui.Println("❌ Error: --stdout cannot be used with --catch-up, --hook-mode, --entry-file, --print-prompt, --upgrade-guide, --create-tag or --annotate-tag")
+		os.Exit(ExitConfig)
+	}
+	if *printPrompt && (*catchUp || *mapReduce || *showPrompt) {
+		ui.Println("❌ Error: --print-prompt cannot be used with --catch-up, --map-reduce or --show-prompt")
+		os.Exit(ExitConfig)
+	}
+
+	var input *pipeInput
+	if *stdinMode {
+		if *catchUp || *upgradeGuideFlag || *mapReduce || signaturePolicy != "" {
+			ui.Println("❌ Error: --stdin cannot be used with --catch-up, --upgrade-guide, --map-reduce or --verify-signatures, which read the repository")
+			os.Exit(ExitConfig)
+		}
+		if assumeAnswer == "" && !*printPrompt {
+			ui.Println("❌ Error: --stdin requires --yes or --no, because stdin is not available for answering prompts")
+			os.Exit(ExitConfig)
+		}
+		input, err = parsePipeInput(os.Stdin)
 		if err != nil {
-			fmt.Printf("❌ Error: Failed to get all tags: %v\n", err)
-			os.Exit(1)
+			ui.Printf("❌ Error: %v\n", err)
+			os.Exit(exitCode(err))
 		}
+		disableGitOptions()
+	}
 
-		// Find the tag before newTag
-		for i, tag := range allTags {
-			if tag == *newTag && i > 0 {
-				previousTag = allTags[i-1]
-				fmt.Printf("📌 Using previous tag: %s\n", previousTag)
-				break
-			} else if tag == *newTag && i == 0 {
-				// This is the first tag, treat as initial release
-				previousTag = ""
-				fmt.Println("📌 This is the first tag, treating as initial release.")
-				break
-			}
+	ui.Printf("🚀 Starting CHANGELOG update process using %s...\n", *model)
+
+	if input == nil && *entryFile == "" && !*hookModeFlag {
+		prepareCheckout(*offline)
+	}
+
+	// Pull latest tags from remote
+	if !*skipPull && !*offline && input == nil && *entryFile == "" && !*hookModeFlag {
+		ui.Println("📥 Fetching latest tags from remote...")
+		if err := pullTags(); err != nil {
+			ui.Printf("⚠️  Warning: Failed to pull tags: %v\n", err)
 		}
-	} else if previousTag == "" {
-		fmt.Println("📌 No previous tags found. This will be the first release.")
-	} else {
-		fmt.Printf("📌 Previous tag: %s\n", previousTag)
 	}
 
-	var diff, commits, stagedDiff string
+	emptyExitCode := ExitOK
+	if *failOnEmpty {
+		emptyExitCode = ExitEmpty
+	}
 
-	if previousTag == "" {
-		// First release - get all files and commits
-		fmt.Println("📊 Analyzing initial release...")
-		diff, err = getGitDiff("", gitRefHEAD)
-		if err != nil {
-			// Check if this is because there are no commits yet
-			if strings.Contains(err.Error(), "exit status 128") {
-				fmt.Println("📝 No commits found. Will generate CHANGELOG based on staged changes...")
-				diff = ""
-			} else {
-				fmt.Printf("❌ Error: Failed to get git diff: %v\n", err)
-				os.Exit(1)
+	summary := &runSummary{NewTag: *newTag, Model: *model}
+	var executor, mapExecutor *meteredExecutor
+	exit := func(code int) {
+		if *summaryJSON != "" {
+			summary.ExitCode = code
+			summary.Signatures = signatureSummary
+			if executor != nil {
+				summary.PromptTokens, summary.ResponseTokens = executor.totals()
+			}
+			if mapExecutor != nil {
+				prompt, response := mapExecutor.totals()
+				summary.PromptTokens += prompt
+				summary.ResponseTokens += response
+			}
+			if err := writeSummary(*summaryJSON, summary); err != nil {
+				ui.Printf("⚠️  Warning: Failed to write summary: %v\n", err)
 			}
 		}
+		os.Exit(code)
+	}
 
-		commits, err = getGitCommits("", gitRefHEAD)
+	if *newTag != "" && input == nil && *entryFile == "" {
+		if err := checkReleaseBranch(*changelogFile, *allowDirty); err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			exit(exitCode(err))
+		}
+	}
+
+	if *entryFile != "" {
+		entry, entryVersion, err := readEntryFile(*entryFile, *newTag)
 		if err != nil {
-			// Check if this is because there are no commits yet
-			if strings.Contains(err.Error(), "exit status 128") {
-				fmt.Println("📝 No commits found. Will generate CHANGELOG based on staged changes...")
-				commits = ""
-			} else {
-				fmt.Printf("❌ Error: Failed to get commit messages: %v\n", err)
-				os.Exit(1)
-			}
+			ui.Printf("❌ Error: %v\n", err)
+			exit(exitCode(err))
 		}
-	} else {
-		// Get the diff between tags
-		diff, err = getGitDiff(previousTag, "HEAD")
+		summary.NewTag, summary.Entry = entryVersion, entry
+		written, err := writeChangelogOutputs(*changelogFile, entry)
 		if err != nil {
-			fmt.Printf("❌ Error: Failed to get git diff: %v\n", err)
-			os.Exit(1)
+			ui.Printf("\n❌ Update failed: %v\n", err)
+			exit(ExitFailure)
 		}
+		summary.ChangelogModified = true
+		ui.Printf("✅ %s updated with the entry for %s from %s\n", changelogName, entryVersion, *entryFile)
+		reportExtraOutputs(*changelogFile, written)
+		exit(ExitOK)
+	}
 
-		// Get commit messages between tags
-		commits, err = getGitCommits(previousTag, "HEAD")
+	baseExecutor, err := newExecutor(*model)
+	if err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		exit(exitCode(err))
+	}
+	baseExecutor = fixtureExecutor(baseExecutor, *recordDir, *replayDir)
+	if *printPrompt {
+		baseExecutor = &promptPrinter{out: os.Stdout}
+	}
+	if *showPrompt {
+		baseExecutor = &reviewingExecutor{AIExecutor: baseExecutor}
+		*concurrency = 1
+	}
+	executor = &meteredExecutor{AIExecutor: baseExecutor}
+
+	if *mapReduce {
+		mapBase, err := newMapExecutor(*model, *mapModel)
 		if err != nil {
-			fmt.Printf("❌ Error: Failed to get commit messages: %v\n", err)
-			os.Exit(1)
+			ui.Printf("❌ Error: %v\n", err)
+			exit(exitCode(err))
+		}
+		mapBase = fixtureExecutor(mapBase, *recordDir, *replayDir)
+		if *showPrompt {
+			mapBase = &reviewingExecutor{AIExecutor: mapBase}
 		}
+		mapExecutor = &meteredExecutor{AIExecutor: mapBase}
+		fileSummarizer = &mapReducer{executor: mapExecutor, maxFiles: *mapMaxFiles, concurrency: *concurrency}
 	}
 
-	// Get staged changes
-	stagedDiff, err = getStagedDiff()
-	if err != nil {
-		fmt.Printf("⚠️  Warning: Failed to get staged diff: %v\n", err)
-		stagedDiff = ""
-	} else if stagedDiff != "" {
-		fmt.Println("📝 Including staged changes in CHANGELOG...")
+	if *hookModeFlag {
+		if err := hookMode(executor, *changelogFile); err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			exit(exitCode(err))
+		}
+		exit(ExitOK)
+	}
+
+	// Handle catch-up mode
+	if *catchUp {
+		added, catchUpErr := catchUpMode(executor, *changelogFile, *concurrency, *verbose)
+		summary.CatchUpTags = added
+		summary.ChangelogModified = len(added) > 0
+		if catchUpErr != nil && !errors.Is(catchUpErr, errNothingToDo) {
+			ui.Printf("❌ Error during catch-up: %v\n", catchUpErr)
+			exit(exitCode(catchUpErr))
+		}
+		// If --tag is also specified, continue to process the new tag
+		if *newTag == "" {
+			if catchUpErr != nil {
+				exit(emptyExitCode)
+			}
+			exit(ExitOK)
+		}
+		ui.Println() // Add a blank line between catch-up and new tag processing
+	}
+
+	if !*force && entryUpToDate(*changelogFile, *newTag) {
+		ui.Printf("✅ %s already has an entry for %s at the tagged commit; already up to date (use --force to regenerate).\n", filepath.Base(*changelogFile), *newTag)
+		exit(emptyExitCode)
+	}
+
+	// Normal mode - generate entry for new tag
+	var previousTag, diff, commits, stagedDiff string
+	if input != nil {
+		previousTag, diff, commits = input.PreviousTag, input.Diff, input.Commits
+		ui.Println("📥 Using the commits and diff read from stdin...")
+	} else {
+		previousTag, diff, commits, stagedDiff, err = collectGitChanges(*newTag)
+		if err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			exit(exitCode(err))
+		}
+	}
+	summary.PreviousTag = previousTag
+
+	summary.CommitCount = countLines(commits)
+	summary.FilesChanged = countLines(diff) + countLines(stagedDiff)
+	if input == nil {
+		ranges := []signatureRange{{From: previousTag, To: gitRefHEAD, Tag: *newTag}}
+		if previousTag != "" {
+			ranges = append(ranges, signatureRange{Tag: previousTag})
+		}
+		if err := verifySignatures(ranges); err != nil {
+			ui.Printf("❌ Error: %v\n", err)
+			exit(ExitFailure)
+		}
 	}
 
 	if diff == "" && commits == "" && stagedDiff == "" {
-		fmt.Println("✅ No changes since last tag and no staged changes. Nothing to do.")
-		os.Exit(0)
+		ui.Println("✅ No changes since last tag and no uncommitted changes. Nothing to do.")
+		exit(emptyExitCode)
 	}
 
+	var initialOverride *bool
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "initial-release" {
+			initialOverride = initialReleaseFlag
+		}
+	})
+	initialRelease := initialReleaseMode(initialOverride, previousTag)
+
 	// Generate CHANGELOG entry
-	changelogEntry, err := generateChangelogEntry(executor, *newTag, diff, commits, stagedDiff)
+	rangeLabel := "initial release"
+	if previousTag != "" {
+		rangeLabel = previousTag + "..HEAD"
+	}
+	spin := startSpinner(ui.Sprintf("Generating entry for %s (%s)", *newTag, rangeLabel), !ui.plain)
+	summaries := changeSummaries(previousTag, gitRefHEAD)
+	recorder := &promptRecorder{AIExecutor: executor}
+	changelogEntry, err := generateChangelogEntry(recorder, previousTag, *newTag, diff, commits, stagedDiff, summaries, initialRelease)
+	spin.Stop()
+	summary.Entry = changelogEntry
+	if errors.Is(err, errPromptPrinted) {
+		exit(ExitOK)
+	}
+	if errors.Is(err, errPromptDeclined) {
+		ui.Println("\n⏹️ Canceled: the prompt was not approved.")
+		exit(ExitOK)
+	}
 	if err != nil {
-		fmt.Printf("❌ Error: Failed to generate changelog entry: %v\n", err)
-		os.Exit(1)
+		ui.Printf("❌ Error: Failed to generate changelog entry: %v\n", err)
+		exit(ExitAI)
 	}
 
 	if changelogEntry == "" {
-		fmt.Println("❌ Error: Generated changelog entry is empty")
-		os.Exit(1)
+		ui.Println("❌ Error: Generated changelog entry is empty")
+		exit(ExitAI)
 	}
+	changelogEntry = finalizeEntry(changelogEntry, previousTag, gitRefHEAD)
 
-	fmt.Println("\n📝 Generated CHANGELOG Entry:")
-	fmt.Println("===================================")
-	fmt.Println(changelogEntry)
-	fmt.Println("===================================")
+	var upgradeGuide string
+	if *upgradeGuideFlag {
+		breaking, breakingErr := hasBreakingChanges(previousTag, gitRefHEAD)
+		if breakingErr != nil {
+			ui.Printf("⚠️  Warning: %v\n", breakingErr)
+		}
+		if breaking {
+			spin := startSpinner(ui.Sprintf("Generating upgrade guide for %s", *newTag), !ui.plain)
+			upgradeGuide, err = generateUpgradeGuide(executor, *newTag, diff, commits)
+			spin.Stop()
+			if err != nil {
+				ui.Printf("⚠️  Warning: Failed to generate upgrade guide: %v\n", err)
+				upgradeGuide = ""
+			} else {
+				changelogEntry = addUpgradeGuideLink(changelogEntry)
+			}
+		} else {
+			ui.Println("ℹ️  No breaking changes detected, skipping upgrade guide.")
+		}
+	}
+	summary.Entry = changelogEntry
+
+	ui.Println("\n📝 Generated CHANGELOG Entry:")
+	ui.Separator()
+	ui.Entry(changelogEntry)
+	ui.Separator()
+	printQualityWarnings(entryQualityWarnings(changelogEntry, *newTag, releaseDate(), strings.TrimSpace(diff+"\n"+stagedDiff), commits))
+
+	if upgradeGuide != "" {
+		ui.Printf("\n📘 Generated %s Section:\n", upgradeGuideFile)
+		ui.Separator()
+		ui.Entry(upgradeGuide)
+		ui.Separator()
+	}
+
+	if *verify {
+		spin := startSpinner(ui.Sprintf("Verifying entry for %s", *newTag), !ui.plain)
+		critique, verifyErr := verifyEntry(executor, changelogEntry, diff, commits, stagedDiff)
+		spin.Stop()
+		switch {
+		case verifyErr != nil:
+			ui.Printf("⚠️  Warning: Verification failed: %v\n", verifyErr)
+		case verificationFailed(critique):
+			ui.Println("\n⚠️  Verification found possible problems:")
+			ui.Separator()
+			ui.Println(critique)
+			ui.Separator()
+		default:
+			ui.Println("\n✅ Verification found no problems.")
+		}
+	}
 
-	var shouldUpdate bool
-	if *autoYes {
-		fmt.Println("\n✔️ Auto-accepting update (--yes flag)")
-		shouldUpdate = true
-	} else {
-		fmt.Print("\nDo you want to update CHANGELOG.md with this entry? [y/N]: ")
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
+	if !*noDuplicateCheck {
+		changelogEntry, err = reviewDuplicates(changelogEntry, *changelogFile, *newTag)
 		if err != nil {
-			fmt.Printf("❌ Error: Failed to read input: %v\n", err)
-			os.Exit(1)
+			ui.Printf("❌ Error: %v\n", err)
+			exit(ExitFailure)
 		}
-		response = strings.TrimSpace(strings.ToLower(response))
-		shouldUpdate = (response == responseY || response == responseYes)
+		summary.Entry = changelogEntry
+	}
+
+	writtenEntry := changelogEntry
+	if genOpts.Metadata {
+		writtenEntry = addGenerationMetadata(changelogEntry, recorder.metadata(previousTag, gitRefHEAD))
+	}
+	if *toStdout {
+		fmt.Println(writtenEntry)
+		exit(ExitOK)
+	}
+
+	shouldUpdate, err := confirm(ui.Sprintf("\nDo you want to update %s with this entry? [y/N]: ", changelogName))
+	if err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		exit(ExitFailure)
 	}
 
 	if shouldUpdate {
-		if err := updateChangelog(*changelogFile, changelogEntry); err != nil {
-			fmt.Printf("\n❌ Update failed: %v\n", err)
-			os.Exit(1)
+		written, err := writeChangelogOutputs(*changelogFile, writtenEntry)
+		if err != nil {
+			ui.Printf("\n❌ Update failed: %v\n", err)
+			exit(ExitFailure)
+		}
+		summary.ChangelogModified = true
+		ui.Printf("\n✅ %s updated successfully!\n", changelogName)
+		reportExtraOutputs(*changelogFile, written)
+
+		if upgradeGuide != "" {
+			guidePath := upgradeGuidePath(*changelogFile)
+			if err := writeUpgradeGuide(guidePath, upgradeGuide); err != nil {
+				ui.Printf("⚠️  Warning: Failed to update %s: %v\n", guidePath, err)
+			} else {
+				ui.Printf("✅ %s updated successfully!\n", guidePath)
+				written = append(written, guidePath)
+			}
+		}
+
+		if *notifyURL != "" {
+			if err := notifyWebhook(*notifyURL, changelogEntry); err != nil {
+				ui.Printf("⚠️  Warning: Failed to send notification: %v\n", err)
+			} else {
+				ui.Println("📣 Notification sent")
+			}
+		}
+
+		if *jiraRelease {
+			if err := publishJiraRelease(cfg.Jira, *newTag, changelogEntry, commits); err != nil {
+				ui.Printf("⚠️  Warning: Failed to publish Jira release: %v\n", err)
+			}
 		}
-		fmt.Printf("\n✅ CHANGELOG.md updated successfully!\n")
 
 		// Update package.json version if it exists
 		if err := updatePackageJSONVersion(*newTag); err != nil {
-			fmt.Printf("⚠️  Warning: Failed to update package.json: %v\n", err)
+			ui.Printf("⚠️  Warning: Failed to update package.json: %v\n", err)
 		}
 
-		fmt.Printf("📌 Next steps:\n")
-		fmt.Printf("  1. Review and edit CHANGELOG.md if needed\n")
-		fmt.Printf("  2. git add CHANGELOG.md\n")
-		if _, err := os.Stat("package.json"); err == nil {
-			fmt.Printf("  3. git add package.json\n")
-			fmt.Printf("  4. git commit -m \"docs: update changelog for %s\"\n", *newTag)
-			fmt.Printf("  5. git tag %s\n", *newTag)
-			fmt.Printf("  6. git push && git push --tags\n")
-		} else {
-			fmt.Printf("  3. git commit -m \"docs: update changelog for %s\"\n", *newTag)
-			fmt.Printf("  4. git tag %s\n", *newTag)
-			fmt.Printf("  5. git push && git push --tags\n")
+		if *createTag || *annotateTag {
+			if _, err := os.Stat(repoPath("package.json")); err == nil {
+				written = append(written, repoPath("package.json"))
+			}
+			if err := tagRelease(*newTag, changelogEntry, written, *createTag, *annotateTag); err != nil {
+				ui.Printf("⚠️  Warning: Failed to tag %s: %v\n", *newTag, err)
+			} else if *createTag {
+				ui.Printf("📌 Next steps:\n")
+				ui.Printf("  1. git push && git push %s %s\n", gitRemote, *newTag)
+				exit(ExitOK)
+			} else {
+				ui.Printf("ℹ️  Push the updated annotation with: git push --force %s %s\n", gitRemote, *newTag)
+			}
+		}
+
+		_, statErr := os.Stat(repoPath("package.json"))
+		ui.Printf("📌 Next steps:\n")
+		for i, step := range nextSteps(changelogName, *newTag, statErr == nil) {
+			ui.Printf("  %d. %s\n", i+1, step)
 		}
 	} else {
-		fmt.Println("\n⏹️ Update canceled.")
-		os.Exit(0)
+		ui.Println("\n⏹️ Update canceled.")
 	}
+	exit(ExitOK)
 }
 
-func generateChangelogEntry(executor AIExecutor, newTag, diff, commits, stagedDiff string) (string, error) {
-	today := time.Now().Format("2006-01-02")
+// collectGitChanges determines the tag preceding newTag and reads the changed files, the commits
+// and the uncommitted changes since then from the repository
+func collectGitChanges(newTag string) (previousTag, diff, commits, stagedDiff string, err error) {
+	// Get the latest tag
+	previousTag = getLatestTag()
 
-	// Check if this is an initial release
-	isInitialRelease := false
+	// Check if new tag already exists
+	if previousTag == newTag {
+		ui.Printf("⚠️  Tag %s already exists. Generating CHANGELOG from previous tag.\n", newTag)
+		// Find the tag before the current one
+		allTags, err := getAllTags()
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to get all tags: %w", err)
+		}
 
-	// Check committed files first
-	if diff != "" {
-		lines := strings.Split(diff, "\n")
-		allAdded := true
-		for _, line := range lines {
-			if line != "" && !strings.HasPrefix(line, "A\t") {
-				allAdded = false
+		// Find the tag before newTag
+		for i, tag := range allTags {
+			if tag == newTag && i > 0 {
+				previousTag = allTags[i-1]
+				ui.Printf("📌 Using previous tag: %s\n", previousTag)
+				break
+			} else if tag == newTag && i == 0 {
+				// This is the first tag, treat as initial release
+				previousTag = ""
+				ui.Println("📌 This is the first tag, treating as initial release.")
 				break
 			}
 		}
-		if allAdded && len(lines) > 5 {
-			isInitialRelease = true
-		}
-	}
-
-	// If no commits, check staged files for initial release pattern
-	if commits == "" && diff == "" && stagedDiff != "" {
-		lines := strings.Split(stagedDiff, "\n")
-		allAdded := true
-		addedCount := 0
-		for _, line := range lines {
-			if line != "" {
-				if strings.HasPrefix(line, "A\t") || strings.HasPrefix(line, "new file:") {
-					addedCount++
-				} else if !strings.HasPrefix(line, "diff --git") && !strings.HasPrefix(line, "index ") && !strings.HasPrefix(line, "+++") && !strings.HasPrefix(line, "---") && !strings.HasPrefix(line, "@@") {
-					// Not a diff header, check if it's an addition
-					if !strings.HasPrefix(line, "+") {
-						allAdded = false
-						break
-					}
-				}
-			}
+	} else if previousTag == "" {
+		ui.Println("📌 No previous tags found. This will be the first release.")
+	} else {
+		ui.Printf("📌 Previous tag: %s\n", previousTag)
+	}
+
+	if previousTag == "" {
+		// First release - get all files and commits
+		ui.Println("📊 Analyzing initial release...")
+		diff, err = getGitDiff("", gitRefHEAD)
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to get git diff: %w", err)
 		}
-		if allAdded && addedCount > 3 {
-			isInitialRelease = true
+
+		commits, err = getGitCommits("", gitRefHEAD)
+		if errors.Is(err, errNoCommits) {
+			ui.Println("📝 No commits found. Will generate CHANGELOG based on staged changes...")
+			commits = ""
+		} else if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to get commit messages: %w", err)
+		}
+	} else {
+		// Get the diff between tags
+		diff, err = getGitDiff(previousTag, "HEAD")
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to get git diff: %w", err)
+		}
+
+		// Get commit messages between tags
+		commits, err = getGitCommits(previousTag, "HEAD")
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to get commit messages: %w", err)
 		}
 	}
 
-	var prompt string
-	if isInitialRelease {
-		// Build content based on what we have
-		var content string
-		if commits != "" {
-			content += fmt.Sprintf(`コミットメッセージ:
+	// Get uncommitted changes
+	stagedDiff, err = getUncommittedDiff()
+	if err != nil {
+		ui.Printf("⚠️  Warning: Failed to get uncommitted changes: %v\n", err)
+		stagedDiff = ""
+	} else if stagedDiff != "" {
+		ui.Printf("📝 Including uncommitted changes (%s) in CHANGELOG...\n", uncommittedKinds())
+	}
+	return previousTag, diff, commits, stagedDiff, nil
+}
+
+// generateChangelogEntry asks the AI for the entry of newTag; initialRelease selects the prompt
+// that describes the whole project instead of the changes since the previous tag
+func generateChangelogEntry(executor AIExecutor, previousTag, newTag, diff, commits, stagedDiff, summaries string, initialRelease bool) (string, error) {
+	now := releaseDate()
+	today := changelogHeading.FormatDate(now)
+	heading := changelogHeading.Render(newTag, now)
+
+	build := func(diff, commits, stagedDiff string) string {
+		var prompt string
+		if initialRelease {
+			// Build content based on what we have
+			var content string
+			if commits != "" {
+				content += fmt.Sprintf(`コミットメッセージ:
 ---
 %s
 ---
 
 `, commits)
-		}
-		if diff != "" {
-			content += fmt.Sprintf(`追加されたファイル:
+			}
+			if diff != "" {
+				content += fmt.Sprintf(`追加されたファイル:
 ---
 %s
 ---
 
 `, diff)
-		}
-		if stagedDiff != "" {
-			content += fmt.Sprintf(`ステージング中のファイル:
+			}
+			if stagedDiff != "" {
+				content += fmt.Sprintf(`まだコミットされていないファイル:
 ---
 %s
 ---
 
 `, stagedDiff)
-		}
+			}
 
-		prompt = fmt.Sprintf(`これは初回リリースです。以下の情報に基づいて、Keep a Changelog形式でCHANGELOG.mdのエントリーを生成してください。
+			prompt = fmt.Sprintf(`これは初回リリースです。以下の情報に基づいて、Keep a Changelog形式でCHANGELOG.mdのエントリーを生成してください。
 
 新しいバージョンタグ: %s
 日付: %s
 
-%s以下の形式でCHANGELOGエントリーを生成してください（見出しレベル2から開始）:
-## [%s] - %s
+%s以下の形式でCHANGELOGエントリーを生成してください（見出しレベル%dから開始）:
+%s
 
 ### 追加
 
@@ -376,20 +1011,20 @@ func generateChangelogEntry(executor AIExecutor, newTag, diff, commits, stagedDi
 - CHANGELOGエントリー本文のみを出力してください
 - 各項目は日本語で記述し、人間が読みやすい形式にしてください
 - プロジェクトの目的や主要機能を明確に記載してください
-- ファイル構成から推測できる技術スタックも記載してください`, newTag, today, content, newTag, today)
-	} else {
-		// Build staged diff section if present
-		stagedSection := ""
-		if stagedDiff != "" {
-			stagedSection = fmt.Sprintf(`
-ステージング中の変更（まだコミットされていない）:
+- ファイル構成から推測できる技術スタックも記載してください`, newTag, today, content, changelogHeading.Level(), heading)
+		} else {
+			// Build staged diff section if present
+			stagedSection := ""
+			if stagedDiff != "" {
+				stagedSection = fmt.Sprintf(`
+まだコミットされていない変更:
 ---
 %s
 ---
 `, stagedDiff)
-		}
+			}
 
-		prompt = fmt.Sprintf(`以下のgitの差分情報とコミットメッセージに基づいて、Keep a Changelog形式でCHANGELOG.mdのエントリーを生成してください。
+			prompt = fmt.Sprintf(`以下のgitの差分情報とコミットメッセージに基づいて、Keep a Changelog形式でCHANGELOG.mdのエントリーを生成してください。
 
 新しいバージョンタグ: %s
 日付: %s
@@ -404,8 +1039,8 @@ func generateChangelogEntry(executor AIExecutor, newTag, diff, commits, stagedDi
 %s
 ---
 %s
-以下の形式でCHANGELOGエントリーを生成してください（見出しレベル2から開始）:
-## [%s] - %s
+以下の形式でCHANGELOGエントリーを生成してください（見出しレベル%dから開始）:
+%s
 
 セクションは以下の順序で、該当する変更がある場合のみ記載してください：
 ### 追加
@@ -441,66 +1076,90 @@ func generateChangelogEntry(executor AIExecutor, newTag, diff, commits, stagedDi
 - 該当する変更がないカテゴリは出力しないでください
 - 各項目は日本語で記述し、ユーザーにとって価値のある情報を具体的に記載してください
 - 変更の影響や理由が分かるように記述してください
-- コミット済みの変更とステージング中の変更を統合して記載してください
-- 技術的な詳細よりも、ユーザーへの影響を重視してください`, newTag, today, commits, diff, stagedSection, newTag, today)
+- コミット済みの変更とまだコミットされていない変更を統合して記載してください
+- 技術的な詳細よりも、ユーザーへの影響を重視してください`, newTag, today, commits, diff, stagedSection, changelogHeading.Level(), heading)
+		}
+		return prompt
 	}
 
+	prompt := fitPrompt(build, diff, commits, stagedDiff,
+		promptExtras(previousTag, gitRefHEAD, diff, commits)+fileSummarySection(summaries)+styleExampleSection(newTag)+projectContextSection())
 	result, err := executor.Execute(prompt)
 	if err != nil {
 		return "", err
 	}
 
-	return result, nil
+	if genOpts.Structured {
+		return renderStructuredEntry(result, changelogHeading.FormatVersion(newTag), today, heading)
+	}
+	return validateEntry(executor, result, heading)
 }
 
-func updateChangelog(filename, entry string) error {
-	// Extract version from the new entry
-	versionPattern := regexp.MustCompile(`^##\s+\[([^\]]+)\]`)
-	newVersionMatch := versionPattern.FindStringSubmatch(entry)
-	var newVersion string
-	if len(newVersionMatch) > 1 {
-		newVersion = newVersionMatch[1]
-	}
+// updatedChangelogContent returns the content of filename with entry inserted or replacing the same version.
+// entry may hold several entries, e.g. from catch-up; each one is placed among the existing versions.
+func updatedChangelogContent(filename, entry string) (string, error) {
+	// A BOM inside the entry would end up in the middle of the file
+	entry = stripBOM(entry)
+	block := strings.TrimSpace(normalizeNewlines(entry))
 
 	// Read existing CHANGELOG.md
-	content, err := os.ReadFile(filename)
+	raw, err := os.ReadFile(filename)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// Create new CHANGELOG.md if it doesn't exist
-			header := "# Changelog\n\n"
-			newContent := header + entry + "\n"
-			return os.WriteFile(filename, []byte(newContent), 0o644)
+			lines := activeSpec.apply(strings.Split("# Changelog\n\n"+block, "\n"), genOpts.RepoURL)
+			return normalizeChangelogSpacing(strings.Join(lines, "\n")), nil
 		}
-		return err
+		return "", err
+	}
+
+	// Work on decoded, LF-normalized content and restore the original byte order mark,
+	// encoding and line ending on write
+	text, encoding := decodeText(raw)
+	lineEnding := detectLineEnding(text)
+	// Setext version headings are parsed as ATX headings and written back as they were
+	text = normalizeNewlines(text)
+	setext := changelogHeading.setextHeadings(text)
+	lines := strings.Split(changelogHeading.ATX(text), "\n")
+	for _, entryLines := range splitEntries(block) {
+		lines = insertEntry(lines, entryLines)
 	}
+	lines = activeSpec.apply(lines, genOpts.RepoURL)
+
+	// Separation between entries and sections comes from the spacing normalization
+	content := restoreSetext(normalizeChangelogSpacing(strings.Join(lines, "\n")), setext)
+	return encoding.encode(applyLineEnding(content, lineEnding)), nil
+}
 
-	lines := strings.Split(string(content), "\n")
+// insertEntry replaces the entry of the same version in lines, or inserts the entry before the
+// first existing version that is not newer than it
+func insertEntry(lines, entryLines []string) []string {
+	newVersion, _ := changelogHeading.Version(entryLines[0])
 
 	// Check if the same version already exists and find its position
 	existingVersionStart := -1
 	existingVersionEnd := -1
 	insertPos := -1
 	inExistingVersion := false
+	lastVersion := -1
 
 	for i, line := range lines {
-		if versionPattern.MatchString(line) {
-			matches := versionPattern.FindStringSubmatch(line)
-			if len(matches) > 1 {
-				if matches[1] == newVersion && existingVersionStart == -1 {
-					// Found the same version
-					existingVersionStart = i
-					inExistingVersion = true
-					fmt.Printf("📝 Found existing entry for version %s, replacing it...\n", newVersion)
-				} else if inExistingVersion {
-					// Found the next version entry, mark the end of existing version
-					existingVersionEnd = i
-					inExistingVersion = false
-				}
+		if version, ok := changelogHeading.Version(line); ok {
+			lastVersion = i
+			if newVersion != "" && sameVersion(version, newVersion) && existingVersionStart == -1 {
+				// Found the same version
+				existingVersionStart = i
+				inExistingVersion = true
+				ui.Printf("📝 Found existing entry for version %s, replacing it...\n", newVersion)
+			} else if inExistingVersion {
+				// Found the next version entry, mark the end of existing version
+				existingVersionEnd = i
+				inExistingVersion = false
+			}
 
-				// Mark the first version position for insertion
-				if insertPos == -1 {
-					insertPos = i
-				}
+			// Mark the first older version position for insertion
+			if insertPos == -1 && (newVersion == "" || !releasedAfter(line, entryLines[0])) {
+				insertPos = i
 			}
 		}
 	}
@@ -511,45 +1170,74 @@ func updateChangelog(filename, entry string) error {
 		existingVersionEnd = len(lines)
 	}
 
-	var newContent string
-
-	if existingVersionStart != -1 {
-		// Replace existing version entry
-		var newLines []string
-
-		// Add lines before the existing version
-		if existingVersionStart > 0 {
-			newLines = append(newLines, lines[:existingVersionStart]...)
+	var newLines []string
+	switch {
+	case existingVersionStart != -1:
+		// Replace existing version entry, keeping its protected regions
+		var kept int
+		entryLines, kept = keepManualRegions(lines[existingVersionStart:existingVersionEnd], entryLines)
+		if kept > 0 {
+			ui.Printf("📌 Kept %d manual region(s) of %s\n", kept, newVersion)
 		}
+		newLines = append(newLines, lines[:existingVersionStart]...)
+		newLines = append(newLines, entryLines...)
+		newLines = append(newLines, lines[existingVersionEnd:]...)
+	case lastVersion == -1:
+		// No existing versions, append at the end
+		newLines = append(append(newLines, lines...), entryLines...)
+	case insertPos == -1:
+		// Every existing version is newer, append after the last entry
+		end := max(entriesEnd(lines), lastVersion+1)
+		newLines = append(newLines, lines[:end]...)
+		newLines = append(newLines, "")
+		newLines = append(newLines, entryLines...)
+		newLines = append(newLines, "")
+		newLines = append(newLines, lines[end:]...)
+	default:
+		// Insert before the first older version entry
+		newLines = append(newLines, lines[:insertPos]...)
+		newLines = append(newLines, entryLines...)
+		newLines = append(newLines, lines[insertPos:]...)
+	}
+	return newLines
+}
 
-		// Add the new entry
-		newLines = append(newLines, strings.Split(entry, "\n")...)
+// gitDir is the working directory for git commands; empty means the current directory
+var gitDir string
 
-		// Add lines after the existing version
-		if existingVersionEnd < len(lines) && existingVersionEnd != -1 {
-			// Add an empty line for separation if needed
-			if existingVersionEnd > 0 && strings.TrimSpace(lines[existingVersionEnd-1]) != "" {
-				newLines = append(newLines, "")
-			}
-			newLines = append(newLines, lines[existingVersionEnd:]...)
-		}
+// setRepoDir makes git commands and repository-relative paths use dir; an empty dir means the current directory
+func setRepoDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return &ConfigError{Err: fmt.Errorf("cannot use repository directory: %w", err)}
+	}
+	if !info.IsDir() {
+		return &ConfigError{Err: fmt.Errorf("cannot use repository directory: %s is not a directory", dir)}
+	}
+	gitDir = dir
+	return nil
+}
 
-		newContent = strings.Join(newLines, "\n")
-	} else if insertPos == -1 {
-		// No existing versions, append at the end
-		newContent = string(content) + "\n" + entry + "\n"
-	} else {
-		// Insert before the first version entry
-		before := strings.Join(lines[:insertPos], "\n")
-		after := strings.Join(lines[insertPos:], "\n")
-		newContent = before + "\n" + entry + "\n\n" + after
+// repoPath resolves a path relative to the repository directory; absolute paths are returned unchanged
+func repoPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
 	}
+	return filepath.Join(gitDir, path)
+}
 
-	return os.WriteFile(filename, []byte(newContent), 0o644)
+// gitCommand builds a git command that runs inside gitDir
+func gitCommand(args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = gitDir
+	return cmd
 }
 
 func getLatestTag() string {
-	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
+	cmd := gitCommand(append([]string{"describe", "--tags", "--abbrev=0"}, describeMatchArgs()...)...)
 	output, err := cmd.Output()
 	if err != nil {
 		// No tags exist yet
@@ -559,64 +1247,58 @@ func getLatestTag() string {
 }
 
 func getGitDiff(fromTag, toTag string) (string, error) {
-	var cmd *exec.Cmd
 	if fromTag == "" || fromTag == gitRefHEAD {
 		// First release, get all files
-		cmd = exec.Command("git", "ls-files")
-		output, err := cmd.Output()
+		output, err := gitOutput("ls-files")
 		if err != nil {
 			return "", err
 		}
 		// Format as added files
-		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-		var result []string
-		for _, line := range lines {
-			if line != "" {
-				result = append(result, "A\t"+line)
-			}
-		}
-		return strings.Join(result, "\n"), nil
-	} else {
-		cmd = exec.Command("git", "diff", "--name-status", fromTag, toTag)
+		return withoutBulkFiles(aiFilter.filterNameStatus(moduleFiles(addedNameStatus(output))), "", toTag), nil
 	}
 
-	output, err := cmd.Output()
+	output, err := gitOutput(nameStatusArgs(fromTag, toTag)...)
 	if err != nil {
 		return "", err
 	}
-	return string(output), nil
+	return withoutBulkFiles(aiFilter.filterNameStatus(moduleFiles(strings.TrimSpace(output))), fromTag, toTag), nil
 }
 
 func getGitCommits(fromTag, toTag string) (string, error) {
-	var cmd *exec.Cmd
-	if fromTag == "" || fromTag == gitRefHEAD {
-		// First release, get all commits
-		cmd = exec.Command("git", "log", "--oneline", toTag)
-	} else {
-		cmd = exec.Command("git", "log", "--oneline", fmt.Sprintf("%s..%s", fromTag, toTag))
+	revRange := toTag
+	if fromTag != "" && fromTag != gitRefHEAD {
+		revRange = fmt.Sprintf("%s..%s", fromTag, toTag)
+	} else if !hasCommits() {
+		// First release in a repository without commits
+		return "", errNoCommits
 	}
 
-	output, err := cmd.Output()
+	output, err := gitOutput(append([]string{"log", commitLogFormat, revRange}, modulePathspec()...)...)
 	if err != nil {
 		return "", err
 	}
-	return string(output), nil
+	commits, _ := parseCommitLog(output)
+	return commitFilter.filterOneline(commits), nil
 }
 
 func pullTags() error {
+	if !hasRemote() {
+		ui.Println("ℹ️  No remote configured, using local tags only.")
+		return nil
+	}
 	// First try git fetch --tags which doesn't require tracking info
-	cmd := exec.Command("git", "fetch", "--tags")
+	cmd := gitCommand("fetch", "--tags", gitRemote)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// If fetch fails, try pull (might work if tracking is set up)
-		cmd = exec.Command("git", "pull", "--tags")
+		cmd = gitCommand("pull", "--tags")
 		_, err = cmd.CombinedOutput()
 		if err != nil {
 			// Check if this is just a warning about no tracking info
 			outputStr := string(output)
 			if strings.Contains(outputStr, "no tracking information") {
 				// This is okay, we can still work with local tags
-				fmt.Println("ℹ️  No remote tracking configured, using local tags only.")
+				ui.Println("ℹ️  No remote tracking configured, using local tags only.")
 				return nil
 			}
 			return fmt.Errorf("failed to fetch tags: %w\nOutput: %s", err, output)
@@ -626,32 +1308,36 @@ func pullTags() error {
 }
 
 func getStagedDiff() (string, error) {
-	cmd := exec.Command("git", "diff", "--cached", "--name-status")
-	output, err := cmd.Output()
+	output, err := gitOutput(nameStatusArgs("--cached")...)
 	if err != nil {
 		return "", err
 	}
-	return strings.TrimSpace(string(output)), nil
+	return aiFilter.filterNameStatus(strings.TrimSpace(output)), nil
 }
 
-func catchUpMode(executor AIExecutor, changelogFile string) error {
-	fmt.Println("🔍 Checking for missing tags in CHANGELOG...")
+// catchUpMode generates entries for tags missing from the changelog and returns the tags that were added;
+// it returns errNothingToDo when there is no tag to add
+func catchUpMode(executor AIExecutor, changelogFile string, concurrency int, verbose bool) ([]string, error) {
+	ui.Println("🔍 Checking for missing tags in CHANGELOG...")
 
 	// Get all tags from git
 	allTags, err := getAllTags()
 	if err != nil {
-		return fmt.Errorf("failed to get all tags: %w", err)
+		return nil, fmt.Errorf("failed to get all tags: %w", err)
 	}
 
 	if len(allTags) == 0 {
-		fmt.Println("❓ No tags found in repository.")
-		return nil
+		ui.Println("❓ No tags found in repository.")
+		return nil, errNothingToDo
 	}
 
 	// Get existing versions from CHANGELOG
 	existingVersions, err := getExistingVersionsFromChangelog(changelogFile)
 	if err != nil {
-		return fmt.Errorf("failed to read existing changelog: %w", err)
+		return nil, fmt.Errorf("failed to read existing changelog: %w", err)
+	}
+	if splitOnly {
+		existingVersions = append(existingVersions, splitVersions(outputTargets)...)
 	}
 
 	// Find missing tags
@@ -659,7 +1345,7 @@ func catchUpMode(executor AIExecutor, changelogFile string) error {
 	for _, tag := range allTags {
 		found := false
 		for _, version := range existingVersions {
-			if version == tag {
+			if sameVersion(version, tag) {
 				found = true
 				break
 			}
@@ -670,26 +1356,35 @@ func catchUpMode(executor AIExecutor, changelogFile string) error {
 	}
 
 	if len(missingTags) == 0 {
-		fmt.Println("✅ All tags are already in CHANGELOG.md")
-		return nil
+		ui.Println("✅ All tags are already in CHANGELOG.md")
+		return nil, errNothingToDo
 	}
 
-	fmt.Printf("📌 Found %d missing tag(s):\n", len(missingTags))
+	ui.Printf("📌 Found %d missing tag(s):\n", len(missingTags))
 	for _, tag := range missingTags {
-		fmt.Printf("  - %s\n", tag)
+		ui.Printf("  - %s\n", tag)
 	}
 
-	fmt.Print("\nDo you want to add these missing entries? [y/N]: ")
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+	ranges := make([]signatureRange, len(missingTags))
+	for i, tag := range missingTags {
+		ranges[i] = signatureRange{From: findPreviousTag(allTags, tag), To: tag, Tag: tag}
+	}
+	if err := verifySignatures(ranges); err != nil {
+		return nil, err
 	}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-	if response != responseY && response != responseYes {
-		fmt.Println("⏹️ Catch-up canceled.")
-		return nil
+	// With --no the entries are still generated so they can be previewed
+	if assumeAnswer == answerNo {
+		ui.Println("\n👀 Previewing the missing entries (--no flag); nothing will be written.")
+	} else {
+		accepted, err := confirm("\nDo you want to add these missing entries? [y/N]: ")
+		if err != nil {
+			return nil, err
+		}
+		if !accepted {
+			ui.Println("⏹️ Catch-up canceled.")
+			return nil, nil
+		}
 	}
 
 	// Process each missing tag (reverse order - newest first)
@@ -698,96 +1393,145 @@ func catchUpMode(executor AIExecutor, changelogFile string) error {
 		missingTags[i], missingTags[j] = missingTags[j], missingTags[i]
 	}
 
-	allEntries := make([]string, 0, len(missingTags))
-	for i, tag := range missingTags {
-		fmt.Printf("\n🔧 Processing %s (%d/%d)...\n", tag, i+1, len(missingTags))
-
-		// Find the previous tag
-		previousTag := ""
-		tagIndex := -1
-		for idx, t := range allTags {
-			if t == tag {
-				tagIndex = idx
-				break
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// Generate entries concurrently, keeping each result at its tag's position
+	results := make([]string, len(missingTags))
+	jobs := make(chan int)
+	progress := newCatchUpProgress(len(missingTags), verbose)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				tag := missingTags[i]
+				started := time.Now()
+				metered := &meteredExecutor{AIExecutor: executor}
+				label := ui.Sprintf("Generating entry for %s (%s..%s)", tag, findPreviousTag(allTags, tag), tag)
+				spin := startSpinner(label, false)
+				entry, genErr := generateCatchUpEntry(metered, allTags, tag)
+				spin.Stop()
+				if genErr != nil {
+					ui.Printf("⚠️  Warning: %v\n", genErr)
+				} else {
+					results[i] = entry
+				}
+				prompt, response := metered.totals()
+				progress.complete(tag, time.Since(started), tokenUsage{Prompt: prompt, Response: response})
 			}
-		}
-		if tagIndex > 0 {
-			previousTag = allTags[tagIndex-1]
-		}
+		}()
+	}
+	for i := range missingTags {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-		if previousTag == "" {
-			previousTag = gitRefHEAD
+	generated := 0
+	for _, entry := range results {
+		if entry != "" {
+			generated++
 		}
+	}
+	if generated == 0 {
+		ui.Println("❌ No entries could be generated.")
+		return nil, nil
+	}
 
-		// Get diff and commits
-		var diff string
-		diff, err = getGitDiff(previousTag, tag)
-		if err != nil {
-			fmt.Printf("⚠️  Warning: Failed to get diff for %s: %v\n", tag, err)
+	// Review each entry, newest first, so a bad entry does not hold back the others
+	allEntries := make([]string, 0, len(missingTags))
+	generatedTags := make([]string, 0, len(missingTags))
+	for i, entry := range results {
+		if entry == "" {
 			continue
 		}
-
-		var commits string
-		commits, err = getGitCommits(previousTag, tag)
-		if err != nil {
-			fmt.Printf("⚠️  Warning: Failed to get commits for %s: %v\n", tag, err)
-			continue
+		tag := missingTags[i]
+		regenerate := func() (string, error) {
+			spin := startSpinner(ui.Sprintf("Regenerating entry for %s (%s..%s)", tag, findPreviousTag(allTags, tag), tag), !ui.plain)
+			defer spin.Stop()
+			return generateCatchUpEntry(&meteredExecutor{AIExecutor: executor}, allTags, tag)
 		}
-
-		// Generate changelog entry with tag date
-		var entry string
-		entry, err = generateChangelogEntryForTag(executor, tag, diff, commits)
+		reviewed, accepted, err := reviewCatchUpEntry(tag, entry, regenerate)
 		if err != nil {
-			fmt.Printf("⚠️  Warning: Failed to generate entry for %s: %v\n", tag, err)
-			continue
+			return nil, err
+		}
+		if accepted {
+			allEntries = append(allEntries, reviewed)
+			generatedTags = append(generatedTags, tag)
 		}
-
-		allEntries = append(allEntries, entry)
 	}
 
 	if len(allEntries) == 0 {
-		fmt.Println("❌ No entries could be generated.")
-		return nil
+		ui.Println("\n⏹️ Update canceled: no entry was accepted.")
+		return nil, nil
 	}
 
 	// Combine all entries
 	combinedEntry := strings.Join(allEntries, "\n\n")
 
-	fmt.Println("\n📝 Generated CHANGELOG Entries:")
-	fmt.Println("===================================")
-	fmt.Println(combinedEntry)
-	fmt.Println("===================================")
-
-	fmt.Print("\nDo you want to update CHANGELOG.md with these entries? [y/N]: ")
-	response2, err := reader.ReadString('\n')
+	written, err := writeChangelogOutputs(changelogFile, combinedEntry)
 	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+		return nil, fmt.Errorf("update failed: %w", err)
+	}
+	if !splitOnly {
+		ui.Printf("\n✅ %s updated successfully!\n", filepath.Base(changelogFile))
 	}
+	reportExtraOutputs(changelogFile, written)
+
+	return generatedTags, nil
+}
 
-	response2 = strings.TrimSpace(strings.ToLower(response2))
-	if response2 == "y" || response2 == "yes" {
-		if err := updateChangelog(changelogFile, combinedEntry); err != nil {
-			return fmt.Errorf("update failed: %w", err)
+// findPreviousTag returns the tag preceding tag in allTags, or HEAD when tag is the first one
+func findPreviousTag(allTags []string, tag string) string {
+	for i, t := range allTags {
+		if t == tag && i > 0 {
+			return allTags[i-1]
 		}
-		fmt.Println("\n✅ CHANGELOG.md updated successfully!")
-	} else {
-		fmt.Println("\n⏹️ Update canceled.")
 	}
+	return gitRefHEAD
+}
 
-	return nil
+// generateCatchUpEntry collects the changes for a single tag and generates its entry
+func generateCatchUpEntry(executor AIExecutor, allTags []string, tag string) (string, error) {
+	previousTag := findPreviousTag(allTags, tag)
+
+	diff, err := getGitDiff(previousTag, tag)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff for %s: %w", tag, err)
+	}
+
+	commits, err := getGitCommits(previousTag, tag)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commits for %s: %w", tag, err)
+	}
+
+	// Generate changelog entry with tag date
+	recorder := &promptRecorder{AIExecutor: executor}
+	entry, err := generateChangelogEntryForTag(recorder, previousTag, tag, diff, commits, changeSummaries(previousTag, tag))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entry for %s: %w", tag, err)
+	}
+	entry = finalizeEntry(entry, previousTag, tag)
+	if genOpts.Metadata {
+		entry = addGenerationMetadata(entry, recorder.metadata(previousTag, tag))
+	}
+	return entry, nil
 }
 
 func getAllTags() ([]string, error) {
-	cmd := exec.Command("git", "tag", "--sort=-version:refname")
-	output, err := cmd.Output()
+	output, err := gitOutput("tag", "--sort=-version:refname")
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	nested := nestedGoModules()
 	var tags []string
 	for _, line := range lines {
-		if line != "" {
+		if line != "" && tagInModule(line, nested) {
 			tags = append(tags, line)
 		}
 	}
@@ -800,7 +1544,7 @@ func getAllTags() ([]string, error) {
 }
 
 func getExistingVersionsFromChangelog(filename string) ([]string, error) {
-	content, err := os.ReadFile(filename)
+	content, err := readTextFile(filename)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return []string{}, nil
@@ -808,44 +1552,45 @@ func getExistingVersionsFromChangelog(filename string) ([]string, error) {
 		return nil, err
 	}
 
-	versionPattern := regexp.MustCompile(`^##\s+\[([^\]]+)\]`)
-	lines := strings.Split(string(content), "\n")
+	lines := strings.Split(changelogHeading.ATX(normalizeNewlines(content)), "\n")
 	var versions []string
 
 	for _, line := range lines {
-		matches := versionPattern.FindStringSubmatch(line)
-		if len(matches) > 1 {
-			versions = append(versions, matches[1])
+		if version, ok := changelogHeading.Version(line); ok {
+			versions = append(versions, version)
 		}
 	}
 
 	return versions, nil
 }
 
-func generateChangelogEntryForTag(executor AIExecutor, tag, diff, commits string) (string, error) {
+func generateChangelogEntryForTag(executor AIExecutor, previousTag, tag, diff, commits, summaries string) (string, error) {
 	// Get tag date
-	date, err := getTagDate(tag)
+	tagDate, err := getTagDate(tag)
 	if err != nil {
-		date = time.Now().Format("2006-01-02")
+		tagDate = releaseDate()
 	}
+	date := changelogHeading.FormatDate(tagDate)
+	heading := changelogHeading.Render(tag, tagDate)
 
-	// Also check for staged changes
-	stagedDiff, err := getStagedDiff()
+	// Also check for uncommitted changes
+	stagedDiff, err := getUncommittedDiff()
 	if err != nil {
-		fmt.Printf("⚠️ Warning: Failed to get staged diff: %v\n", err)
+		ui.Printf("⚠️ Warning: Failed to get uncommitted changes: %v\n", err)
 		stagedDiff = ""
 	}
-	stagedSection := ""
-	if stagedDiff != "" {
-		stagedSection = fmt.Sprintf(`
+	build := func(diff, commits, stagedDiff string) string {
+		stagedSection := ""
+		if stagedDiff != "" {
+			stagedSection = fmt.Sprintf(`
 
-ステージング中の変更（まだコミットされていない）:
+まだコミットされていない変更:
 ---
 %s
 ---`, stagedDiff)
-	}
+		}
 
-	prompt := fmt.Sprintf(`以下のgitの差分情報とコミットメッセージに基づいて、Keep a Changelog形式でCHANGELOG.mdのエントリーを生成してください。
+		return fmt.Sprintf(`以下のgitの差分情報とコミットメッセージに基づいて、Keep a Changelog形式でCHANGELOG.mdのエントリーを生成してください。
 
 バージョンタグ: %s
 日付: %s
@@ -860,8 +1605,8 @@ func generateChangelogEntryForTag(executor AIExecutor, tag, diff, commits string
 %s
 ---%s
 
-以下の形式でCHANGELOGエントリーを生成してください（見出しレベル2から開始）:
-## [%s] - %s
+以下の形式でCHANGELOGエントリーを生成してください（見出しレベル%dから開始）:
+%s
 
 セクションは以下の順序で、該当する変更がある場合のみ記載してください：
 ### 追加
@@ -897,42 +1642,26 @@ func generateChangelogEntryForTag(executor AIExecutor, tag, diff, commits string
 - 該当する変更がないカテゴリは出力しないでください
 - 各項目は日本語で記述し、ユーザーにとって価値のある情報を具体的に記載してください
 - 変更の影響や理由が分かるように記述してください
-- ステージング中の変更も含めて記載してください
-- 技術的な詳細よりも、ユーザーへの影響を重視してください`, tag, date, commits, diff, stagedSection, tag, date)
-
-	result, err := executor.Execute(prompt)
-	if err != nil {
-		return "", err
+- まだコミットされていない変更も含めて記載してください
+- 技術的な詳細よりも、ユーザーへの影響を重視してください`, tag, date, commits, diff, stagedSection, changelogHeading.Level(), heading)
 	}
 
-	return result, nil
-}
-
-func getTagDate(tag string) (string, error) {
-	cmd := exec.Command("git", "log", "-1", "--format=%ai", tag)
-	output, err := cmd.Output()
+	prompt := fitPrompt(build, diff, commits, stagedDiff,
+		promptExtras(previousTag, tag, diff, commits)+fileSummarySection(summaries)+tagMessageSection(tagMessage(tag))+styleExampleSection(tag)+projectContextSection())
+	result, err := executor.Execute(prompt)
 	if err != nil {
 		return "", err
 	}
 
-	// Parse date from output (format: 2025-08-26 12:34:56 +0900)
-	dateStr := strings.TrimSpace(string(output))
-	if dateStr == "" {
-		return "", fmt.Errorf("no date found for tag %s", tag)
+	if genOpts.Structured {
+		return renderStructuredEntry(result, changelogHeading.FormatVersion(tag), date, heading)
 	}
-
-	// Extract just the date part (YYYY-MM-DD)
-	parts := strings.Split(dateStr, " ")
-	if len(parts) > 0 {
-		return parts[0], nil
-	}
-
-	return "", fmt.Errorf("invalid date format for tag %s", tag)
+	return validateEntry(executor, result, heading)
 }
 
 func updatePackageJSONVersion(tag string) error {
 	// Check if package.json exists
-	packageJSONPath := "package.json"
+	packageJSONPath := repoPath("package.json")
 	if _, err := os.Stat(packageJSONPath); os.IsNotExist(err) {
 		// No package.json, nothing to do
 		return nil
@@ -956,9 +1685,9 @@ func updatePackageJSONVersion(tag string) error {
 	// Update version
 	oldVersion, hasVersion := packageData["version"].(string)
 	if !hasVersion {
-		fmt.Println("📦 Adding version to package.json...")
+		ui.Println("📦 Adding version to package.json...")
 	} else if oldVersion != version {
-		fmt.Printf("📦 Updating package.json version from %s to %s...\n", oldVersion, version)
+		ui.Printf("📦 Updating package.json version from %s to %s...\n", oldVersion, version)
 	} else {
 		// Version is already up to date
 		return nil
@@ -977,7 +1706,7 @@ func updatePackageJSONVersion(tag string) error {
 		return fmt.Errorf("failed to write package.json: %w", err)
 	}
 
-	fmt.Println("✅ package.json version updated successfully!")
+	ui.Println("✅ package.json version updated successfully!")
 	return nil
 }
 
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -16,6 +17,17 @@ type MockExecutor struct {
 	prompts  []string // Store prompts for verification
 }
 
+// testReleaseDate is the date of new entries in the tests, fixed like --date does
+var testReleaseDate = time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+// fixReleaseDate dates new entries with testReleaseDate until the test ends
+func fixReleaseDate(t *testing.T) {
+	t.Helper()
+	saved := releaseDateOverride
+	t.Cleanup(func() { releaseDateOverride = saved })
+	releaseDateOverride = testReleaseDate
+}
+
 func (m *MockExecutor) Execute(prompt string) (string, error) {
 	m.prompts = append(m.prompts, prompt)
 	if m.err != nil {
@@ -25,6 +37,7 @@ func (m *MockExecutor) Execute(prompt string) (string, error) {
 }
 
 func TestGenerateChangelogEntry(t *testing.T) {
+	fixReleaseDate(t)
 	tests := []struct {
 		name       string
 		tag        string
@@ -95,19 +108,27 @@ func TestGenerateChangelogEntry(t *testing.T) {
 				executor.err = fmt.Errorf("mock error")
 			}
 
-			got, err := generateChangelogEntry(executor, tt.tag, tt.diff, tt.commits, tt.stagedDiff)
+			got, err := generateChangelogEntry(executor, "", tt.tag, tt.diff, tt.commits, tt.stagedDiff, "", false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("generateChangelogEntry() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.response {
-				t.Errorf("generateChangelogEntry() = %v, want %v", got, tt.response)
+			if tt.wantErr {
+				return
+			}
+			// The heading is always replaced with the rendered one for the release date
+			want := changelogHeading.Render(tt.tag, testReleaseDate) + tt.response[strings.Index(tt.response, "\n"):]
+			if got != want {
+				t.Errorf("generateChangelogEntry() = %v, want %v", got, want)
 			}
 		})
 	}
 }
 
 func TestGenerateChangelogEntryForTag(t *testing.T) {
+	// No tag exists in the empty repository, so the entry is dated with the release date
+	newTestRepo(t)
+	fixReleaseDate(t)
 	tests := []struct {
 		name     string
 		tag      string
@@ -148,13 +169,18 @@ func TestGenerateChangelogEntryForTag(t *testing.T) {
 				executor.err = fmt.Errorf("mock error")
 			}
 
-			got, err := generateChangelogEntryForTag(executor, tt.tag, tt.diff, tt.commits)
+			got, err := generateChangelogEntryForTag(executor, "", tt.tag, tt.diff, tt.commits, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("generateChangelogEntryForTag() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.response {
-				t.Errorf("generateChangelogEntryForTag() = %v, want %v", got, tt.response)
+			if tt.wantErr {
+				return
+			}
+			// The heading is always replaced with the rendered one for the release date
+			want := changelogHeading.Render(tt.tag, testReleaseDate) + tt.response[strings.Index(tt.response, "\n"):]
+			if got != want {
+				t.Errorf("generateChangelogEntryForTag() = %v, want %v", got, want)
 			}
 		})
 	}
@@ -270,6 +296,34 @@ This is the changelog.
 				"2025-08-01",
 			},
 		},
+		{
+			name: "replace non-bracketed version entry",
+			existingContent: `# Changelog
+
+## 1.0.0 (2025-08-01)
+
+### 追加
+- Old feature
+
+## v0.9.0 - 2025-07-01
+
+### 修正
+- Old fix`,
+			newEntry: `## [v1.0.0] - 2025-08-27
+
+### 追加
+- New feature`,
+			wantContains: []string{
+				"## [v1.0.0] - 2025-08-27",
+				"New feature",
+				"## v0.9.0 - 2025-07-01",
+				"Old fix",
+			},
+			wantNotContains: []string{
+				"Old feature",
+				"## 1.0.0 (2025-08-01)",
+			},
+		},
 		{
 			name: "add multiple entries",
 			existingContent: `# Changelog
@@ -307,9 +361,9 @@ This is the changelog.
 			}
 
 			// Update changelog
-			err := updateChangelog(tempFile, tt.newEntry)
+			_, err := writeChangelogOutputs(tempFile, tt.newEntry)
 			if err != nil {
-				t.Errorf("updateChangelog() error = %v", err)
+				t.Errorf("writeChangelogOutputs() error = %v", err)
 				return
 			}
 
@@ -386,6 +440,16 @@ This is a new changelog.`,
 ## [v0.9.0] - 2025-08-01`,
 			want: []string{" v1.0.0 ", "v0.9.0"},
 		},
+		{
+			name: "non-bracketed headings",
+			content: `# Changelog
+
+## v1.2.0 - 2025-01-01
+## 1.1.0 (2024-12-01)
+## v1.0.0
+### 追加`,
+			want: []string{"v1.2.0", "1.1.0", "v1.0.0"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -496,9 +560,9 @@ More text.
 ### 追加
 - New feature`
 
-		err := updateChangelog(tempFile, newEntry)
+		_, err := writeChangelogOutputs(tempFile, newEntry)
 		if err != nil {
-			t.Errorf("updateChangelog() error = %v", err)
+			t.Errorf("writeChangelogOutputs() error = %v", err)
 		}
 
 		updated, _ := os.ReadFile(tempFile)
@@ -525,6 +589,7 @@ More text.
 }
 
 func TestGenerateChangelogEntryPromptContent(t *testing.T) {
+	fixReleaseDate(t)
 	executor := &MockExecutor{
 		response: "## [v1.0.0] - 2025-08-27\n### 追加\n- Test",
 	}
@@ -533,7 +598,7 @@ func TestGenerateChangelogEntryPromptContent(t *testing.T) {
 	diff := "A\tfile.go"
 	commits := "abc123 feat: test"
 
-	_, err := generateChangelogEntry(executor, tag, diff, commits, "")
+	_, err := generateChangelogEntry(executor, "", tag, diff, commits, "", "", false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -562,10 +627,9 @@ func TestGenerateChangelogEntryPromptContent(t *testing.T) {
 		}
 	}
 
-	// Check date format in prompt (should be today's date)
-	today := time.Now().Format("2006-01-02")
-	if !strings.Contains(prompt, today) {
-		t.Errorf("Prompt does not contain today's date: %s", today)
+	// Check date format in prompt (should be the release date)
+	if date := testReleaseDate.Format(time.DateOnly); !strings.Contains(prompt, date) {
+		t.Errorf("Prompt does not contain the release date: %s", date)
 	}
 }
 
@@ -640,3 +704,48 @@ func TestIntegrationWithClaude(t *testing.T) {
 	// These would test the actual integration with Claude CLI
 	// when running locally with proper setup
 }
+
+func TestFindPreviousTag(t *testing.T) {
+	allTags := []string{"v0.1.0", "v0.2.0", "v1.0.0"}
+
+	testCases := []struct {
+		tag  string
+		want string
+	}{
+		{"v1.0.0", "v0.2.0"},
+		{"v0.2.0", "v0.1.0"},
+		{"v0.1.0", gitRefHEAD},
+		{"v9.9.9", gitRefHEAD},
+	}
+
+	for _, tc := range testCases {
+		if got := findPreviousTag(allTags, tc.tag); got != tc.want {
+			t.Errorf("findPreviousTag(%q) = %q, want %q", tc.tag, got, tc.want)
+		}
+	}
+}
+
+func TestRepoPath(t *testing.T) {
+	saved := gitDir
+	defer func() { gitDir = saved }()
+
+	dir := t.TempDir()
+	if err := setRepoDir(dir); err != nil {
+		t.Fatalf("setRepoDir() error = %v", err)
+	}
+	if got := repoPath("CHANGELOG.md"); got != filepath.Join(dir, "CHANGELOG.md") {
+		t.Errorf("repoPath() = %q", got)
+	}
+	abs := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	if got := repoPath(abs); got != abs {
+		t.Errorf("repoPath(%q) = %q", abs, got)
+	}
+
+	err := setRepoDir(filepath.Join(dir, "missing"))
+	if exitCode(err) != ExitConfig {
+		t.Errorf("setRepoDir(missing) error = %v, want a config error", err)
+	}
+	if gitDir != dir {
+		t.Errorf("gitDir = %q after a failed setRepoDir, want %q", gitDir, dir)
+	}
+}
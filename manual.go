@@ -0,0 +1,102 @@
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Markers of a protected region inside a version entry, which regenerating the entry keeps
+var (
+	manualStartPattern = regexp.MustCompile(`^\s*<!--\s*manual\s*-->\s*$`)
+	manualEndPattern   = regexp.MustCompile(`^\s*<!--\s*/manual\s*-->\s*$`)
+)
+
+// manualRegion is a protected region of an entry with the section heading it appeared under
+type manualRegion struct {
+	// Section is the heading line above the region, empty when it precedes every section
+	Section string
+	// Lines include both markers; a region without an end marker runs to the end of the entry
+	Lines []string
+}
+
+// manualRegions returns the protected regions of an entry; headings inside a region do not start
+// a section
+func manualRegions(entryLines []string) []manualRegion {
+	var regions []manualRegion
+	section := ""
+	var current *manualRegion
+	for i, line := range entryLines {
+		switch {
+		case current != nil:
+			current.Lines = append(current.Lines, line)
+			if manualEndPattern.MatchString(line) {
+				regions = append(regions, *current)
+				current = nil
+			}
+		case manualStartPattern.MatchString(line):
+			current = &manualRegion{Section: section, Lines: []string{line}}
+		case i > 0 && htmlHeadingPattern.MatchString(line):
+			section = strings.TrimSpace(line)
+		}
+	}
+	if current != nil {
+		for len(current.Lines) > 1 && strings.TrimSpace(current.Lines[len(current.Lines)-1]) == "" {
+			current.Lines = current.Lines[:len(current.Lines)-1]
+		}
+		regions = append(regions, *current)
+	}
+	return regions
+}
+
+// keepManualRegions carries the protected regions of an existing entry over to the entry that
+// replaces it and returns how many it added: a region above every section goes right below the
+// version heading, any other to the end of its section, or to the end of the entry (before the
+// generation metadata) when the new entry has no such section. Regions the new entry already
+// contains are not repeated.
+func keepManualRegions(existing, entryLines []string) ([]string, int) {
+	regions := manualRegions(existing)
+	present := manualRegions(entryLines)
+	kept := 0
+	for _, region := range regions {
+		if slices.ContainsFunc(present, func(r manualRegion) bool { return slices.Equal(r.Lines, region.Lines) }) {
+			continue
+		}
+		at := manualInsertPosition(entryLines, region.Section)
+		entryLines = slices.Insert(entryLines, at, append(append([]string{""}, region.Lines...), "")...)
+		kept++
+	}
+	return entryLines, kept
+}
+
+// manualInsertPosition returns where a region of section goes in entryLines
+func manualInsertPosition(entryLines []string, section string) int {
+	if section == "" {
+		return min(1, len(entryLines))
+	}
+	start, end := 1, len(entryLines)
+	if i := slices.IndexFunc(entryLines, func(line string) bool { return strings.TrimSpace(line) == section }); i > 0 {
+		start, end = i+1, sectionEnd(entryLines, i+1)
+	}
+	for end > start && (strings.TrimSpace(entryLines[end-1]) == "" || strings.HasPrefix(entryLines[end-1], metadataCommentPrefix)) {
+		end--
+	}
+	return end
+}
+
+// sectionEnd returns the index of the first heading at or after start that is not inside a
+// protected region, or the number of lines
+func sectionEnd(entryLines []string, start int) int {
+	inRegion := false
+	for i := start; i < len(entryLines); i++ {
+		switch {
+		case manualStartPattern.MatchString(entryLines[i]):
+			inRegion = true
+		case manualEndPattern.MatchString(entryLines[i]):
+			inRegion = false
+		case !inRegion && htmlHeadingPattern.MatchString(entryLines[i]):
+			return i
+		}
+	}
+	return len(entryLines)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKeepManualRegions(t *testing.T) {
+	savedUI := ui
+	defer func() { ui = savedUI }()
+	ui = &console{out: &strings.Builder{}, plain: true}
+
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	existing := `# Changelog
+
+## [v1.1.0] - 2025-02-01
+
+<!-- manual -->
+> このリリースではデータベースの移行が必要です
+<!-- /manual -->
+
+### 追加
+
+- 古い項目
+<!-- manual -->
+- 詳細は [移行ガイド](docs/migrate.md) を参照
+<!-- /manual -->
+
+### 削除
+
+- 古い削除
+
+<!-- manual -->
+### 既知の問題
+
+- Windowsでは未対応
+<!-- /manual -->
+
+## [v1.0.0] - 2025-01-01
+
+- A
+`
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := "## [v1.1.0] - 2025-02-01\n\n### 追加\n\n- 新しい項目\n\n### 修正\n\n- 修正\n\n<!-- changelog-update: tool=dev -->"
+	got, err := updatedChangelogContent(path, entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Changelog
+
+## [v1.1.0] - 2025-02-01
+
+<!-- manual -->
+> このリリースではデータベースの移行が必要です
+<!-- /manual -->
+
+### 追加
+
+- 新しい項目
+
+<!-- manual -->
+- 詳細は [移行ガイド](docs/migrate.md) を参照
+<!-- /manual -->
+
+### 修正
+
+- 修正
+
+<!-- manual -->
+
+### 既知の問題
+
+- Windowsでは未対応
+<!-- /manual -->
+
+<!-- changelog-update: tool=dev -->
+
+## [v1.0.0] - 2025-01-01
+
+- A
+`
+	if got != want {
+		t.Errorf("updatedChangelogContent() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Regenerating again keeps each region once
+	if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	again, err := updatedChangelogContent(path, entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != want {
+		t.Errorf("second regeneration =\n%s", again)
+	}
+}
+
+func TestManualRegionsUnclosed(t *testing.T) {
+	regions := manualRegions([]string{"## [v1.0.0]", "", "### 追加", "", "- A", "<!-- manual -->", "- B", ""})
+	if len(regions) != 1 || regions[0].Section != "### 追加" || strings.Join(regions[0].Lines, "|") != "<!-- manual -->|- B" {
+		t.Errorf("manualRegions() = %+v", regions)
+	}
+}
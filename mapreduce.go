@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Defaults for the map phase of --map-reduce
+const (
+	defaultMapMaxFiles     = 40
+	defaultMapModel        = "haiku"
+	mapFileDiffTokenBudget = 6000
+)
+
+// fileSummarizer summarizes significant files individually before the final entry is composed; nil disables it
+var fileSummarizer *mapReducer
+
+// mapReducer runs the map phase of the map-reduce pipeline with a cheaper executor
+type mapReducer struct {
+	executor    AIExecutor
+	maxFiles    int
+	concurrency int
+}
+
+// newMapExecutor returns the executor of the map phase: the backend of model, so the mock backend
+// covers both phases, with Claude running mapModel
+func newMapExecutor(model, mapModel string) (AIExecutor, error) {
+	executor, err := newExecutor(model)
+	if err != nil {
+		return nil, err
+	}
+	if claude, ok := executor.(*ClaudeExecutor); ok {
+		claude.Model = mapModel
+	}
+	return executor, nil
+}
+
+// fileChange is a changed file with its changed line count from `git diff --numstat`
+type fileChange struct {
+	Path  string
+	Lines int
+}
+
+// parseNumstat parses `git diff --numstat` output, skipping binary files, largest changes first
+func parseNumstat(output string) []fileChange {
+	var files []fileChange
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			continue
+		}
+		added, errAdded := strconv.Atoi(fields[0])
+		deleted, errDeleted := strconv.Atoi(fields[1])
+		if errAdded != nil || errDeleted != nil {
+			continue
+		}
+		files = append(files, fileChange{Path: fields[len(fields)-1], Lines: added + deleted})
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Lines > files[j].Lines })
+	return files
+}
+
+// significantFiles returns the most changed files of the selected module in from..to that may be
+// sent to the AI, leaving out lockfiles and minified assets
+func (m *mapReducer) significantFiles(from, to string) ([]fileChange, error) {
+	output, err := gitCommand(append([]string{"diff", "--numstat", "--no-renames", diffBase(from), to}, modulePathspec()...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff stats: %w", err)
+	}
+	var files []fileChange
+	for _, f := range parseNumstat(string(output)) {
+		if len(files) == m.maxFiles {
+			break
+		}
+		// Lockfiles and minified assets are often the largest changes but say nothing about them
+		if aiFilter.allowed(f.Path) && bulkFileKind(f.Path) == "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// diffBase returns the revision to diff from, using the empty tree for an initial release
+func diffBase(from string) string {
+	if from == "" || from == gitRefHEAD {
+		return emptyTreeHash
+	}
+	return from
+}
+
+// summarizeFile asks the AI for a short user-facing summary of one file's diff
+func (m *mapReducer) summarizeFile(from, to string, file fileChange) (string, error) {
+	output, err := gitCommand("diff", diffBase(from), to, "--", file.Path).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff for %s: %w", file.Path, err)
+	}
+	patch := truncateLines(string(output), mapFileDiffTokenBudget)
+
+	prompt := fmt.Sprintf(`以下はファイル %s の差分です。この変更内容を、CHANGELOGの作成に使えるよう1〜3文の日本語で要約してください。
+
+差分:
+---
+%s
+---
+
+注意事項：
+- ユーザーから見た影響（新機能、挙動の変更、バグ修正など）を優先して記載してください
+- 前置きや説明文は一切含めず、要約文のみを出力してください`, file.Path, patch)
+
+	summary, err := m.executor.Execute(prompt)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(summary), " "), nil
+}
+
+// summarize runs the map phase for from..to and returns one "- path: summary" line per significant file
+func (m *mapReducer) summarize(from, to string) (string, error) {
+	files, err := m.significantFiles(from, to)
+	if err != nil {
+		return "", err
+	}
+
+	summaries := make([]string, len(files))
+	errs := make([]error, len(files))
+	sem := make(chan struct{}, max(m.concurrency, 1))
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file fileChange) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			summaries[i], errs[i] = m.summarizeFile(from, to, file)
+		}(i, file)
+	}
+	wg.Wait()
+
+	var lines []string
+	for i, file := range files {
+		if errs[i] != nil {
+			ui.Printf("⚠️  Warning: Failed to summarize %s: %v\n", file.Path, errs[i])
+			continue
+		}
+		if summaries[i] != "" {
+			lines = append(lines, fmt.Sprintf("- %s: %s", file.Path, summaries[i]))
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// changeSummaries returns the per-file summaries for from..to when --map-reduce is enabled
+func changeSummaries(from, to string) string {
+	if fileSummarizer == nil {
+		return ""
+	}
+	summaries, err := fileSummarizer.summarize(from, to)
+	if err != nil {
+		ui.Printf("⚠️  Warning: Failed to summarize files, continuing without summaries: %v\n", err)
+		return ""
+	}
+	return summaries
+}
+
+// fileSummarySection formats per-file summaries for the final generation prompt
+func fileSummarySection(summaries string) string {
+	if summaries == "" {
+		return ""
+	}
+	return fmt.Sprintf(`
+
+変更量の多いファイルごとの変更要約（エントリー作成の根拠として使用してください）:
+---
+%s
+---`, summaries)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseNumstat(t *testing.T) {
+	output := "10\t2\tmain.go\n-\t-\tlogo.png\n300\t0\tinternal/big.go\n1\t1\told.go => new.go\n"
+	want := []fileChange{
+		{Path: "internal/big.go", Lines: 300},
+		{Path: "main.go", Lines: 12},
+		{Path: "old.go => new.go", Lines: 2},
+	}
+	if got := parseNumstat(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseNumstat() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewMapExecutor(t *testing.T) {
+	executor, err := newMapExecutor("mock", defaultMapModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := executor.(*CannedExecutor); !ok {
+		t.Errorf("newMapExecutor(mock) = %T, want the mock backend", executor)
+	}
+	executor, err = newMapExecutor("claude", defaultMapModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claude, ok := executor.(*ClaudeExecutor); !ok || claude.Model != defaultMapModel {
+		t.Errorf("newMapExecutor(claude) = %#v, want Claude with %s", executor, defaultMapModel)
+	}
+}
+
+func TestFileSummarySection(t *testing.T) {
+	if got := fileSummarySection(""); got != "" {
+		t.Errorf("fileSummarySection(\"\") = %q, want empty", got)
+	}
+	got := fileSummarySection("- main.go: フラグを追加")
+	if !strings.HasPrefix(got, "\n\n") || !strings.Contains(got, "- main.go: フラグを追加") {
+		t.Errorf("fileSummarySection() = %q", got)
+	}
+}
+
+func TestChangeSummariesDisabled(t *testing.T) {
+	original := fileSummarizer
+	defer func() { fileSummarizer = original }()
+
+	fileSummarizer = nil
+	if got := changeSummaries("v1.0.0", gitRefHEAD); got != "" {
+		t.Errorf("changeSummaries() without --map-reduce = %q, want empty", got)
+	}
+}
+
+func TestGenerateChangelogEntryIncludesSummaries(t *testing.T) {
+	mock := &MockExecutor{response: "## [v1.1.0] - 2025-09-01"}
+	if _, err := generateChangelogEntry(mock, "", "v1.1.0", "M\tmain.go", "abc1234 feat: x", "", "- main.go: 新しいフラグを追加", false); err != nil {
+		t.Fatalf("generateChangelogEntry() error = %v", err)
+	}
+	if !strings.Contains(mock.prompts[0], "- main.go: 新しいフラグを追加") {
+		t.Error("prompt should include the per-file summaries")
+	}
+}
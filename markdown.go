@@ -0,0 +1,111 @@
+package main
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlHeadingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	htmlCodePattern    = regexp.MustCompile("`([^`]+)`")
+	htmlBoldPattern    = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	htmlItalicPattern  = regexp.MustCompile(`(^|[^\w])_([^_]+)_([^\w]|$)`)
+	htmlLinkPattern    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// markdownToHTML converts the Markdown subset used in changelog entries (headings, nested lists,
+// blockquotes, code fences, paragraphs and inline formatting) to HTML
+func markdownToHTML(markdown string) string {
+	var b strings.Builder
+	var listIndents []int
+	var paragraph []string
+	inCode := false
+
+	closeParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + strings.Join(paragraph, " ") + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeLists := func(indent int) {
+		for len(listIndents) > 0 && listIndents[len(listIndents)-1] >= indent {
+			b.WriteString("</li>\n</ul>\n")
+			listIndents = listIndents[:len(listIndents)-1]
+		}
+	}
+
+	for _, line := range strings.Split(normalizeNewlines(markdown), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			closeParagraph()
+			if inCode {
+				b.WriteString("</code></pre>\n")
+			} else {
+				closeLists(0)
+				b.WriteString("<pre><code>")
+			}
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			b.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		if m := listItemPattern.FindStringSubmatch(line); m != nil {
+			closeParagraph()
+			indent := len(m[1])
+			switch {
+			case len(listIndents) == 0 || indent > listIndents[len(listIndents)-1]:
+				b.WriteString("<ul>\n")
+				listIndents = append(listIndents, indent)
+			default:
+				closeLists(indent + 1)
+				b.WriteString("</li>\n")
+			}
+			b.WriteString("<li>" + inlineHTML(m[2]))
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			closeParagraph()
+		case strings.HasPrefix(trimmed, "<!--") && strings.HasSuffix(trimmed, "-->"):
+			// Comments such as the generation metadata are not rendered
+			closeParagraph()
+		case htmlHeadingPattern.MatchString(trimmed):
+			closeParagraph()
+			closeLists(0)
+			m := htmlHeadingPattern.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(m[1])))
+			b.WriteString("<h" + level + ">" + inlineHTML(m[2]) + "</h" + level + ">\n")
+		case strings.HasPrefix(trimmed, ">"):
+			closeParagraph()
+			closeLists(0)
+			b.WriteString("<blockquote><p>" + inlineHTML(strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))) + "</p></blockquote>\n")
+		case len(listIndents) > 0 && strings.HasPrefix(line, " "):
+			b.WriteString(" " + inlineHTML(trimmed))
+		default:
+			closeLists(0)
+			paragraph = append(paragraph, inlineHTML(trimmed))
+		}
+	}
+
+	if inCode {
+		b.WriteString("</code></pre>\n")
+	}
+	closeParagraph()
+	closeLists(0)
+	return b.String()
+}
+
+// inlineHTML escapes text and converts inline code, bold, italic and links
+func inlineHTML(text string) string {
+	text = html.EscapeString(text)
+	text = htmlCodePattern.ReplaceAllString(text, "<code>$1</code>")
+	text = htmlBoldPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = htmlItalicPattern.ReplaceAllString(text, "$1<em>$2</em>$3")
+	return htmlLinkPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "sections and lists",
+			markdown: "### 追加\n\n- **新機能** `--flag` を追加\n- <script> はエスケープ",
+			want:     "<h3>追加</h3>\n<ul>\n<li><strong>新機能</strong> <code>--flag</code> を追加</li>\n<li>&lt;script&gt; はエスケープ</li>\n</ul>\n",
+		},
+		{
+			name:     "nested list",
+			markdown: "- 親\n  - 子\n- 次",
+			want:     "<ul>\n<li>親<ul>\n<li>子</li>\n</ul>\n</li>\n<li>次</li>\n</ul>\n",
+		},
+		{
+			name:     "link, quote and paragraph",
+			markdown: "> 注意\n\n詳細は [README](https://example.com/?a=1&b=2) を参照\n\n_12 commits_",
+			want:     "<blockquote><p>注意</p></blockquote>\n<p>詳細は <a href=\"https://example.com/?a=1&amp;b=2\">README</a> を参照</p>\n<p><em>12 commits</em></p>\n",
+		},
+		{
+			name:     "code fence",
+			markdown: "```\n<b>x</b>\n```",
+			want:     "<pre><code>&lt;b&gt;x&lt;/b&gt;\n</code></pre>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownToHTML(tt.markdown); got != tt.want {
+				t.Errorf("markdownToHTML() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import "strings"
+
+// markdownBlock classifies a line for the blank-line rules applied by lintMarkdown
+type markdownBlock int
+
+const (
+	blockBlank markdownBlock = iota
+	blockHeading
+	blockList
+	blockFence
+	blockText
+)
+
+// lintMarkdown rewrites an entry to satisfy common markdownlint rules: blank lines around headings (MD022),
+// lists (MD032) and code fences (MD031), no consecutive blank lines (MD012) and no trailing spaces (MD009)
+func lintMarkdown(entry string) string {
+	var out []string
+	prev := blockBlank
+	inFence := false
+
+	lastBlank := func() bool { return len(out) == 0 || out[len(out)-1] == "" }
+
+	for _, line := range strings.Split(normalizeNewlines(entry), "\n") {
+		if inFence {
+			out = append(out, line)
+			if strings.HasPrefix(strings.TrimSpace(line), "```") {
+				inFence = false
+			}
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t")
+		kind := classifyMarkdownLine(line, prev)
+		if kind == blockBlank {
+			if !lastBlank() {
+				out = append(out, "")
+			}
+			prev = blockBlank
+			continue
+		}
+
+		if !lastBlank() && needsBlankLine(prev, kind) {
+			out = append(out, "")
+		}
+		out = append(out, line)
+		if kind == blockFence {
+			inFence = true
+		}
+		prev = kind
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// classifyMarkdownLine returns the block a line belongs to; indented lines continue a preceding list
+func classifyMarkdownLine(line string, prev markdownBlock) markdownBlock {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return blockBlank
+	case strings.HasPrefix(trimmed, "```"):
+		return blockFence
+	case chatHeadingPattern.MatchString(line):
+		return blockHeading
+	case listItemPattern.MatchString(line):
+		return blockList
+	case prev == blockList && line != trimmed:
+		return blockList
+	}
+	return blockText
+}
+
+// needsBlankLine reports whether a blank line is required between consecutive blocks
+func needsBlankLine(prev, next markdownBlock) bool {
+	switch {
+	case prev == blockBlank:
+		return false
+	case prev == blockHeading, next == blockHeading, prev == blockFence, next == blockFence:
+		return true
+	}
+	return (prev == blockList) != (next == blockList)
+}
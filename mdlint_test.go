@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestLintMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+		want  string
+	}{
+		{
+			name:  "blank lines around headings and lists",
+			entry: "## [v1.0.0] - 2025-09-01\n### 追加\n- A  \n  続き\n- B\n### 修正\n- C\n_1 commit_",
+			want:  "## [v1.0.0] - 2025-09-01\n\n### 追加\n\n- A\n  続き\n- B\n\n### 修正\n\n- C\n\n_1 commit_",
+		},
+		{
+			name:  "multiple blank lines",
+			entry: "\n\n## [v1.0.0]\n\n\n\n### 追加\n\n\n- A\n\n",
+			want:  "## [v1.0.0]\n\n### 追加\n\n- A",
+		},
+		{
+			name:  "code fences are kept verbatim",
+			entry: "### 変更\n- A\n```\n\n\nx  \n```\nB",
+			want:  "### 変更\n\n- A\n\n```\n\n\nx  \n```\n\nB",
+		},
+		{
+			name:  "already compliant entry",
+			entry: "## [v1.0.0]\n\n### 追加\n\n- A\n  - nested",
+			want:  "## [v1.0.0]\n\n### 追加\n\n- A\n  - nested",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lintMarkdown(tt.entry); got != tt.want {
+				t.Errorf("lintMarkdown() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
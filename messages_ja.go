@@ -0,0 +1,207 @@
+package main
+
+// jaMessages is the Japanese catalog of the status messages, spinner labels and questions
+var jaMessages = map[string]string{
+	// Generation
+	"🚀 Starting CHANGELOG update process using %s...\n":                        "🚀 %s で CHANGELOG の更新を開始します...\n",
+	"📥 Fetching latest tags from remote...\n":                                  "📥 リモートから最新のタグを取得しています...\n",
+	"📥 Using the commits and diff read from stdin...\n":                        "📥 標準入力から読み込んだコミットと差分を使用します...\n",
+	"📥 Shallow clone detected, fetching the full history and tags...\n":        "📥 shallow clone を検出しました。全履歴とタグを取得しています...\n",
+	"ℹ️  HEAD is detached at %s; changes are read up to this commit.\n":        "ℹ️  HEAD は %s で detached 状態です。このコミットまでの変更を読み込みます。\n",
+	"ℹ️  No remote configured, using local tags only.\n":                       "ℹ️  リモートが設定されていないため、ローカルのタグのみを使用します。\n",
+	"ℹ️  No remote tracking configured, using local tags only.\n":              "ℹ️  リモート追跡が設定されていないため、ローカルのタグのみを使用します。\n",
+	"ℹ️  Expanding the first %d of %d squash-merged pull requests.\n":          "ℹ️  squash マージされたプルリクエスト %[2]d 件のうち、最初の %[1]d 件を展開します。\n",
+	"⚠️  Tag %s already exists. Generating CHANGELOG from previous tag.\n":     "⚠️  タグ %s はすでに存在します。前のタグから CHANGELOG を生成します。\n",
+	"📌 Using previous tag: %s\n":                                               "📌 前のタグを使用します: %s\n",
+	"📌 Previous tag: %s\n":                                                     "📌 前のタグ: %s\n",
+	"📌 This is the first tag, treating as initial release.\n":                  "📌 最初のタグのため、初回リリースとして扱います。\n",
+	"📌 No previous tags found. This will be the first release.\n":              "📌 前のタグが見つかりません。これが最初のリリースになります。\n",
+	"📊 Analyzing initial release...\n":                                         "📊 初回リリースを分析しています...\n",
+	"📝 No commits found. Will generate CHANGELOG based on staged changes...\n": "📝 コミットが見つかりません。ステージされた変更から CHANGELOG を生成します...\n",
+	"📝 Including uncommitted changes (%s) in CHANGELOG...\n":                   "📝 コミットされていない変更（%s）を CHANGELOG に含めます...\n",
+	"📌 Writing the entry as the initial release (--initial-release).\n":        "📌 初回リリースとしてエントリーを作成します（--initial-release）。\n",
+	"📌 Writing a regular entry for the changes (--initial-release=false).\n":   "📌 通常のエントリーとして変更を記載します（--initial-release=false）。\n",
+	"📌 Writing the entry as the initial release because no earlier tag exists (use --initial-release=false to describe it as regular changes).\n": "📌 以前のタグがないため、初回リリースとしてエントリーを作成します（通常の変更として記載するには --initial-release=false を指定してください）。\n",
+	"📌 Writing a regular entry for the changes since %s (use --initial-release to describe the whole project).\n":                                 "📌 %s 以降の変更を通常のエントリーとして記載します（プロジェクト全体を説明するには --initial-release を指定してください）。\n",
+	"✅ No changes since last tag and no uncommitted changes. Nothing to do.\n":                                                                    "✅ 前回のタグ以降の変更もコミットされていない変更もありません。何もすることはありません。\n",
+	"✅ %s already has an entry for %s at the tagged commit; already up to date (use --force to regenerate).\n":                                    "✅ %s にはタグ付けされたコミット時点の %s のエントリーがすでにあり、最新の状態です（再生成するには --force を指定してください）。\n",
+	"Generating entry for %s (%s)":                                                      "%s のエントリーを生成しています（%s）",
+	"Generating entry for %s (%s..%s)":                                                  "%s のエントリーを生成しています（%s..%s）",
+	"Regenerating entry for %s (%s..%s)":                                                "%s のエントリーを再生成しています（%s..%s）",
+	"Generating upgrade guide for %s":                                                   "%s のアップグレードガイドを生成しています",
+	"Verifying entry for %s":                                                            "%s のエントリーを検証しています",
+	"⏳ Still working on %s (%s elapsed)\n":                                              "⏳ %s の処理を続けています（%s 経過）\n",
+	"⏱️  %s took %s (~%d prompt tokens, ~%d response tokens)\n":                         "⏱️  %s に %s かかりました（プロンプト約 %d トークン、応答約 %d トークン）\n",
+	"✂️  Prompt trimmed from ~%d to ~%d tokens to fit --max-prompt-tokens %d\n":         "✂️  --max-prompt-tokens %[3]d に収めるため、プロンプトを約 %[1]d トークンから約 %[2]d トークンに削減しました\n",
+	"\n⏹️ Canceled: the prompt was not approved.\n":                                     "\n⏹️ キャンセルしました: プロンプトが承認されませんでした。\n",
+	"❌ Error: Failed to generate changelog entry: %v\n":                                 "❌ エラー: CHANGELOG エントリーの生成に失敗しました: %v\n",
+	"❌ Error: Generated changelog entry is empty\n":                                     "❌ エラー: 生成された CHANGELOG エントリーが空です\n",
+	"🔧 Generated entry for %s has %d format problem(s), asking the AI to fix them...\n": "🔧 %s の生成エントリーに %d 件の書式の問題があるため、AI に修正を依頼しています...\n",
+	"⚠️  Warning: Generated entry for %s still has format problems: %s\n":               "⚠️  警告: %s の生成エントリーにはまだ書式の問題があります: %s\n",
+	"ℹ️  No breaking changes detected, skipping upgrade guide.\n":                       "ℹ️  破壊的変更が検出されなかったため、アップグレードガイドを省略します。\n",
+	"\n📝 Generated CHANGELOG Entry:\n":                                                  "\n📝 生成された CHANGELOG エントリー:\n",
+	"\n📘 Generated %s Section:\n":                                                       "\n📘 生成された %s セクション:\n",
+	"\n⚠️  Verification found possible problems:\n":                                     "\n⚠️  検証で問題の可能性が見つかりました:\n",
+	"\n✅ Verification found no problems.\n":                                             "\n✅ 検証で問題は見つかりませんでした。\n",
+	"⚠️  Warning: Verification failed: %v\n":                                            "⚠️  警告: 検証に失敗しました: %v\n",
+	"\n⚠️  The entry may need attention (%d warning(s)):\n":                             "\n⚠️  エントリーに確認が必要な点があります（警告 %d 件）:\n",
+	"\n⚠️  %d item(s) look like changes that were already released:\n":                  "\n⚠️  %d 件の項目がリリース済みの変更と重複しているようです:\n",
+	"  - %s\n    ≈ %s: %s (%.0f%% similar)\n":                                           "  - %s\n    ≈ %s: %s（類似度 %.0f%%）\n",
+	"ℹ️  Keeping them (--yes flag); review the entry before releasing.\n":               "ℹ️  これらの項目を残します（--yes フラグ）。リリース前にエントリーを確認してください。\n",
+	"Drop these items from the entry? [y/N]: ":                                          "これらの項目をエントリーから削除しますか？ [y/N]: ",
+	"\n📝 Updated CHANGELOG Entry:\n":                                                    "\n📝 更新後の CHANGELOG エントリー:\n",
+
+	// Prompts and questions
+	"\n🔎 Prompt to be sent to the AI:\n":                  "\n🔎 AI に送信するプロンプト:\n",
+	"📏 Prompt size: %d bytes (~%d tokens)\n":              "📏 プロンプトのサイズ: %d バイト（約 %d トークン）\n",
+	"Send this prompt? [y/N]: ":                           "このプロンプトを送信しますか？ [y/N]: ",
+	"%s%s (--%s flag)\n":                                  "%s%s（--%s フラグ）\n",
+	"\nDo you want to update %s with this entry? [y/N]: ": "\nこのエントリーで %s を更新しますか？ [y/N]: ",
+	"\nAdd the entry for %s? [a]ccept / [e]dit / [s]kip / [r]egenerate (default: skip): ": "\n%s のエントリーを追加しますか？ [a]承認 / [e]編集 / [s]スキップ / [r]再生成（デフォルト: スキップ）: ",
+	"❓ Unknown answer %q\n": "❓ 不明な回答です: %q\n",
+	"\n📝 Entry for %s:\n":   "\n📝 %s のエントリー:\n",
+	"⏭️  Skipped %s\n":      "⏭️  %s をスキップしました\n",
+	"⚠️  Warning: The edited entry is empty; keeping the previous one.\n": "⚠️  警告: 編集後のエントリーが空のため、以前のエントリーを残します。\n",
+
+	// Writing the changelog
+	"📝 Found existing entry for version %s, replacing it...\n":       "📝 バージョン %s の既存のエントリーが見つかったため、置き換えます...\n",
+	"📌 Kept %d manual region(s) of %s\n":                             "📌 %[2]s の手動で追記した領域を %[1]d 件残しました\n",
+	"\n✅ %s updated successfully!\n":                                 "\n✅ %s を更新しました！\n",
+	"✅ %s updated successfully!\n":                                   "✅ %s を更新しました！\n",
+	"✅ %s updated\n":                                                 "✅ %s を更新しました\n",
+	"✅ %s updated with the entry for %s from %s\n":                   "✅ %[3]s から %[2]s のエントリーで %[1]s を更新しました\n",
+	"\n❌ Update failed: %v\n":                                        "\n❌ 更新に失敗しました: %v\n",
+	"⚠️  Warning: Failed to update %s: %v\n":                         "⚠️  警告: %s の更新に失敗しました: %v\n",
+	"\n⏹️ Update canceled.\n":                                        "\n⏹️ 更新をキャンセルしました。\n",
+	"⚠️  Warning: Failed to write summary: %v\n":                     "⚠️  警告: 実行結果のサマリーの書き込みに失敗しました: %v\n",
+	"📣 Notification sent\n":                                          "📣 通知を送信しました\n",
+	"⚠️  Warning: Failed to send notification: %v\n":                 "⚠️  警告: 通知の送信に失敗しました: %v\n",
+	"⚠️  Warning: Failed to publish Jira release: %v\n":              "⚠️  警告: Jira リリースの公開に失敗しました: %v\n",
+	"✅ Published Jira release %s\n":                                  "✅ Jira リリース %s を公開しました\n",
+	"⚠️  Warning: Failed to link %s to %s: %v\n":                     "⚠️  警告: %s を %s に関連付けられませんでした: %v\n",
+	"⚠️  Warning: Failed to update package.json: %v\n":               "⚠️  警告: package.json の更新に失敗しました: %v\n",
+	"📦 Adding version to package.json...\n":                          "📦 package.json にバージョンを追加しています...\n",
+	"📦 Updating package.json version from %s to %s...\n":             "📦 package.json のバージョンを %s から %s に更新しています...\n",
+	"✅ package.json version updated successfully!\n":                 "✅ package.json のバージョンを更新しました！\n",
+	"⚠️  Warning: Failed to tag %s: %v\n":                            "⚠️  警告: タグ %s の作成に失敗しました: %v\n",
+	"🏷️  Committed the changelog and created tag %s\n":               "🏷️  CHANGELOG をコミットし、タグ %s を作成しました\n",
+	"🏷️  Updated the annotation of tag %s\n":                         "🏷️  タグ %s の注釈を更新しました\n",
+	"ℹ️  Push the updated annotation with: git push --force %s %s\n": "ℹ️  更新した注釈は次のコマンドでプッシュしてください: git push --force %s %s\n",
+	"ℹ️  Using prompt version %d recorded in %s; pass --prompt-version %d to switch to the current prompts.\n": "ℹ️  %[2]s に記録されたプロンプトのバージョン %[1]d を使用します。現在のプロンプトに切り替えるには --prompt-version %[3]d を指定してください。\n",
+	"📌 Next steps:\n":              "📌 次のステップ:\n",
+	"Review and edit %s if needed": "必要に応じて %s を確認・編集する",
+
+	// Catch-up
+	"🔍 Checking for missing tags in CHANGELOG...\n":                              "🔍 CHANGELOG に記載されていないタグを確認しています...\n",
+	"❓ No tags found in repository.\n":                                           "❓ リポジトリにタグが見つかりません。\n",
+	"✅ All tags are already in CHANGELOG.md\n":                                   "✅ すべてのタグがすでに CHANGELOG.md に記載されています\n",
+	"📌 Found %d missing tag(s):\n":                                               "📌 記載されていないタグが %d 件見つかりました:\n",
+	"\n👀 Previewing the missing entries (--no flag); nothing will be written.\n": "\n👀 不足しているエントリーをプレビューします（--no フラグ）。何も書き込みません。\n",
+	"\nDo you want to add these missing entries? [y/N]: ":                        "\nこれらの不足しているエントリーを追加しますか？ [y/N]: ",
+	"⏹️ Catch-up canceled.\n":                                                    "⏹️ catch-up をキャンセルしました。\n",
+	"❌ No entries could be generated.\n":                                         "❌ エントリーを1件も生成できませんでした。\n",
+	"\n⏹️ Update canceled: no entry was accepted.\n":                             "\n⏹️ 更新をキャンセルしました: 承認されたエントリーがありません。\n",
+	"❌ Error during catch-up: %v\n":                                              "❌ catch-up 中にエラーが発生しました: %v\n",
+
+	// Warnings from repository inspection
+	"⚠️  Warning: %v\n":                                                          "⚠️  警告: %v\n",
+	"⚠️  Warning: Failed to pull tags: %v\n":                                     "⚠️  警告: タグの取得に失敗しました: %v\n",
+	"⚠️  Warning: Failed to get uncommitted changes: %v\n":                       "⚠️  警告: コミットされていない変更の取得に失敗しました: %v\n",
+	"⚠️ Warning: Failed to get uncommitted changes: %v\n":                        "⚠️ 警告: コミットされていない変更の取得に失敗しました: %v\n",
+	"⚠️  Warning: Failed to generate upgrade guide: %v\n":                        "⚠️  警告: アップグレードガイドの生成に失敗しました: %v\n",
+	"⚠️  Warning: Failed to compute release stats: %v\n":                         "⚠️  警告: リリースの統計の計算に失敗しました: %v\n",
+	"⚠️  Warning: Failed to detect dependency updates: %v\n":                     "⚠️  警告: 依存関係の更新の検出に失敗しました: %v\n",
+	"⚠️  Warning: Failed to read commit messages for advisories: %v\n":           "⚠️  警告: セキュリティアドバイザリ用のコミットメッセージの読み込みに失敗しました: %v\n",
+	"⚠️  Warning: Failed to summarize %s: %v\n":                                  "⚠️  警告: %s の要約に失敗しました: %v\n",
+	"⚠️  Warning: Failed to summarize files, continuing without summaries: %v\n": "⚠️  警告: ファイルの要約に失敗したため、要約なしで続行します: %v\n",
+	"⚠️  Warning: Failed to unshallow the repository: %v\n":                      "⚠️  警告: リポジトリの unshallow に失敗しました: %v\n",
+	"⚠️  Warning: Credential helper failed for %s: %v\n":                         "⚠️  警告: %s の認証情報ヘルパーが失敗しました: %v\n",
+	"⚠️  Warning: This is a shallow clone, so the previous tag and older commits may be missing. Run git fetch --unshallow --tags first.\n": "⚠️  警告: shallow clone のため、前のタグや古いコミットが欠けている可能性があります。先に git fetch --unshallow --tags を実行してください。\n",
+	"⚠️  Warning: On branch %s, not the default branch %s; the entry describes this branch since the latest tag.\n":                         "⚠️  警告: デフォルトブランチ %[2]s ではなくブランチ %[1]s にいます。エントリーには最新のタグ以降のこのブランチの変更が記載されます。\n",
+	"⚠️  Warning: %d file(s) with uncommitted changes are not included in the entry: %s\n":                                                  "⚠️  警告: コミットされていない変更のある %d 件のファイルはエントリーに含まれません: %s\n",
+
+	// Errors
+	"❌ Error: %v\n": "❌ エラー: %v\n",
+	"❌ Error: --repo cannot be used with -C\n":                                      "❌ エラー: --repo は -C と同時に使用できません\n",
+	"❌ Error: --repo cannot be used with --stdin, --create-tag or --annotate-tag\n": "❌ エラー: --repo は --stdin、--create-tag、--annotate-tag と同時に使用できません\n",
+	"❌ Error: --push-branch requires --repo\n":                                      "❌ エラー: --push-branch には --repo が必要です\n",
+	"❌ Error: --stdout cannot be used with --catch-up, --hook-mode, --entry-file, --print-prompt, --upgrade-guide, --create-tag or --annotate-tag\n": "❌ エラー: --stdout は --catch-up、--hook-mode、--entry-file、--print-prompt、--upgrade-guide、--create-tag、--annotate-tag と同時に使用できません\n",
+	"❌ Error: --split-only requires --split-dir\n":                                                                                       "❌ エラー: --split-only には --split-dir が必要です\n",
+	"❌ Error: --version-style must be %q or %q\n":                                                                                        "❌ エラー: --version-style には %q または %q を指定してください\n",
+	"❌ Error: --group-by must be %q or %q\n":                                                                                             "❌ エラー: --group-by には %q または %q を指定してください\n",
+	"❌ Error: --audience must be %q, %q or %q\n":                                                                                         "❌ エラー: --audience には %q、%q、%q のいずれかを指定してください\n",
+	"❌ Error: --tone must be %q or %q\n":                                                                                                 "❌ エラー: --tone には %q または %q を指定してください\n",
+	"❌ Error: --detail must be %q, %q or %q\n":                                                                                           "❌ エラー: --detail には %q、%q、%q のいずれかを指定してください\n",
+	"❌ Error: --max-items-per-section must not be negative\n":                                                                            "❌ エラー: --max-items-per-section に負の値は指定できません\n",
+	"❌ Error: --bot-commits must be %q, %q or %q\n":                                                                                      "❌ エラー: --bot-commits には %q、%q、%q のいずれかを指定してください\n",
+	"❌ Error: --yes cannot be used with --no\n":                                                                                          "❌ エラー: --yes は --no と同時に使用できません\n",
+	"❌ Error: --verify-signatures must be %q or %q\n":                                                                                    "❌ エラー: --verify-signatures には %q または %q を指定してください\n",
+	"❌ Error: --record and --replay cannot be used together\n":                                                                           "❌ エラー: --record と --replay は同時に使用できません\n",
+	"❌ Error: --jira-release requires a \"jira\" section in the configuration file\n":                                                    "❌ エラー: --jira-release には設定ファイルの \"jira\" セクションが必要です\n",
+	"❌ Error: --hook-mode cannot be used with --tag, --catch-up, --entry-file, --stdin or --print-prompt\n":                              "❌ エラー: --hook-mode は --tag、--catch-up、--entry-file、--stdin、--print-prompt と同時に使用できません\n",
+	"❌ Error: --tag flag is required (or use --catch-up, or both)\n":                                                                     "❌ エラー: --tag フラグが必要です（または --catch-up、もしくは両方を指定してください）\n",
+	"❌ Error: --entry-file cannot be used with --catch-up, --stdin or --print-prompt\n":                                                  "❌ エラー: --entry-file は --catch-up、--stdin、--print-prompt と同時に使用できません\n",
+	"❌ Error: --print-prompt cannot be used with --catch-up, --map-reduce or --show-prompt\n":                                            "❌ エラー: --print-prompt は --catch-up、--map-reduce、--show-prompt と同時に使用できません\n",
+	"❌ Error: --stdin cannot be used with --catch-up, --upgrade-guide, --map-reduce or --verify-signatures, which read the repository\n": "❌ エラー: --stdin は、リポジトリを読み込む --catch-up、--upgrade-guide、--map-reduce、--verify-signatures と同時に使用できません\n",
+	"❌ Error: --stdin requires --yes or --no, because stdin is not available for answering prompts\n":                                    "❌ エラー: 標準入力は確認への回答に使用できないため、--stdin には --yes または --no が必要です\n",
+	"❌ Error: failed to create a temporary directory: %v\n":                                                                              "❌ エラー: 一時ディレクトリの作成に失敗しました: %v\n",
+	"❌ Error: failed to clone %s: %v\n":                                                                                                  "❌ エラー: %s のクローンに失敗しました: %v\n",
+	"❌ Error: failed to read the run summary: %v\n":                                                                                      "❌ エラー: 実行結果のサマリーの読み込みに失敗しました: %v\n",
+	"❌ Error: failed to parse the run summary: %v\n":                                                                                     "❌ エラー: 実行結果のサマリーの解析に失敗しました: %v\n",
+	"❌ Error: failed to push %s: %v\n":                                                                                                   "❌ エラー: %s のプッシュに失敗しました: %v\n",
+	"❌ Error: Failed to get all tags: %v\n":                                                                                              "❌ エラー: タグ一覧の取得に失敗しました: %v\n",
+	"📥 Cloning %s...\n":                "📥 %s をクローンしています...\n",
+	"🚀 Pushed %q to branch %s of %s\n": "🚀 %[3]s のブランチ %[2]s に %[1]q をプッシュしました\n",
+	"changelog-update version %s\n":    "changelog-update バージョン %s\n",
+
+	// Hook mode and validation
+	"ℹ️  No staged changes to describe.\n":                    "ℹ️  記載するステージされた変更はありません。\n",
+	"Describing %d staged file(s) in the Unreleased section":  "ステージされた %d 件のファイルを Unreleased セクションに記載しています",
+	"\n📝 Unreleased section of %s:\n":                         "\n📝 %s の Unreleased セクション:\n",
+	"✅ %s is staged and has an Unreleased section\n":          "✅ %s はステージされており、Unreleased セクションがあります\n",
+	"✅ %s follows --spec %s\n":                                "✅ %s は --spec %s に準拠しています\n",
+	"✅ %s has an entry for %s\n":                              "✅ %s には %s のエントリーがあります\n",
+	"🔏 Verifying tag and commit signatures...\n":              "🔏 タグとコミットの署名を検証しています...\n",
+	"✅ All checked tags and commits have valid signatures.\n": "✅ 確認したすべてのタグとコミットの署名は有効です。\n",
+	"⚠️  %d signature problem(s):\n":                          "⚠️  署名の問題が %d 件あります:\n",
+	"✅ All %d commit(s) in %s follow Conventional Commits\n":  "✅ %[2]s の %[1]d 件のコミットはすべて Conventional Commits に準拠しています\n",
+	"Suggesting messages for %d commit(s)":                    "%d 件のコミットのメッセージを提案しています",
+	"⚠️  Warning: Failed to get suggestions: %v\n":            "⚠️  警告: 提案の取得に失敗しました: %v\n",
+	"\n✅ Everything looks good.\n":                            "\n✅ 問題は見つかりませんでした。\n",
+
+	// Subcommands
+	"✅ No entries after %s\n":                                            "✅ %s 以降のエントリーはありません\n",
+	"Summarizing %d release(s)":                                          "%d 件のリリースを要約しています",
+	"✅ Wrote the summary of %d release(s) to %s\n":                       "✅ %d 件のリリースの要約を %s に書き込みました\n",
+	"✅ Wrote the changes in %d release(s) since %s to %s\n":              "✅ %[2]s 以降の %[1]d 件のリリースの変更を %[3]s に書き込みました\n",
+	"✅ No commits on %s in %s\n":                                         "✅ %[2]s の期間に %[1]s へのコミットはありません\n",
+	"Summarizing %d commit(s) in %s":                                     "%[2]s の %[1]d 件のコミットを要約しています",
+	"✅ Wrote the progress report for %s to %s\n":                         "✅ %s の進捗レポートを %s に書き込みました\n",
+	"✅ %s has no changes that are not in %s\n":                           "✅ %s には %s にない変更はありません\n",
+	"Generating release notes for %s":                                    "%s のリリースノートを生成しています",
+	"✅ Wrote release notes for %s to %s\n":                               "✅ %s のリリースノートを %s に書き込みました\n",
+	"Composing release notes for %d service(s)":                          "%d 件のサービスのリリースノートを作成しています",
+	"✅ Wrote release notes for %d service(s) to %s\n":                    "✅ %d 件のサービスのリリースノートを %s に書き込みました\n",
+	"⚠️  Warning: %s has no released entries, skipping.\n":               "⚠️  警告: %s にはリリース済みのエントリーがないため、スキップします。\n",
+	"Generating changelog entry for #%d":                                 "#%d の CHANGELOG エントリーを生成しています",
+	"✅ Wrote the changelog entry for #%d to %s\n":                        "✅ #%d の CHANGELOG エントリーを %s に書き込みました\n",
+	"✅ Exported %d version(s) to %s\n":                                   "✅ %d 件のバージョンを %s にエクスポートしました\n",
+	"✅ All GitHub Releases are up to date with the changelog.\n":         "✅ すべての GitHub リリースは CHANGELOG と一致しています。\n",
+	"✅ Synced %d release(s).\n":                                          "✅ %d 件のリリースを同期しました。\n",
+	"📝 Would %s release %s\n":                                            "📝 リリース %[2]s を%[1]sします（ドライラン）\n",
+	"🔧 %s release %s...\n":                                               "🔧 リリース %[2]s を%[1]sしています...\n",
+	"update":                                                             "更新",
+	"create":                                                             "作成",
+	"Updating":                                                           "更新",
+	"Creating":                                                           "作成",
+	"👀 Watching for new tags every %s (%d existing tag(s) ignored)...\n": "👀 %s ごとに新しいタグを監視しています（既存のタグ %d 件は無視します）...\n",
+	"🔧 New tag %s detected, generating entry...\n":                       "🔧 新しいタグ %s を検出しました。エントリーを生成しています...\n",
+	"✅ Added %s to %s\n":                                                 "✅ %s を %s に追加しました\n",
+	"❌ Error processing %s: %v\n":                                        "❌ %s の処理中にエラーが発生しました: %v\n",
+	"⚠️  Warning: No webhook secret configured, deliveries will not be verified.\n": "⚠️  警告: Webhook のシークレットが設定されていないため、配信は検証されません。\n",
+	"🌐 Listening for tag webhooks on %s/webhook\n":                                  "🌐 %s/webhook でタグの Webhook を待ち受けています\n",
+	"📨 Queued %s from %s\n":                                                         "📨 %[2]s の %[1]s をキューに追加しました\n",
+	"🔧 Processing %s for %s...\n":                                                   "🔧 %[2]s の %[1]s を処理しています...\n",
+	"❌ Error processing %s from %s: %v\n":                                           "❌ %[2]s の %[1]s の処理中にエラーが発生しました: %[3]v\n",
+	"✅ Opened pull request for %s on %s\n":                                          "✅ %[2]s に %[1]s のプルリクエストを作成しました\n",
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// metadataCommentPrefix starts the HTML comment that records how an entry was generated
+const metadataCommentPrefix = "<!-- changelog-update:"
+
+// generationMetadata records how an entry was generated so it can be identified and reproduced later
+type generationMetadata struct {
+	ToolVersion   string
+	Model         string
+	PromptHash    string
+	PromptVersion int
+	Range         string
+	// Commit is the full hash of the commit at the end of Range when the entry was generated
+	Commit string
+}
+
+// comment renders the metadata as a single-line HTML comment, which Markdown renderers hide
+func (m generationMetadata) comment() string {
+	revRange := m.Range
+	if m.Commit != "" {
+		revRange += " commit=" + m.Commit
+	}
+	return fmt.Sprintf("%s tool=%s model=%s prompt=sha256:%s prompt-version=%d range=%s -->",
+		metadataCommentPrefix, m.ToolVersion, m.Model, m.PromptHash, m.PromptVersion, revRange)
+}
+
+// addGenerationMetadata appends the metadata comment under entry
+func addGenerationMetadata(entry string, m generationMetadata) string {
+	return strings.TrimRight(entry, "\n") + "\n\n" + m.comment()
+}
+
+// metadataRange formats the commit range of an entry; from is empty for an initial release
+func metadataRange(from, to string) string {
+	if from == "" {
+		return to
+	}
+	return from + ".." + to
+}
+
+// promptHash returns a short SHA-256 digest of prompt
+func promptHash(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// promptRecorder wraps an AIExecutor and remembers the digest of the first prompt, the
+// generation prompt; later prompts such as format repairs do not change it
+type promptRecorder struct {
+	AIExecutor
+	mu   sync.Mutex
+	hash string
+}
+
+// Execute records the first prompt and runs the wrapped executor
+func (r *promptRecorder) Execute(prompt string) (string, error) {
+	r.mu.Lock()
+	if r.hash == "" {
+		r.hash = promptHash(prompt)
+	}
+	r.mu.Unlock()
+	return r.AIExecutor.Execute(prompt)
+}
+
+// metadata returns the generation metadata for the recorded prompt and the commit range from..to
+func (r *promptRecorder) metadata(from, to string) generationMetadata {
+	commit, _ := gitOutput("rev-parse", "--verify", "--quiet", to+"^{commit}")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return generationMetadata{ToolVersion: version, Model: genOpts.Model, PromptHash: r.hash, PromptVersion: genOpts.PromptVersion,
+		Range: metadataRange(from, to), Commit: strings.TrimSpace(commit)}
+}
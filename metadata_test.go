@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddGenerationMetadata(t *testing.T) {
+	m := generationMetadata{ToolVersion: "1.4.0", Model: "claude", PromptHash: "0123456789abcdef", PromptVersion: 1, Range: "v1.1.0..v1.2.0", Commit: "0123abc"}
+	got := addGenerationMetadata("## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- 新機能\n", m)
+	want := "## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- 新機能\n\n" +
+		"<!-- changelog-update: tool=1.4.0 model=claude prompt=sha256:0123456789abcdef prompt-version=1 range=v1.1.0..v1.2.0 commit=0123abc -->"
+	if got != want {
+		t.Errorf("addGenerationMetadata() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestMetadataRange(t *testing.T) {
+	if got := metadataRange("", "v1.0.0"); got != "v1.0.0" {
+		t.Errorf("metadataRange() = %q", got)
+	}
+	if got := metadataRange("v1.0.0", "HEAD"); got != "v1.0.0..HEAD" {
+		t.Errorf("metadataRange() = %q", got)
+	}
+}
+
+func TestPromptRecorderKeepsFirstPrompt(t *testing.T) {
+	recorder := &promptRecorder{AIExecutor: &MockExecutor{response: "ok"}}
+	for _, prompt := range []string{"generate", "repair"} {
+		if _, err := recorder.Execute(prompt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := recorder.metadata("v1.0.0", "HEAD")
+	if m.PromptHash != promptHash("generate") || len(m.PromptHash) != 16 {
+		t.Errorf("PromptHash = %q, want the digest of the first prompt", m.PromptHash)
+	}
+	if m.ToolVersion != version || m.Range != "v1.0.0..HEAD" {
+		t.Errorf("metadata() = %+v", m)
+	}
+}
+
+func TestMarkdownToHTMLSkipsComments(t *testing.T) {
+	got := markdownToHTML("- item\n\n" + metadataCommentPrefix + " tool=dev -->")
+	if strings.Contains(got, "changelog-update") {
+		t.Errorf("markdownToHTML() rendered the comment: %q", got)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// mockModelPrefix selects the mock backend; "mock:<file>" returns the entry in file
+const mockModelPrefix = "mock"
+
+// defaultMockEntry is the entry body returned by the mock backend when no file is given
+const defaultMockEntry = `### 追加
+
+- モックのAIが返したサンプルの項目です`
+
+// CannedExecutor implements AIExecutor without any AI access by returning a fixed entry,
+// for demos, dry runs and integration tests
+type CannedExecutor struct {
+	// Entry is returned for every prompt; a placeholder version heading is added when it has none
+	Entry string
+}
+
+// newCannedExecutor returns a CannedExecutor for the mock model spec, "mock" or "mock:<file>"
+func newCannedExecutor(spec string) (*CannedExecutor, error) {
+	file, ok := strings.CutPrefix(spec, mockModelPrefix+":")
+	if !ok {
+		return &CannedExecutor{Entry: defaultMockEntry}, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, &ConfigError{Err: fmt.Errorf("cannot read mock entry: %w", err)}
+	}
+	return &CannedExecutor{Entry: normalizeNewlines(string(data))}, nil
+}
+
+// isMockModel reports whether model selects the mock backend
+func isMockModel(model string) bool {
+	return model == mockModelPrefix || strings.HasPrefix(model, mockModelPrefix+":")
+}
+
+// Execute returns the fixed entry; the placeholder heading is replaced with the expected one when
+// the response is cleaned
+func (e *CannedExecutor) Execute(prompt string) (string, error) {
+	entry := strings.TrimSpace(e.Entry)
+	if first, _, _ := strings.Cut(entry, "\n"); first != "" {
+		if _, ok := changelogHeading.Version(first); ok {
+			return entry, nil
+		}
+	}
+	return changelogHeading.Render("0.0.0", releaseDate()) + "\n\n" + entry, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMockModel(t *testing.T) {
+	executor, err := newExecutor("mock")
+	if err != nil {
+		t.Fatalf("newExecutor(mock) error = %v", err)
+	}
+	response, err := executor.Execute("prompt")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	heading := "## [v1.2.0] - 2025-09-01"
+	entry := cleanEntryResponse(response, heading)
+	if violations := entryViolations(entry, heading); len(violations) > 0 {
+		t.Errorf("mock entry %q has format problems: %v", entry, violations)
+	}
+}
+
+func TestMockModelFromFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "entry.md")
+	if err := os.WriteFile(file, []byte("## [v9.9.9] - 2025-01-01\r\n\r\n### 修正\r\n\r\n- ファイルの項目\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	executor, err := newExecutor("mock:" + file)
+	if err != nil {
+		t.Fatalf("newExecutor() error = %v", err)
+	}
+	got, _ := executor.Execute("prompt")
+	if want := "## [v9.9.9] - 2025-01-01\n\n### 修正\n\n- ファイルの項目"; got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+
+	_, err = newExecutor("mock:" + filepath.Join(t.TempDir(), "missing.md"))
+	if exitCode(err) != ExitConfig || !strings.Contains(err.Error(), "cannot read mock entry") {
+		t.Errorf("newExecutor() with a missing file error = %v, want a config error", err)
+	}
+}
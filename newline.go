@@ -0,0 +1,32 @@
+package main
+
+import "strings"
+
+const (
+	lf   = "\n"
+	crlf = "\r\n"
+)
+
+// detectLineEnding returns CRLF when content predominantly uses Windows line endings, otherwise LF
+func detectLineEnding(content string) string {
+	crlfCount := strings.Count(content, crlf)
+	lfCount := strings.Count(content, lf) - crlfCount
+	if crlfCount > 0 && crlfCount >= lfCount {
+		return crlf
+	}
+	return lf
+}
+
+// normalizeNewlines converts CRLF and lone CR line endings to LF
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, crlf, lf)
+	return strings.ReplaceAll(s, "\r", lf)
+}
+
+// applyLineEnding converts LF-normalized content to the given line ending
+func applyLineEnding(s, ending string) string {
+	if ending == lf {
+		return s
+	}
+	return strings.ReplaceAll(s, lf, ending)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDetectLineEnding(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{"# Changelog\n\n## [v1.0.0]\n", lf},
+		{"# Changelog\r\n\r\n## [v1.0.0]\r\n", crlf},
+		{"", lf},
+		{"single line", lf},
+	}
+
+	for _, tc := range testCases {
+		if got := detectLineEnding(tc.input); got != tc.want {
+			t.Errorf("detectLineEnding(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestUpdateChangelogPreservesCRLF(t *testing.T) {
+	tempFile := t.TempDir() + "/CHANGELOG.md"
+	existing := "# Changelog\r\n\r\n## [v0.9.0] - 2025-08-01\r\n\r\n### 追加\r\n\r\n- Old feature\r\n"
+	if err := os.WriteFile(tempFile, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writeChangelogOutputs(tempFile, "## [v1.0.0] - 2025-08-27\n\n### 追加\n\n- New feature"); err != nil {
+		t.Fatalf("writeChangelogOutputs() error = %v", err)
+	}
+
+	content, err := os.ReadFile(tempFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(content)
+	if strings.Count(text, "\n") != strings.Count(text, "\r\n") {
+		t.Errorf("updated changelog mixes line endings: %q", text)
+	}
+	if !strings.Contains(text, "## [v1.0.0] - 2025-08-27\r\n") || !strings.Contains(text, "- Old feature") {
+		t.Errorf("unexpected content: %q", text)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// notesCommand previews the release notes for the changes on one branch relative to another
+// without touching the changelog
+func notesCommand(args []string) error {
+	fs := flag.NewFlagSet("notes", flag.ContinueOnError)
+	from := fs.String("from", "origin/HEAD", "Branch or commit the release starts from, e.g. origin/main")
+	to := fs.String("to", "", "Branch or commit that will be released, e.g. origin/release-2.0")
+	model := fs.String("model", "claude", "AI model to use: claude, or mock[:file.md] for a canned entry without AI access")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update notes --from origin/main --to origin/release-2.0 [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Previews the release notes for the changes on --to since it diverged from --from.\n")
+		fmt.Fprintf(os.Stderr, "The changelog is not modified.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *to == "" {
+		fs.Usage()
+		return &ConfigError{Err: fmt.Errorf("--to is required")}
+	}
+
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		return err
+	}
+	aiFilter = newPathFilter(cfg.AIAllow, cfg.AIDeny)
+	if changelogHeading, err = newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat); err != nil {
+		return &ConfigError{Err: err}
+	}
+	if commitFilter, err = newCommitIgnoreFilter(nil, true); err != nil {
+		return &ConfigError{Err: err}
+	}
+	// The preview has no separately appended dependency section, so the AI has to mention updates itself
+	genOpts.DependencySection = false
+	genOpts.SecurityAdvisories = false
+
+	base, err := gitOutput("merge-base", *from, *to)
+	if err != nil {
+		return fmt.Errorf("cannot find where %s diverged from %s: %w", *to, *from, err)
+	}
+	base = strings.TrimSpace(base)
+	diff, err := getGitDiff(base, *to)
+	if err != nil {
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+	commits, err := getGitCommits(base, *to)
+	if err != nil {
+		return fmt.Errorf("failed to get commits: %w", err)
+	}
+	if strings.TrimSpace(commits) == "" {
+		ui.Printf("✅ %s has no changes that are not in %s\n", *to, *from)
+		return nil
+	}
+
+	executor, err := newExecutor(*model)
+	if err != nil {
+		return err
+	}
+	heading := changelogHeading.Render(*to, releaseDate())
+	spin := startSpinner(ui.Sprintf("Generating release notes for %s", *to), !ui.plain)
+	response, err := executor.Execute(notesPrompt(*from, *to, heading, diff, commits))
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to generate release notes: %w", err)
+	}
+	notes, err := validateEntry(executor, response, heading)
+	if err != nil {
+		return fmt.Errorf("failed to generate release notes: %w", err)
+	}
+
+	if *output == "" {
+		fmt.Println(notes)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(notes+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+	ui.Printf("✅ Wrote release notes for %s to %s\n", *to, *output)
+	return nil
+}
+
+// notesPrompt asks for a changelog-style summary of the changes that will ship from to
+func notesPrompt(from, to, heading, diff, commits string) string {
+	build := func(diff, commits, _ string) string {
+		return fmt.Sprintf(`以下は、リリースブランチ %s に含まれ、%s にはまだない変更です。このブランチからリリースした場合に含まれる内容を、Keep a Changelog形式のリリースノートとしてまとめてください。
+
+コミットメッセージ:
+---
+%s
+---
+
+差分情報:
+---
+%s
+---
+
+以下の見出しから開始してください:
+%s
+
+セクションは ### %s の順序で、該当する変更がある場合のみ記載してください。
+
+注意事項：
+- 各セクションヘッダーの後には必ず空行を入れてください
+- 各セクションの内容は箇条書き（- ）のみで記載してください
+- 前置きや説明文は一切含めないでください
+- 各項目は日本語で記述し、ユーザーにとって価値のある情報を具体的に記載してください
+- 技術的な詳細よりも、ユーザーへの影響を重視してください`, to, from, commits, diff, heading, strings.Join(changelogSections, " / ### "))
+	}
+	return fitPrompt(build, diff, commits, "", promptExtras("", "", diff, commits))
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNotesPrompt(t *testing.T) {
+	heading := changelogHeading.Render("origin/release-2.0", releaseDate())
+	got := notesPrompt("origin/main", "origin/release-2.0", heading, "M\tmain.go", "abc1234 feat: add export")
+	for _, want := range []string{"origin/release-2.0 に含まれ、origin/main にはまだない", heading, "abc1234 feat: add export", "M\tmain.go"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("notesPrompt() does not contain %q", want)
+		}
+	}
+}
+
+func TestNotesCommandRequiresTo(t *testing.T) {
+	var configErr *ConfigError
+	if err := notesCommand([]string{"--from", "origin/main"}); !errors.As(err, &configErr) {
+		t.Errorf("notesCommand() without --to = %v, want a ConfigError", err)
+	}
+}
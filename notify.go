@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// discordMessageLimit is the maximum length of a Discord message
+const discordMessageLimit = 2000
+
+var (
+	chatHeadingPattern = regexp.MustCompile(`^#{1,6}\s+(.*)$`)
+	slackBoldPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	slackLinkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// isDiscordWebhook reports whether webhookURL points at a Discord webhook
+func isDiscordWebhook(webhookURL string) bool {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return (host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")) &&
+		strings.HasPrefix(u.Path, "/api/webhooks/")
+}
+
+// notificationPayload builds the JSON body for a Slack or Discord webhook
+func notificationPayload(webhookURL, entry string) ([]byte, error) {
+	if isDiscordWebhook(webhookURL) {
+		return json.Marshal(map[string]string{"content": toDiscordMarkdown(entry)})
+	}
+	return json.Marshal(map[string]string{"text": toSlackMrkdwn(entry)})
+}
+
+// toSlackMrkdwn converts a changelog entry to Slack mrkdwn
+func toSlackMrkdwn(entry string) string {
+	lines := strings.Split(normalizeNewlines(entry), "\n")
+	for i, line := range lines {
+		if m := chatHeadingPattern.FindStringSubmatch(line); m != nil {
+			line = "*" + slackBoldPattern.ReplaceAllString(m[1], "$1") + "*"
+		} else if m := listItemPattern.FindStringSubmatch(line); m != nil {
+			line = m[1] + "• " + m[2]
+		}
+		line = slackBoldPattern.ReplaceAllString(line, "*$1*")
+		lines[i] = slackLinkPattern.ReplaceAllString(line, "<$2|$1>")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// toDiscordMarkdown adapts a changelog entry to Discord markdown, which supports
+// only three heading levels and limits message length
+func toDiscordMarkdown(entry string) string {
+	lines := strings.Split(normalizeNewlines(entry), "\n")
+	for i, line := range lines {
+		if m := chatHeadingPattern.FindStringSubmatch(line); m != nil && strings.HasPrefix(line, "####") {
+			lines[i] = "**" + m[1] + "**"
+		}
+	}
+	content := strings.Join(lines, "\n")
+	if runes := []rune(content); len(runes) > discordMessageLimit {
+		content = string(runes[:discordMessageLimit-1]) + "…"
+	}
+	return content
+}
+
+// notifyWebhook posts the entry to a Slack or Discord incoming webhook
+func notifyWebhook(webhookURL, entry string) error {
+	payload, err := notificationPayload(webhookURL, entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	client, err := newHTTPClient(30 * time.Second)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestToSlackMrkdwn(t *testing.T) {
+	entry := "## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- **CLI**: [docs](https://example.com) を追加\n  - 詳細"
+	want := "*[v1.2.0] - 2025-09-01*\n\n*追加*\n\n• *CLI*: <https://example.com|docs> を追加\n  • 詳細"
+	if got := toSlackMrkdwn(entry); got != want {
+		t.Errorf("toSlackMrkdwn() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestToDiscordMarkdown(t *testing.T) {
+	if got := toDiscordMarkdown("### 追加\n#### 詳細\n- item"); got != "### 追加\n**詳細**\n- item" {
+		t.Errorf("toDiscordMarkdown() = %q", got)
+	}
+	long := toDiscordMarkdown(strings.Repeat("あ", discordMessageLimit+10))
+	if n := len([]rune(long)); n != discordMessageLimit {
+		t.Errorf("toDiscordMarkdown() length = %d, want %d", n, discordMessageLimit)
+	}
+}
+
+func TestIsDiscordWebhook(t *testing.T) {
+	tests := map[string]bool{
+		"https://discord.com/api/webhooks/1/abc":     true,
+		"https://discordapp.com/api/webhooks/1/abc":  true,
+		"https://hooks.slack.com/services/T/B/X":     false,
+		"https://discord.com.evil.test/api/webhooks": false,
+	}
+	for u, want := range tests {
+		if got := isDiscordWebhook(u); got != want {
+			t.Errorf("isDiscordWebhook(%q) = %v, want %v", u, got, want)
+		}
+	}
+}
+
+func TestNotifyWebhook(t *testing.T) {
+	var received map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	if err := notifyWebhook(srv.URL, "### 修正\n\n- **bug**"); err != nil {
+		t.Fatalf("notifyWebhook() error = %v", err)
+	}
+	if received["text"] != "*修正*\n\n• *bug*" {
+		t.Errorf("posted text = %q", received["text"])
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer failing.Close()
+	if err := notifyWebhook(failing.URL, "x"); err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("notifyWebhook() error = %v, want webhook error", err)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// generationOptions holds settings that adjust how entries are generated
+type generationOptions struct {
+	// LinkCommits asks for commit and PR references at the end of each bullet
+	LinkCommits bool
+	// AttributeAuthors credits the authors of the commits behind each bullet as "(by @alice)"
+	AttributeAuthors bool
+	// RepoURL is the web URL of the repository used to build links
+	RepoURL string
+	// Stats appends a release statistics line to each entry
+	Stats bool
+	// DependencySection lists dependency updates in a dedicated section instead of leaving them to the AI
+	DependencySection bool
+	// GroupBy groups bullets by component ("scope" or "directory") when set
+	GroupBy string
+	// SecurityAdvisories lists the CVE and GHSA advisories referenced by commits in the security section
+	SecurityAdvisories bool
+	// BotCommits controls dependency bot commits: "collapse" replaces them with one bullet, "exclude" drops them, "keep" leaves them to the AI
+	BotCommits string
+	// PRLabels fetches the labels of referenced PRs and passes their section to the AI as ground truth
+	PRLabels bool
+	// ExpandSquashMerges passes the description and commits of squash-merged PRs to the AI
+	ExpandSquashMerges bool
+	// LabelSections maps lowercase PR labels to changelog sections
+	LabelSections map[string]string
+	// Structured asks the AI for JSON items and renders the entry through entryTemplate
+	Structured bool
+	// MarkdownLint post-processes entries to satisfy common markdownlint rules
+	MarkdownLint bool
+	// IncludeStaged passes staged changes to the AI together with the commits
+	IncludeStaged bool
+	// IncludeWorkingTree passes unstaged changes to tracked files to the AI
+	IncludeWorkingTree bool
+	// IncludeUntracked passes untracked files that are not ignored to the AI as added files
+	IncludeUntracked bool
+	// Metadata records the tool version, model, prompt hash and version, and commit range in a comment under each entry
+	Metadata bool
+	// Model is the AI model name recorded in the metadata comment
+	Model string
+	// Audience selects who the entry is written for: "end-user", "developer" or "ops"; empty keeps the default prompt
+	Audience string
+	// Tone selects the writing style: "formal" or "casual"; empty keeps the default prompt
+	Tone string
+	// Detail selects how much is written per item: "terse", "normal" or "detailed"
+	Detail string
+	// ListStyle is the list marker and nesting indentation enforced on generated entries
+	ListStyle listStyleConfig
+	// MaxItemsPerSection caps the bullets in each section of generated entries; 0 means no limit
+	MaxItemsPerSection int
+	// Instructions are free-form instructions for this run, appended to every generation prompt
+	Instructions string
+	// PromptVersion selects the wording of the built-in prompts, one of promptVersions
+	PromptVersion int
+}
+
+// genOpts are the generation options for the current run
+var genOpts = generationOptions{DependencySection: true, SecurityAdvisories: true, BotCommits: botCommitsCollapse, IncludeStaged: true, Model: "claude", PromptVersion: currentPromptVersion}
+
+// promptExtras returns additional instructions appended to every generation prompt for the changes
+// between from and to
+func promptExtras(from, to, diff, commits string) string {
+	var notes []string
+
+	if genOpts.LinkCommits {
+		if genOpts.RepoURL != "" {
+			notes = append(notes,
+				fmt.Sprintf("各項目の末尾に、根拠となるコミットへのリンクを `([abc1234](%s))` の形式で付けてください", commitURL(genOpts.RepoURL, "abc1234")),
+				fmt.Sprintf("コミットメッセージに `#123` の形式でPR番号が含まれる場合は、コミットの代わりに `([#123](%s))` の形式でPRへのリンクを付けてください", pullRequestURL(genOpts.RepoURL, "123")))
+		} else {
+			notes = append(notes,
+				"各項目の末尾に、根拠となるコミットの短縮ハッシュを `(abc1234)` の形式で付けてください",
+				"コミットメッセージに `#123` の形式でPR番号が含まれる場合は、コミットの代わりに `(#123)` の形式で記載してください")
+		}
+	}
+
+	if genOpts.AttributeAuthors {
+		notes = append(notes, "各項目の末尾に、その項目の根拠となるコミットの短縮ハッシュを `<!-- by: abc1234 def5678 -->` の形式で付けてください（投稿者の表記に自動で置き換えます）")
+	}
+
+	if genOpts.DependencySection && hasDependencyChanges(from, to) {
+		notes = append(notes, "依存パッケージのバージョン更新は別途自動で記載するため、エントリーには含めないでください")
+	}
+
+	if genOpts.SecurityAdvisories && len(advisoryIDs(commits)) > 0 {
+		notes = append(notes, "コミットで参照されているセキュリティアドバイザリ（CVE / GHSA）は重大度とリンク付きでセキュリティセクションに自動で記載するため、エントリーには含めないでください")
+	}
+
+	if note := activeSpec.sectionNote(); note != "" {
+		notes = append(notes, note)
+	}
+
+	notes = append(notes, renameNotes(diff)...)
+	notes = append(notes, groupingNotes(diff, commits)...)
+	notes = append(notes, labelNotes(commits)...)
+	notes = append(notes, squashMergeNotes(commits)...)
+	notes = append(notes, audienceNotes()...)
+	notes = append(notes, detailNotes()...)
+	notes = append(notes, subBulletNote()...)
+	if instructions := strings.TrimSpace(genOpts.Instructions); instructions != "" {
+		notes = append(notes, "追加の指示（他の指示より優先してください）: "+instructions)
+	}
+
+	if genOpts.Structured {
+		notes = append(notes, structuredOutputNote())
+	}
+
+	if len(notes) == 0 {
+		return ""
+	}
+	return "\n- " + strings.Join(notes, "\n- ")
+}
+
+// finalizeEntry applies the deterministic post-processing steps to a generated entry for the range from..to
+func finalizeEntry(entry, from, to string) string {
+	entry = limitSectionItems(entry, genOpts.MaxItemsPerSection)
+	entry = attributeAuthors(entry, from, to)
+	entry = appendSecurityAdvisories(entry, from, to)
+	entry = appendDependencySection(entry, from, to)
+	entry = appendBotCommits(entry, from, to)
+	entry = appendStats(entry, from, to)
+	entry = applyListStyle(entry, genOpts.ListStyle)
+	if genOpts.MarkdownLint {
+		entry = lintMarkdown(entry)
+	}
+	return entry
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptExtrasLinkCommits(t *testing.T) {
+	original := genOpts
+	defer func() { genOpts = original }()
+
+	genOpts = generationOptions{}
+	if got := promptExtras("", "", "", ""); got != "" {
+		t.Errorf("promptExtras() with no options = %q, want empty", got)
+	}
+
+	genOpts = generationOptions{LinkCommits: true, RepoURL: "https://github.com/org/repo"}
+	got := promptExtras("", "", "", "")
+	if !strings.Contains(got, "https://github.com/org/repo/commit/abc1234") || !strings.Contains(got, "https://github.com/org/repo/pull/123") {
+		t.Errorf("promptExtras() = %q, want commit and PR link formats", got)
+	}
+
+	genOpts = generationOptions{LinkCommits: true}
+	if got := promptExtras("", "", "", ""); !strings.Contains(got, "(abc1234)") {
+		t.Errorf("promptExtras() without repo URL = %q, want plain hash format", got)
+	}
+}
+
+func TestPromptExtrasAudienceAndTone(t *testing.T) {
+	original := genOpts
+	defer func() { genOpts = original }()
+
+	genOpts = generationOptions{Audience: audienceOps, Tone: toneFormal}
+	got := promptExtras("", "", "", "")
+	if !strings.Contains(got, "運用") || !strings.Contains(got, "です・ます調") {
+		t.Errorf("promptExtras() = %q, want the ops and formal instructions", got)
+	}
+
+	for _, value := range []string{"", audienceEndUser, audienceDeveloper, audienceOps} {
+		if !validAudience(value) {
+			t.Errorf("validAudience(%q) = false", value)
+		}
+	}
+	if validAudience("manager") || validTone("angry") {
+		t.Error("unsupported audience or tone accepted")
+	}
+}
+
+func TestPromptExtrasInstructions(t *testing.T) {
+	original := genOpts
+	defer func() { genOpts = original }()
+
+	genOpts = generationOptions{Instructions: "  新しいREST APIを目立たせる  "}
+	if got := promptExtras("", "", "", ""); !strings.HasSuffix(got, "追加の指示（他の指示より優先してください）: 新しいREST APIを目立たせる") {
+		t.Errorf("promptExtras() = %q, want the instructions as the last note", got)
+	}
+}
+
+func TestPromptExtrasDependencyNote(t *testing.T) {
+	original := genOpts
+	defer func() { genOpts = original }()
+	newTestRepo(t)
+	genOpts = generationOptions{DependencySection: true}
+
+	first := testCommit(t, "change", map[string]string{"go.mod": "module example.com/app\n\nrequire golang.org/x/text v0.13.0\n"})
+	second := testCommit(t, "change", map[string]string{"main.go": "package main\n"})
+	third := testCommit(t, "change", map[string]string{"go.mod": "module example.com/app\n\nrequire golang.org/x/text v0.14.0\n"})
+
+	const note = "依存パッケージのバージョン更新"
+	if got := promptExtras(first, second, "", ""); strings.Contains(got, note) {
+		t.Errorf("promptExtras() without dependency updates = %q, want no dependency note", got)
+	}
+	if got := promptExtras(second, third, "", ""); !strings.Contains(got, note) {
+		t.Errorf("promptExtras() with a dependency update = %q, want the dependency note", got)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// linkDefinitionPattern matches Markdown link reference definitions such as "[1.0.0]: https://..."
+var linkDefinitionPattern = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*\S`)
+
+// parseVersion splits a semantic version into its numeric core and pre-release identifiers,
+// ignoring a leading "v" and build metadata; ok is false for other version schemes
+func parseVersion(version string) (core []int, pre []string, ok bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	version, prerelease, hasPre := strings.Cut(version, "-")
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, nil, false
+		}
+		core = append(core, n)
+	}
+	if hasPre {
+		pre = strings.Split(prerelease, ".")
+	}
+	return core, pre, true
+}
+
+// compareVersions compares two semantic versions, returning -1, 0 or 1; ok is false when either
+// is not a semantic version
+func compareVersions(a, b string) (result int, ok bool) {
+	coreA, preA, okA := parseVersion(a)
+	coreB, preB, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := 0; i < max(len(coreA), len(coreB)); i++ {
+		var x, y int
+		if i < len(coreA) {
+			x = coreA[i]
+		}
+		if i < len(coreB) {
+			y = coreB[i]
+		}
+		if x != y {
+			return compareInts(x, y), true
+		}
+	}
+
+	// A release is newer than its pre-releases
+	switch {
+	case len(preA) == 0 && len(preB) == 0:
+		return 0, true
+	case len(preA) == 0:
+		return 1, true
+	case len(preB) == 0:
+		return -1, true
+	}
+	for i := 0; i < min(len(preA), len(preB)); i++ {
+		x, errX := strconv.Atoi(preA[i])
+		y, errY := strconv.Atoi(preB[i])
+		switch {
+		case errX == nil && errY == nil:
+			if x != y {
+				return compareInts(x, y), true
+			}
+		case errX == nil:
+			return -1, true
+		case errY == nil:
+			return 1, true
+		default:
+			if c := strings.Compare(preA[i], preB[i]); c != 0 {
+				return c, true
+			}
+		}
+	}
+	return compareInts(len(preA), len(preB)), true
+}
+
+// compareInts returns -1, 0 or 1 as a is less than, equal to or greater than b
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// releasedAfter reports whether the entry with heading line existing is a newer release than the
+// entry with heading line entry and stays above it. Versions are compared as semantic versions,
+// falling back to the heading dates; entries that cannot be ordered are not considered newer.
+// The Unreleased section always stays on top.
+func releasedAfter(existing, entry string) bool {
+	existingVersion, _ := changelogHeading.Version(existing)
+	entryVersion, _ := changelogHeading.Version(entry)
+	if strings.EqualFold(existingVersion, "Unreleased") {
+		return true
+	}
+	if c, ok := compareVersions(existingVersion, entryVersion); ok {
+		return c > 0
+	}
+	existingDate, okExisting := changelogHeading.Date(existing)
+	entryDate, okEntry := changelogHeading.Date(entry)
+	return okExisting && okEntry && existingDate.After(entryDate)
+}
+
+// splitEntries splits a block of entries at their version headings
+func splitEntries(block string) [][]string {
+	var entries [][]string
+	for i, line := range strings.Split(block, "\n") {
+		if _, ok := changelogHeading.Version(line); ok && i > 0 {
+			entries = append(entries, nil)
+		}
+		if len(entries) == 0 {
+			entries = append(entries, nil)
+		}
+		entries[len(entries)-1] = append(entries[len(entries)-1], line)
+	}
+	return entries
+}
+
+// entriesEnd returns the index after the last entry of a changelog, before the link reference
+// definitions that usually close the file
+func entriesEnd(lines []string) int {
+	end := len(lines)
+	for end > 0 && (strings.TrimSpace(lines[end-1]) == "" || linkDefinitionPattern.MatchString(lines[end-1])) {
+		end--
+	}
+	return end
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"v1.2.0", "1.10.0", -1, true},
+		{"v2.0.0", "v1.9.9", 1, true},
+		{"v1.0", "v1.0.0", 0, true},
+		{"v1.0.0-rc.1", "v1.0.0", -1, true},
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1, true},
+		{"v1.0.0-beta", "v1.0.0-alpha", 1, true},
+		{"v1.0.0-alpha", "v1.0.0-alpha.1", -1, true},
+		{"v1.0.0+build.5", "v1.0.0", 0, true},
+		{"2024.05", "2024.10", -1, true},
+		{"release-7", "v1.0.0", 0, false},
+	} {
+		got, ok := compareVersions(tt.a, tt.b)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("compareVersions(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUpdatedChangelogContentInsertsGapEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	existing := "# Changelog\n\n## [Unreleased]\n\n- 作業中\n\n## [v1.2.0] - 2024-03-01\n\n- C\n\n## [v1.0.0] - 2024-01-01\n\n- A\n\n[v1.2.0]: https://example.com/v1.2.0\n"
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Catch-up passes the missing entries newest first as one block
+	block := "## [v1.3.0] - 2024-04-01\n\n- D\n\n## [v1.1.0] - 2024-02-01\n\n- B\n\n## [v0.9.0] - 2023-12-01\n\n- Z"
+	got, err := updatedChangelogContent(path, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Changelog\n\n## [Unreleased]\n\n- 作業中\n\n## [v1.3.0] - 2024-04-01\n\n- D\n\n## [v1.2.0] - 2024-03-01\n\n- C\n\n## [v1.1.0] - 2024-02-01\n\n- B\n\n## [v1.0.0] - 2024-01-01\n\n- A\n\n## [v0.9.0] - 2023-12-01\n\n- Z\n\n[v1.2.0]: https://example.com/v1.2.0\n"
+	if got != want {
+		t.Errorf("updatedChangelogContent() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUpdatedChangelogContentOrdersByDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	existing := "# Changelog\n\n## [release-3] - 2024-03-01\n\n- C\n\n## [release-1] - 2024-01-01\n\n- A\n"
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := updatedChangelogContent(path, "## [release-2] - 2024-02-01\n\n- B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Changelog\n\n## [release-3] - 2024-03-01\n\n- C\n\n## [release-2] - 2024-02-01\n\n- B\n\n## [release-1] - 2024-01-01\n\n- A\n"
+	if got != want {
+		t.Errorf("updatedChangelogContent() =\n%s\nwant\n%s", got, want)
+	}
+}
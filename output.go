@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const separatorLine = "==================================="
+
+// ANSI escape sequences used for colored output
+const (
+	ansiReset   = "\033[0m"
+	ansiBold    = "\033[1m"
+	ansiRed     = "\033[31m"
+	ansiGreen   = "\033[32m"
+	ansiYellow  = "\033[33m"
+	ansiMagenta = "\033[35m"
+	ansiCyan    = "\033[36m"
+)
+
+// statusColors maps the leading emoji of a status message to its color
+var statusColors = map[rune]string{
+	'❌': ansiRed,
+	'⚠': ansiYellow,
+	'✅': ansiGreen,
+}
+
+// quietStatuses are the leading emoji of the messages still shown in quiet mode
+var quietStatuses = []rune{'❌', '⚠', '❓'}
+
+// console writes user-facing status messages, honoring the plain and color output settings; it is
+// safe for concurrent use, e.g. by catch-up workers
+type console struct {
+	// mu serializes writes and guards shown
+	mu    sync.Mutex
+	out   io.Writer
+	plain bool
+	color bool
+	raw   bool
+	// quiet drops status messages other than warnings and errors, and entry previews
+	quiet bool
+	// shown reports whether quiet mode kept the last status message
+	shown bool
+	// messages translates status messages into the --ui-lang language; nil prints them in English
+	messages map[string]string
+}
+
+// ui is the console used for all status output
+var ui = &console{out: os.Stdout}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Printf formats and writes a status message
+func (c *console) Printf(format string, a ...any) {
+	c.status(c.Sprintf(format, a...))
+}
+
+// Println writes a status message followed by a newline
+func (c *console) Println(a ...any) {
+	c.status(c.translate(fmt.Sprintln(a...)))
+}
+
+// Print writes a status message, such as a question, that quiet mode keeps
+func (c *console) Print(a ...any) {
+	c.write(c.translate(fmt.Sprint(a...)))
+}
+
+// Entry writes a generated CHANGELOG entry for preview, rendered unless raw output is requested
+func (c *console) Entry(entry string) {
+	if c.quiet {
+		return
+	}
+	switch {
+	case !c.raw:
+		entry = renderMarkdown(entry, c.color)
+	case c.color:
+		entry = colorizeMarkdown(entry)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintln(c.out, entry)
+}
+
+// Separator writes the line framing generated entries; plain mode omits it
+func (c *console) Separator() {
+	if c.plain || c.quiet {
+		return
+	}
+	c.write(separatorLine + "\n")
+}
+
+// status writes a status message unless quiet mode drops it; indented detail lines follow the
+// message above them
+func (c *console) status(s string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.quiet {
+		body := strings.TrimLeft(s, "\n")
+		r, _ := utf8.DecodeRuneInString(body)
+		switch {
+		case slices.Contains(quietStatuses, r):
+			c.shown = true
+		case strings.HasPrefix(body, " ") && c.shown:
+		default:
+			c.shown = false
+			return
+		}
+	}
+	c.print(s)
+}
+
+// write writes s whatever quiet mode drops
+func (c *console) write(s string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.print(s)
+}
+
+// print decorates and writes s; the caller holds mu
+func (c *console) print(s string) {
+	if c.color {
+		s = colorizeStatus(s)
+	}
+	if c.plain {
+		s = stripDecorations(s)
+	}
+	fmt.Fprint(c.out, s)
+}
+
+// isEmoji reports whether r is an emoji or an emoji presentation modifier
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r >= 0x2300 && r <= 0x23FF:
+		return true
+	case r == 0x2139, r == 0xFE0F, r == 0x200D:
+		return true
+	}
+	return false
+}
+
+// stripDecorations removes emoji (with the spacing that followed them) and replaces block characters with ASCII
+func stripDecorations(s string) string {
+	var b strings.Builder
+	skipSpaces := false
+	for _, r := range s {
+		switch {
+		case isEmoji(r):
+			skipSpaces = true
+			continue
+		case skipSpaces && r == ' ':
+			continue
+		case r == '█':
+			r = '#'
+		case r == '░':
+			r = '-'
+		}
+		if !unicode.IsSpace(r) || r == '\n' {
+			skipSpaces = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// colorEnabled reports whether colored output should be used for f
+func colorEnabled(f *os.File) bool {
+	return os.Getenv("NO_COLOR") == "" && isTerminal(f)
+}
+
+// colorizeStatus colors a message according to its leading status emoji
+func colorizeStatus(s string) string {
+	body := strings.TrimLeft(s, "\n")
+	lead := s[:len(s)-len(body)]
+	trimmed := strings.TrimRight(body, "\n")
+	trail := body[len(trimmed):]
+
+	r, _ := utf8.DecodeRuneInString(trimmed)
+	if code, ok := statusColors[r]; ok {
+		return lead + code + trimmed + ansiReset + trail
+	}
+	return s
+}
+
+// colorizeMarkdown highlights version and section headings in an entry preview
+func colorizeMarkdown(entry string) string {
+	lines := strings.Split(entry, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "### "):
+			lines[i] = ansiBold + ansiMagenta + line + ansiReset
+		case strings.HasPrefix(line, "## "):
+			lines[i] = ansiBold + ansiCyan + line + ansiReset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
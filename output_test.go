@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStripDecorations(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{"🚀 Starting CHANGELOG update process using claude...\n", "Starting CHANGELOG update process using claude...\n"},
+		{"⚠️  Warning: Failed to pull tags\n", "Warning: Failed to pull tags\n"},
+		{"\n✅ CHANGELOG.md updated successfully!\n", "\nCHANGELOG.md updated successfully!\n"},
+		{"  - v1.0.0\n", "  - v1.0.0\n"},
+		{"[███░░░] 1/2", "[###---] 1/2"},
+		{"日本語のメッセージ", "日本語のメッセージ"},
+	}
+
+	for _, tc := range testCases {
+		if got := stripDecorations(tc.input); got != tc.want {
+			t.Errorf("stripDecorations(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestConsolePlainMode(t *testing.T) {
+	var buf bytes.Buffer
+	c := &console{out: &buf, plain: true}
+	c.Printf("📌 Previous tag: %s\n", "v1.0.0")
+	c.Separator()
+
+	if got := buf.String(); got != "Previous tag: v1.0.0\n" {
+		t.Errorf("plain console output = %q", got)
+	}
+
+	buf.Reset()
+	c.plain = false
+	c.Separator()
+	if got := buf.String(); got != separatorLine+"\n" {
+		t.Errorf("decorated separator = %q", got)
+	}
+}
+
+func TestColorizeStatus(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{"❌ Error: boom\n", ansiRed + "❌ Error: boom" + ansiReset + "\n"},
+		{"\n✅ CHANGELOG.md updated successfully!\n", "\n" + ansiGreen + "✅ CHANGELOG.md updated successfully!" + ansiReset + "\n"},
+		{"⚠️  Warning: careful\n", ansiYellow + "⚠️  Warning: careful" + ansiReset + "\n"},
+		{"📌 Previous tag: v1.0.0\n", "📌 Previous tag: v1.0.0\n"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		if got := colorizeStatus(tc.input); got != tc.want {
+			t.Errorf("colorizeStatus(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestColorizeMarkdown(t *testing.T) {
+	got := colorizeMarkdown("## [v1.0.0] - 2025-08-27\n\n### 追加\n\n- Feature")
+	want := ansiBold + ansiCyan + "## [v1.0.0] - 2025-08-27" + ansiReset + "\n\n" +
+		ansiBold + ansiMagenta + "### 追加" + ansiReset + "\n\n- Feature"
+	if got != want {
+		t.Errorf("colorizeMarkdown() = %q, want %q", got, want)
+	}
+}
+
+func TestConsoleQuietMode(t *testing.T) {
+	var buf bytes.Buffer
+	c := &console{out: &buf, quiet: true}
+	c.Println("🚀 Starting CHANGELOG update process using claude...")
+	c.Printf("  - %s\n", "dropped detail")
+	c.Printf("\n⚠️  The entry may need attention (%d warning(s)):\n", 1)
+	c.Printf("  - %s\n", "kept detail")
+	c.Println("\n📝 Generated CHANGELOG Entry:")
+	c.Separator()
+	c.Entry("## [v1.0.0]")
+	c.Printf("❌ Error: %v\n", "failed")
+	c.Print("Proceed? [y/N]: ")
+
+	want := "\n⚠️  The entry may need attention (1 warning(s)):\n  - kept detail\n❌ Error: failed\nProceed? [y/N]: "
+	if got := buf.String(); got != want {
+		t.Errorf("quiet console output = %q, want %q", got, want)
+	}
+}
+
+func TestConsoleConcurrentStatus(t *testing.T) {
+	var buf bytes.Buffer
+	c := &console{out: &buf, quiet: true}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Printf("⚠️  Warning: tag %d failed\n", i)
+			c.Printf("✅ Added tag %d\n", i)
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("concurrent quiet output has %d lines, want the 8 warnings: %q", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "⚠️  Warning: tag ") {
+			t.Errorf("unexpected line %q", line)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// versionPlaceholder in an output path selects one file per version
+const versionPlaceholder = "{version}"
+
+// outputTarget is an additional destination written together with the changelog
+type outputTarget struct {
+	// Path is relative to the repository root; a path containing {version} receives one file per version
+	Path string `json:"path"`
+	// FrontMatter writes per-version files as pages with the version and date in front matter
+	FrontMatter bool `json:"front_matter,omitempty"`
+}
+
+// outputTargets are the additional destinations from the configuration file
+var outputTargets []outputTarget
+
+// validOutputPath rejects output paths that are empty, absolute or leave the repository; in serve
+// mode the configuration comes from the cloned repository and must not write elsewhere
+func validOutputPath(path string) error {
+	switch {
+	case strings.TrimSpace(path) == "":
+		return fmt.Errorf("path is empty")
+	case filepath.IsAbs(path) || strings.HasPrefix(path, "/"):
+		return fmt.Errorf("%s must be relative to the repository", path)
+	case !filepath.IsLocal(filepath.FromSlash(path)):
+		return fmt.Errorf("%s leaves the repository", path)
+	}
+	return nil
+}
+
+// pendingWrite is a file content prepared before any destination is modified
+type pendingWrite struct {
+	path    string
+	content string
+}
+
+// writeChangelogOutputs writes entry to changelogFile and every output target, replacing all files
+// only after every new content has been prepared and staged; it returns the written paths.
+// The changelog is locked for the whole read-modify-write so concurrent runs cannot interleave.
+func writeChangelogOutputs(changelogFile, entry string) ([]string, error) {
+	lock, err := acquireLock(changelogFile)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.release()
+
+	writes, err := planOutputWrites(changelogFile, entry, outputTargets)
+	if err != nil {
+		return nil, err
+	}
+	if err := commitWrites(writes); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(writes))
+	for i, w := range writes {
+		paths[i] = w.path
+	}
+	return paths, nil
+}
+
+// planOutputWrites computes the new content of the changelog and every target; with --split-only
+// the changelog itself is left out
+func planOutputWrites(changelogFile, entry string, targets []outputTarget) ([]pendingWrite, error) {
+	var writes []pendingWrite
+	if !splitOnly {
+		content, err := updatedChangelogContent(changelogFile, entry)
+		if err != nil {
+			return nil, err
+		}
+		writes = append(writes, pendingWrite{path: changelogFile, content: content})
+	}
+
+	for _, target := range targets {
+		path := filepath.Join(gitDir, filepath.FromSlash(target.Path))
+		if !strings.Contains(target.Path, versionPlaceholder) {
+			content, err := updatedChangelogContent(path, entry)
+			if err != nil {
+				return nil, fmt.Errorf("failed to prepare %s: %w", target.Path, err)
+			}
+			writes = append(writes, pendingWrite{path: path, content: content})
+			continue
+		}
+
+		for _, e := range parseChangelogEntries(entry) {
+			content := e.Markdown() + "\n"
+			if target.FrontMatter {
+				page, err := frontMatterPage(e)
+				if err != nil {
+					return nil, err
+				}
+				content = page
+			}
+			writes = append(writes, pendingWrite{
+				path:    strings.ReplaceAll(path, versionPlaceholder, versionFileName(e.Version)),
+				content: content,
+			})
+		}
+	}
+	return writes, nil
+}
+
+// commitWrites stages every content in a temporary file next to its destination and then renames
+// them into place, so a failure while preparing leaves all destinations untouched
+func commitWrites(writes []pendingWrite) error {
+	temps := make([]string, 0, len(writes))
+	cleanup := func() {
+		for _, tmp := range temps {
+			os.Remove(tmp)
+		}
+	}
+
+	for _, w := range writes {
+		tmp, err := stageWrite(w)
+		if err != nil {
+			cleanup()
+			return fmt.Errorf("failed to write %s: %w", w.path, err)
+		}
+		temps = append(temps, tmp)
+	}
+
+	for i, w := range writes {
+		if err := os.Rename(temps[i], w.path); err != nil {
+			cleanup()
+			return fmt.Errorf("failed to replace %s: %w", w.path, err)
+		}
+	}
+	return nil
+}
+
+// stageWrite writes content to a temporary file in the destination directory, keeping the destination's mode
+func stageWrite(w pendingWrite) (string, error) {
+	dir := filepath.Dir(w.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(w.path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(w.path)+".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	_, writeErr := f.WriteString(w.content)
+	closeErr := f.Close()
+	if err := firstError(writeErr, closeErr, os.Chmod(f.Name(), mode)); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// reportExtraOutputs lists the files written besides the changelog itself
+func reportExtraOutputs(changelogFile string, written []string) {
+	for _, path := range written {
+		if path != changelogFile {
+			ui.Printf("✅ %s updated\n", path)
+		}
+	}
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
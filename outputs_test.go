@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteChangelogOutputs(t *testing.T) {
+	dir := t.TempDir()
+	originalDir, originalTargets := gitDir, outputTargets
+	defer func() { gitDir, outputTargets = originalDir, originalTargets }()
+	gitDir = dir
+	outputTargets = []outputTarget{
+		{Path: "docs/CHANGELOG.md"},
+		{Path: "docs/changelog/{version}.md"},
+	}
+
+	changelog := filepath.Join(dir, "CHANGELOG.md")
+	entry := "## [v1.1.0] - 2025-09-01\n\n### 追加\n\n- B\n\n## [v1.0.0] - 2025-08-01\n\n### 追加\n\n- A"
+
+	written, err := writeChangelogOutputs(changelog, entry)
+	if err != nil {
+		t.Fatalf("writeChangelogOutputs() error = %v", err)
+	}
+	if len(written) != 4 {
+		t.Errorf("written = %v, want 4 files", written)
+	}
+
+	for path, want := range map[string]string{
+		"CHANGELOG.md":             "## [v1.1.0] - 2025-09-01",
+		"docs/CHANGELOG.md":        "## [v1.0.0] - 2025-08-01",
+		"docs/changelog/v1.1.0.md": "## [v1.1.0] - 2025-09-01\n\n### 追加\n\n- B\n",
+		"docs/changelog/v1.0.0.md": "## [v1.0.0] - 2025-08-01\n\n### 追加\n\n- A\n",
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("%s = %q, want it to contain %q", path, content, want)
+		}
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(dir, "docs", "changelog", ".*.tmp-*"))
+	if len(leftovers) != 0 {
+		t.Errorf("temporary files left behind: %v", leftovers)
+	}
+}
+
+func TestWriteChangelogOutputsLeavesFilesOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	originalDir, originalTargets := gitDir, outputTargets
+	defer func() { gitDir, outputTargets = originalDir, originalTargets }()
+	gitDir = dir
+
+	// A regular file where the per-version directory should be makes staging fail
+	if err := os.WriteFile(filepath.Join(dir, "pages"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outputTargets = []outputTarget{{Path: "pages/{version}.md"}}
+
+	changelog := filepath.Join(dir, "CHANGELOG.md")
+	original := "# Changelog\n\n## [v1.0.0] - 2025-08-01\n"
+	if err := os.WriteFile(changelog, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writeChangelogOutputs(changelog, "## [v1.1.0] - 2025-09-01\n\n- B"); err == nil {
+		t.Fatal("writeChangelogOutputs() should fail")
+	}
+	content, _ := os.ReadFile(changelog)
+	if string(content) != original {
+		t.Errorf("changelog was modified despite the failure: %q", content)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(dir, ".CHANGELOG.md.tmp-*")); len(leftovers) != 0 {
+		t.Errorf("temporary files left behind: %v", leftovers)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// pullRequest is the information about a pull request used to generate its changelog fragment
+type pullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	URL    string `json:"url"`
+	// Commits lists the commits, one "<hash> <subject>" per line
+	Commits string `json:"-"`
+	// Diff lists the changed files in git diff --name-status format
+	Diff string `json:"-"`
+}
+
+// ghPullRequest is the output of gh pr view --json
+type ghPullRequest struct {
+	pullRequest
+	CommitList []struct {
+		OID             string `json:"oid"`
+		MessageHeadline string `json:"messageHeadline"`
+	} `json:"commits"`
+	Files []struct {
+		Path       string `json:"path"`
+		ChangeType string `json:"changeType"`
+	} `json:"files"`
+}
+
+// changeTypeStatus maps GitHub file change types to git name-status letters
+var changeTypeStatus = map[string]string{
+	"ADDED":   "A",
+	"DELETED": "D",
+	"RENAMED": "R",
+	"COPIED":  "C",
+}
+
+// parseGHPullRequest converts gh pr view --json output into a pull request
+func parseGHPullRequest(data []byte) (*pullRequest, error) {
+	var raw ghPullRequest
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request: %w", err)
+	}
+	pr := raw.pullRequest
+
+	var commits, files []string
+	for _, c := range raw.CommitList {
+		hash := c.OID
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		commits = append(commits, hash+" "+c.MessageHeadline)
+	}
+	for _, f := range raw.Files {
+		status, ok := changeTypeStatus[f.ChangeType]
+		if !ok {
+			status = "M"
+		}
+		files = append(files, status+"\t"+f.Path)
+	}
+	pr.Commits = commitFilter.filterOneline(strings.Join(commits, "\n"))
+	pr.Diff = aiFilter.filterNameStatus(strings.Join(files, "\n"))
+	return &pr, nil
+}
+
+// fetchPullRequest reads a pull request with gh; replaced in tests
+var fetchPullRequest = func(repo, number string) (*pullRequest, error) {
+	output, err := ghCommand(repo, "pr", "view", number, "--json", "number,title,body,url,commits,files").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pull request #%s (is gh installed and authenticated?): %w", number, err)
+	}
+	return parseGHPullRequest(output)
+}
+
+// prCommand generates the changelog fragment for the changes of a single pull request
+func prCommand(args []string) error {
+	fs := flag.NewFlagSet("pr", flag.ContinueOnError)
+	repo := fs.String("repo", "", "GitHub repository as owner/name (default: the repository of the current directory)")
+	model := fs.String("model", "claude", "AI model to use: claude, or mock[:file.md] for a canned entry without AI access")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update pr [flags] <number>\n\n")
+		fmt.Fprintf(os.Stderr, "Generates the changelog sections for the changes of one pull request (requires gh).\n")
+		fmt.Fprintf(os.Stderr, "The changelog is not modified.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return &ConfigError{Err: fmt.Errorf("expected one pull request number")}
+	}
+	number := strings.TrimPrefix(fs.Arg(0), "#")
+
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		return err
+	}
+	aiFilter = newPathFilter(cfg.AIAllow, cfg.AIDeny)
+	if changelogHeading, err = newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat); err != nil {
+		return &ConfigError{Err: err}
+	}
+	if commitFilter, err = newCommitIgnoreFilter(nil, true); err != nil {
+		return &ConfigError{Err: err}
+	}
+	// The fragment has no separately appended dependency section, so the AI has to mention updates itself
+	genOpts.DependencySection = false
+	genOpts.SecurityAdvisories = false
+
+	pr, err := fetchPullRequest(*repo, number)
+	if err != nil {
+		return err
+	}
+
+	executor, err := newExecutor(*model)
+	if err != nil {
+		return err
+	}
+	heading := changelogHeading.Render("Unreleased", releaseDate())
+	spin := startSpinner(ui.Sprintf("Generating changelog entry for #%d", pr.Number), !ui.plain)
+	response, err := executor.Execute(prPrompt(pr, heading))
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to generate changelog entry: %w", err)
+	}
+	entry, err := validateEntry(executor, response, heading)
+	if err != nil {
+		return fmt.Errorf("failed to generate changelog entry: %w", err)
+	}
+	fragment := prFragment(entry)
+
+	if *output == "" {
+		fmt.Println(fragment)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(fragment+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+	ui.Printf("✅ Wrote the changelog entry for #%d to %s\n", pr.Number, *output)
+	return nil
+}
+
+// prFragment drops the version heading from a generated entry, leaving the sections of the pull request
+func prFragment(entry string) string {
+	_, body, _ := strings.Cut(entry, "\n")
+	return strings.TrimSpace(body)
+}
+
+// prPrompt asks for the changelog sections describing a single pull request
+func prPrompt(pr *pullRequest, heading string) string {
+	description := strings.TrimSpace(firstLines(normalizeNewlines(pr.Body), 40))
+	if description == "" {
+		description = "（説明なし）"
+	}
+	build := func(diff, commits, _ string) string {
+		return fmt.Sprintf(`以下のプルリクエスト #%d の内容に基づいて、このPRによる変更をKeep a Changelog形式のCHANGELOGエントリーとして生成してください。
+
+タイトル: %s
+
+説明:
+---
+%s
+---
+
+コミットメッセージ:
+---
+%s
+---
+
+差分情報:
+---
+%s
+---
+
+以下の見出しから開始してください:
+%s
+
+セクションは ### %s の順序で、該当する変更がある場合のみ記載してください。
+
+注意事項：
+- 各セクションヘッダーの後には必ず空行を入れてください
+- 各セクションの内容は箇条書き（- ）のみで記載してください
+- 前置きや説明文は一切含めないでください
+- 各項目の末尾に (#%d) を付けてください
+- 各項目は日本語で記述し、ユーザーにとって価値のある情報を具体的に記載してください
+- 技術的な詳細よりも、ユーザーへの影響を重視してください`, pr.Number, pr.Title, description, commits, diff, heading, strings.Join(changelogSections, " / ### "), pr.Number)
+	}
+	return fitPrompt(build, pr.Diff, pr.Commits, "", promptExtras("", "", pr.Diff, pr.Commits))
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseGHPullRequest(t *testing.T) {
+	data := `{"number": 123, "title": "Add CSV export", "body": "Closes #100", "url": "https://github.com/org/repo/pull/123",
+		"commits": [{"oid": "abc1234def5678", "messageHeadline": "feat: add CSV export"}],
+		"files": [{"path": "export.go", "changeType": "ADDED"}, {"path": "main.go", "changeType": "MODIFIED"}]}`
+	pr, err := parseGHPullRequest([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Number != 123 || pr.Title != "Add CSV export" || pr.Body != "Closes #100" {
+		t.Errorf("parseGHPullRequest() = %+v", pr)
+	}
+	if pr.Commits != "abc1234 feat: add CSV export" {
+		t.Errorf("Commits = %q", pr.Commits)
+	}
+	if pr.Diff != "A\texport.go\nM\tmain.go" {
+		t.Errorf("Diff = %q", pr.Diff)
+	}
+}
+
+func TestPRCommand(t *testing.T) {
+	original, opts, heading, filter, ignore := fetchPullRequest, genOpts, changelogHeading, aiFilter, commitFilter
+	defer func() {
+		fetchPullRequest, genOpts, changelogHeading, aiFilter, commitFilter = original, opts, heading, filter, ignore
+	}()
+	fetchPullRequest = func(repo, number string) (*pullRequest, error) {
+		if number != "123" {
+			t.Errorf("fetchPullRequest() number = %q, want 123", number)
+		}
+		return &pullRequest{Number: 123, Title: "Add CSV export", Commits: "abc1234 feat: add CSV export", Diff: "A\texport.go"}, nil
+	}
+
+	output := filepath.Join(t.TempDir(), "fragment.md")
+	if err := prCommand([]string{"--model", "mock", "--output", output, "#123"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); !strings.HasPrefix(got, "### ") || strings.Contains(got, "## [") {
+		t.Errorf("fragment = %q, want the sections without a version heading", got)
+	}
+}
+
+func TestPRPrompt(t *testing.T) {
+	pr := &pullRequest{Number: 42, Title: "Fix crash on empty tags", Commits: "abc1234 fix: crash"}
+	got := prPrompt(pr, "## [Unreleased] - 2025-09-01")
+	for _, want := range []string{"#42", "Fix crash on empty tags", "（説明なし）", "(#42)", "abc1234 fix: crash"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("prPrompt() does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const progressBarWidth = 30
+
+// estimateTokens roughly estimates the token count of text (about 4 bytes per token)
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// tokenUsage holds estimated token counts for AI calls
+type tokenUsage struct {
+	Prompt   int
+	Response int
+}
+
+// meteredExecutor wraps an AIExecutor and records estimated token usage
+type meteredExecutor struct {
+	AIExecutor
+	mu    sync.Mutex
+	usage tokenUsage
+}
+
+// Execute runs the wrapped executor and accumulates token estimates
+func (m *meteredExecutor) Execute(prompt string) (string, error) {
+	result, err := m.AIExecutor.Execute(prompt)
+	m.mu.Lock()
+	m.usage.Prompt += estimateTokens(prompt)
+	m.usage.Response += estimateTokens(result)
+	m.mu.Unlock()
+	return result, err
+}
+
+// totals returns the accumulated prompt and response token estimates
+func (m *meteredExecutor) totals() (prompt, response int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage.Prompt, m.usage.Response
+}
+
+// catchUpProgress reports completion, timing and ETA while catch-up entries are generated
+type catchUpProgress struct {
+	mu      sync.Mutex
+	total   int
+	done    int
+	start   time.Time
+	verbose bool
+}
+
+func newCatchUpProgress(total int, verbose bool) *catchUpProgress {
+	return &catchUpProgress{total: total, start: time.Now(), verbose: verbose}
+}
+
+// complete records a finished tag and prints the updated progress bar
+func (p *catchUpProgress) complete(tag string, elapsed time.Duration, usage tokenUsage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	if p.verbose {
+		ui.Printf("⏱️  %s took %s (~%d prompt tokens, ~%d response tokens)\n",
+			tag, elapsed.Round(time.Second), usage.Prompt, usage.Response)
+	}
+	ui.Println(p.render(time.Since(p.start)))
+}
+
+// render formats the progress bar with elapsed time and ETA
+func (p *catchUpProgress) render(elapsed time.Duration) string {
+	filled := 0
+	percent := 100
+	if p.total > 0 {
+		filled = p.done * progressBarWidth / p.total
+		percent = p.done * 100 / p.total
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+
+	eta := "--"
+	if p.done > 0 && p.done < p.total {
+		remaining := elapsed / time.Duration(p.done) * time.Duration(p.total-p.done)
+		eta = remaining.Round(time.Second).String()
+	} else if p.done >= p.total {
+		eta = "0s"
+	}
+
+	return fmt.Sprintf("[%s] %d/%d (%d%%) elapsed %s, ETA %s",
+		bar, p.done, p.total, percent, elapsed.Round(time.Second), eta)
+}
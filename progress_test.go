@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCatchUpProgressRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int
+		done    int
+		elapsed time.Duration
+		want    []string
+	}{
+		{
+			name:    "not started",
+			total:   4,
+			done:    0,
+			elapsed: 0,
+			want:    []string{"0/4 (0%)", "ETA --"},
+		},
+		{
+			name:    "half done",
+			total:   4,
+			done:    2,
+			elapsed: 2 * time.Minute,
+			want:    []string{"2/4 (50%)", "elapsed 2m0s", "ETA 2m0s"},
+		},
+		{
+			name:    "finished",
+			total:   4,
+			done:    4,
+			elapsed: 3 * time.Minute,
+			want:    []string{"4/4 (100%)", "ETA 0s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &catchUpProgress{total: tt.total, done: tt.done}
+			got := p.render(tt.elapsed)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("render() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMeteredExecutor(t *testing.T) {
+	metered := &meteredExecutor{AIExecutor: &MockExecutor{response: "12345678"}}
+	if _, err := metered.Execute("1234"); err != nil {
+		t.Fatal(err)
+	}
+	if metered.usage.Prompt != 1 || metered.usage.Response != 2 {
+		t.Errorf("usage = %+v, want {Prompt:1 Response:2}", metered.usage)
+	}
+}
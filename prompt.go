@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// stdinReader is shared by all interactive prompts so buffered input is never lost between them
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// Values of assumeAnswer
+const (
+	answerYes = "yes"
+	answerNo  = "no"
+)
+
+// assumeAnswer answers every interactive prompt without reading stdin: answerYes for --yes and
+// answerNo for --no; empty asks the user
+var assumeAnswer string
+
+// assumedAnswer shows the answer given for question by --yes or --no
+func assumedAnswer(question string) {
+	ui.Printf("%s%s (--%s flag)\n", question, assumeAnswer, assumeAnswer)
+}
+
+// confirm asks a yes/no question and reports whether the user answered yes
+func confirm(question string) (bool, error) {
+	if assumeAnswer != "" {
+		assumedAnswer(question)
+		return assumeAnswer == answerYes, nil
+	}
+	ui.Print(question)
+	response, err := readLine(stdinReader)
+	if err != nil {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+	response = strings.ToLower(response)
+	return response == responseY || response == responseYes, nil
+}
+
+// readLine reads a single line, accepting CRLF line endings and a final line without a newline
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimRight(line, "\r\n")), nil
+}
+
+// errPromptDeclined is returned when the user does not approve sending a prompt
+var errPromptDeclined = errors.New("prompt was not approved")
+
+// reviewingExecutor shows each prompt and asks for approval before sending it to the AI
+type reviewingExecutor struct {
+	AIExecutor
+	mu sync.Mutex
+}
+
+// Execute prints the prompt with its size and runs it only when the user approves
+func (r *reviewingExecutor) Execute(prompt string) (string, error) {
+	r.mu.Lock()
+	resume := pauseSpinners()
+	ui.Println("\n🔎 Prompt to be sent to the AI:")
+	ui.Separator()
+	fmt.Fprintln(ui.out, prompt)
+	ui.Separator()
+	ui.Printf("📏 Prompt size: %d bytes (~%d tokens)\n", len(prompt), estimateTokens(prompt))
+	approved, err := confirm("Send this prompt? [y/N]: ")
+	resume()
+	r.mu.Unlock()
+
+	if err != nil {
+		return "", err
+	}
+	if !approved {
+		return "", errPromptDeclined
+	}
+	return r.AIExecutor.Execute(prompt)
+}
+
+// errPromptPrinted is returned after the prompt was printed for --print-prompt instead of being sent
+var errPromptPrinted = errors.New("prompt was printed")
+
+// promptPrinter writes the prompt to out instead of sending it to the AI, so it can be run through
+// external tooling and the result passed back with --entry-file
+type promptPrinter struct {
+	out io.Writer
+}
+
+// Execute prints the prompt and stops generation with errPromptPrinted
+func (p *promptPrinter) Execute(prompt string) (string, error) {
+	if _, err := fmt.Fprintln(p.out, prompt); err != nil {
+		return "", err
+	}
+	return "", errPromptPrinted
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadLine(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{"y\n", "y"},
+		{"yes\r\n", "yes"},
+		{"  Y  \r\n", "Y"},
+		{"y", "y"},
+	}
+
+	for _, tc := range testCases {
+		got, err := readLine(bufio.NewReader(strings.NewReader(tc.input)))
+		if err != nil {
+			t.Errorf("readLine(%q) error = %v", tc.input, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("readLine(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+
+	if _, err := readLine(bufio.NewReader(strings.NewReader(""))); err == nil {
+		t.Error("readLine on empty input should return an error")
+	}
+}
+
+func TestReviewingExecutor(t *testing.T) {
+	original, originalReader := ui, stdinReader
+	defer func() { ui, stdinReader = original, originalReader }()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+		calls   int
+	}{
+		{name: "approved", input: "y\n", calls: 1},
+		{name: "declined", input: "n\n", wantErr: errPromptDeclined, calls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ui = &console{out: &buf, plain: true}
+			stdinReader = bufio.NewReader(strings.NewReader(tt.input))
+
+			mock := &MockExecutor{response: "ok"}
+			reviewer := &reviewingExecutor{AIExecutor: mock}
+			_, err := reviewer.Execute("secret prompt")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(mock.prompts) != tt.calls {
+				t.Errorf("AI called %d times, want %d", len(mock.prompts), tt.calls)
+			}
+			if !strings.Contains(buf.String(), "secret prompt") || !strings.Contains(buf.String(), "~4 tokens") {
+				t.Errorf("prompt preview missing from output: %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestPromptPrinter(t *testing.T) {
+	var out strings.Builder
+	_, err := (&promptPrinter{out: &out}).Execute("生成プロンプト")
+	if !errors.Is(err, errPromptPrinted) {
+		t.Errorf("Execute() error = %v, want errPromptPrinted", err)
+	}
+	if out.String() != "生成プロンプト\n" {
+		t.Errorf("printed %q", out.String())
+	}
+}
+
+func TestConfirmAssumeAnswer(t *testing.T) {
+	original, originalReader, originalAnswer := ui, stdinReader, assumeAnswer
+	defer func() { ui, stdinReader, assumeAnswer = original, originalReader, originalAnswer }()
+	// Nothing can be read, so an answer must come from the flags
+	stdinReader = bufio.NewReader(strings.NewReader(""))
+
+	for answer, want := range map[string]bool{answerYes: true, answerNo: false} {
+		var buf bytes.Buffer
+		ui = &console{out: &buf, plain: true}
+		assumeAnswer = answer
+		got, err := confirm("Continue? [y/N]: ")
+		if err != nil || got != want {
+			t.Errorf("confirm() with --%s = %v, %v; want %v", answer, got, err, want)
+		}
+		if !strings.Contains(buf.String(), "Continue? [y/N]: "+answer+" (--"+answer+" flag)") {
+			t.Errorf("confirm() with --%s did not show the answer: %q", answer, buf.String())
+		}
+
+		reviewed, accepted, err := reviewCatchUpEntry("v1.0.0", "## [v1.0.0]", nil)
+		if err != nil || accepted != want || (reviewed != "") != want {
+			t.Errorf("reviewCatchUpEntry() with --%s = %q, %v, %v", answer, reviewed, accepted, err)
+		}
+	}
+}
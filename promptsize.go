@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// defaultMaxPromptTokens leaves headroom below the model context window for the response
+const defaultMaxPromptTokens = 150000
+
+// maxPromptTokens is the prompt budget; larger prompts are trimmed before sending (0 disables the guard)
+var maxPromptTokens = defaultMaxPromptTokens
+
+// promptBuilder renders a prompt from the diff, commit and staged inputs
+type promptBuilder func(diff, commits, stagedDiff string) string
+
+// fitPrompt builds the prompt followed by sections, the instructions and context appended to it, and
+// when the whole exceeds maxPromptTokens, trims the inputs step by step: per-file lists are collapsed
+// into per-directory summaries, then the file lists and finally the commit messages are truncated
+func fitPrompt(build promptBuilder, diff, commits, stagedDiff, sections string) string {
+	full := func(diff, commits, stagedDiff string) string {
+		return build(diff, commits, stagedDiff) + sections
+	}
+	prompt := full(diff, commits, stagedDiff)
+	original := estimateTokens(prompt)
+	if maxPromptTokens <= 0 || original <= maxPromptTokens {
+		return prompt
+	}
+
+	report := func(prompt string) string {
+		ui.Printf("✂️  Prompt trimmed from ~%d to ~%d tokens to fit --max-prompt-tokens %d\n",
+			original, estimateTokens(prompt), maxPromptTokens)
+		return prompt
+	}
+
+	diff = summarizeNameStatus(diff)
+	stagedDiff = summarizeNameStatus(stagedDiff)
+	if prompt = full(diff, commits, stagedDiff); estimateTokens(prompt) <= maxPromptTokens {
+		return report(prompt)
+	}
+
+	// The commit messages say more about the changes than the file lists, so those are cut first
+	budget := maxPromptTokens - estimateTokens(full("", commits, ""))
+	diffBudget := budget
+	if stagedDiff != "" {
+		diffBudget = budget / 2
+	}
+	diff = truncateLines(diff, diffBudget)
+	stagedDiff = truncateLines(stagedDiff, budget-estimateTokens(diff))
+	if prompt = full(diff, commits, stagedDiff); estimateTokens(prompt) <= maxPromptTokens {
+		return report(prompt)
+	}
+
+	budget = maxPromptTokens - estimateTokens(full(diff, "", stagedDiff))
+	commits = truncateLines(commits, budget)
+	return report(full(diff, commits, stagedDiff))
+}
+
+// summarizeNameStatus collapses `git diff --name-status` output into one line per directory with status counts
+func summarizeNameStatus(output string) string {
+	type dirStats struct {
+		files    int
+		statuses map[string]int
+	}
+	dirs := map[string]*dirStats{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		status := fields[0][:1]
+		dir := path.Dir(fields[len(fields)-1]) + "/"
+		if dirs[dir] == nil {
+			dirs[dir] = &dirStats{statuses: map[string]int{}}
+		}
+		dirs[dir].files++
+		dirs[dir].statuses[status]++
+	}
+
+	names := make([]string, 0, len(dirs))
+	for name := range dirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		stats := dirs[name]
+		codes := make([]string, 0, len(stats.statuses))
+		for code, n := range stats.statuses {
+			codes = append(codes, fmt.Sprintf("%s %d", code, n))
+		}
+		sort.Strings(codes)
+		lines = append(lines, fmt.Sprintf("%s: %d files (%s)", name, stats.files, strings.Join(codes, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateLines keeps leading lines of text within the token budget and notes how many were omitted
+func truncateLines(text string, budget int) string {
+	if text == "" || estimateTokens(text) <= budget {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines))
+	used := 0
+	for _, line := range lines {
+		cost := estimateTokens(line + "\n")
+		if used+cost > budget-16 {
+			break
+		}
+		kept = append(kept, line)
+		used += cost
+	}
+	return strings.Join(kept, "\n") + fmt.Sprintf("\n... (%d more lines omitted)", len(lines)-len(kept))
+}
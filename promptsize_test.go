@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeNameStatus(t *testing.T) {
+	input := "M\tpkg/a.go\nA\tpkg/b.go\nM\tpkg/c.go\nD\tREADME.md\nR100\told/x.go\tnew/x.go"
+	want := "./: 1 files (D 1)\nnew/: 1 files (R 1)\npkg/: 3 files (A 1, M 2)"
+
+	if got := summarizeNameStatus(input); got != want {
+		t.Errorf("summarizeNameStatus() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTruncateLines(t *testing.T) {
+	text := strings.Repeat("abc123 feat: something\n", 100)
+	got := truncateLines(text, 100)
+	if estimateTokens(got) > 100 {
+		t.Errorf("truncated text is over budget: ~%d tokens", estimateTokens(got))
+	}
+	if !strings.Contains(got, "more lines omitted") {
+		t.Errorf("truncated text does not note omission: %q", got)
+	}
+	if truncateLines("short", 100) != "short" {
+		t.Error("text within budget should be unchanged")
+	}
+}
+
+func TestFitPrompt(t *testing.T) {
+	original, originalMax := ui, maxPromptTokens
+	defer func() { ui, maxPromptTokens = original, originalMax }()
+	ui = &console{out: &bytes.Buffer{}, plain: true}
+
+	build := func(diff, commits, stagedDiff string) string {
+		return fmt.Sprintf("commits:\n%s\ndiff:\n%s\nstaged:\n%s", commits, diff, stagedDiff)
+	}
+
+	var diff strings.Builder
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&diff, "M\tinternal/pkg%d/file%d.go\n", i%3, i)
+	}
+	commits := strings.Repeat("abc123 feat: add a feature with a long description\n", 500)
+
+	maxPromptTokens = 0
+	if got := fitPrompt(build, diff.String(), commits, "", ""); got != build(diff.String(), commits, "") {
+		t.Error("guard disabled should not trim")
+	}
+
+	maxPromptTokens = 2000
+	sections := "\n- " + strings.Repeat("note ", 200)
+	got := fitPrompt(build, diff.String(), commits, "", sections)
+	if estimateTokens(got) > maxPromptTokens {
+		t.Errorf("prompt is ~%d tokens, want <= %d", estimateTokens(got), maxPromptTokens)
+	}
+	if !strings.HasSuffix(got, sections) {
+		t.Error("the appended sections should be kept")
+	}
+	if !strings.Contains(got, "abc123 feat: add a feature") {
+		t.Error("commit messages should be kept as far as the budget allows")
+	}
+
+	// The file list is cut before the commit messages
+	diff.Reset()
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&diff, "M\tinternal/pkg%d/file.go\n", i)
+	}
+	commits = strings.Repeat("abc123 feat: add a feature with a long description\n", 20)
+	got = fitPrompt(build, diff.String(), commits, "", sections)
+	if estimateTokens(got) > maxPromptTokens {
+		t.Errorf("prompt is ~%d tokens, want <= %d", estimateTokens(got), maxPromptTokens)
+	}
+	if !strings.Contains(got, commits) || !strings.HasSuffix(got, sections) {
+		t.Error("every commit message and the sections should be kept")
+	}
+	if !strings.Contains(got, "internal/pkg0/: ") || !strings.Contains(got, "more lines omitted") {
+		t.Errorf("the directory summary should be truncated: %q", got)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// currentPromptVersion is the version of the built-in prompts used by new projects. A change to
+// the prompts that alters the style of generated entries adds a version to promptVersions and
+// keeps the previous wording behind genOpts.PromptVersion, so a project can stay on it.
+const currentPromptVersion = 1
+
+// promptVersions describe the prompt versions this build can generate with
+var promptVersions = map[int]string{
+	1: "Keep a Changelog entries with Japanese items",
+}
+
+// promptVersionPattern finds the prompt version recorded in a metadata comment
+var promptVersionPattern = regexp.MustCompile(`\bprompt-version=(\d+)\b`)
+
+// supportedPromptVersions lists the known prompt versions for messages, e.g. "1, 2"
+func supportedPromptVersions() string {
+	versions := make([]int, 0, len(promptVersions))
+	for v := range promptVersions {
+		versions = append(versions, v)
+	}
+	slices.Sort(versions)
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = strconv.Itoa(v)
+	}
+	return strings.Join(names, ", ")
+}
+
+// recordedPromptVersion returns the prompt version of the newest entry with generation metadata
+// in content, or 0 when no entry has metadata. Metadata written before prompts were versioned
+// has no version and counts as version 1.
+func recordedPromptVersion(content string) int {
+	for _, line := range strings.Split(normalizeNewlines(content), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), metadataCommentPrefix) {
+			continue
+		}
+		if m := promptVersionPattern.FindStringSubmatch(line); m != nil {
+			v, _ := strconv.Atoi(m[1])
+			return v
+		}
+		return 1
+	}
+	return 0
+}
+
+// resolvePromptVersion returns the prompt version to generate with: the --prompt-version flag when
+// set (requested > 0), otherwise the version recorded in the changelog so that upgrading the tool
+// does not change the style of a project's entries, otherwise the current version. recorded
+// reports whether the version came from the changelog.
+func resolvePromptVersion(requested int, changelogContent string) (version int, recorded bool, err error) {
+	if requested > 0 {
+		if _, ok := promptVersions[requested]; !ok {
+			return 0, false, &ConfigError{Err: fmt.Errorf("unknown --prompt-version %d: this build supports %s", requested, supportedPromptVersions())}
+		}
+		return requested, false, nil
+	}
+	v := recordedPromptVersion(changelogContent)
+	if v == 0 {
+		return currentPromptVersion, false, nil
+	}
+	if _, ok := promptVersions[v]; !ok {
+		return 0, false, &ConfigError{Err: fmt.Errorf("the changelog was generated with prompt version %d, which this build does not support; upgrade changelog-update or pass --prompt-version %d", v, currentPromptVersion)}
+	}
+	return v, true, nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestRecordedPromptVersion(t *testing.T) {
+	testCases := []struct {
+		content string
+		want    int
+	}{
+		{"# Changelog\n\n## [v1.0.0] - 2025-01-01\n\n- item\n", 0},
+		{"## [v1.1.0]\n\n- item\n\n<!-- changelog-update: tool=1.4.0 model=claude prompt=sha256:ab prompt-version=3 range=v1.0.0..v1.1.0 -->\n\n" +
+			"## [v1.0.0]\n\n<!-- changelog-update: tool=1.3.0 model=claude prompt=sha256:cd prompt-version=2 range=v1.0.0 -->\n", 3},
+		{"## [v1.0.0]\n\n- item\n\n<!-- changelog-update: tool=1.2.0 model=claude prompt=sha256:ab range=v1.0.0 -->\n", 1},
+	}
+	for _, tc := range testCases {
+		if got := recordedPromptVersion(tc.content); got != tc.want {
+			t.Errorf("recordedPromptVersion(%q) = %d, want %d", tc.content, got, tc.want)
+		}
+	}
+}
+
+func TestResolvePromptVersion(t *testing.T) {
+	legacy := "## [v1.0.0]\n\n<!-- changelog-update: tool=1.2.0 model=claude prompt=sha256:ab range=v1.0.0 -->\n"
+	newer := "## [v1.0.0]\n\n<!-- changelog-update: tool=9.0.0 model=claude prompt=sha256:ab prompt-version=99 range=v1.0.0 -->\n"
+
+	if v, recorded, err := resolvePromptVersion(0, ""); err != nil || v != currentPromptVersion || recorded {
+		t.Errorf("resolvePromptVersion(0, empty) = %d, %v, %v", v, recorded, err)
+	}
+	if v, recorded, err := resolvePromptVersion(0, legacy); err != nil || v != 1 || !recorded {
+		t.Errorf("resolvePromptVersion(0, legacy) = %d, %v, %v", v, recorded, err)
+	}
+	if _, _, err := resolvePromptVersion(0, newer); exitCode(err) != ExitConfig {
+		t.Errorf("resolvePromptVersion(0, newer) = %v, want a configuration error", err)
+	}
+	if v, _, err := resolvePromptVersion(currentPromptVersion, newer); err != nil || v != currentPromptVersion {
+		t.Errorf("resolvePromptVersion(current, newer) = %d, %v; the flag should win", v, err)
+	}
+	if _, _, err := resolvePromptVersion(99, ""); exitCode(err) != ExitConfig {
+		t.Errorf("resolvePromptVersion(99) = %v, want a configuration error", err)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// genericBulletPattern matches bullets that say nothing specific about the change
+var genericBulletPattern = regexp.MustCompile(`(?i)^(various|several|misc(ellaneous)?|minor|some|general|other)\s+(bug\s*)?(fix(es)?|improvements?|changes|updates|tweaks)\.?$|^(様々|さまざま|各種|いくつか|細かな|細かい|軽微|その他|複数)の?(な)?(バグ|不具合|改善|修正|変更|調整|更新)+(を)?(修正|改善|実施|行い)?(しました|しています|です)?。?$|^(バグ|不具合)(を)?修正(しました)?。?$`)
+
+// conventionalTypePattern matches the conventional commit type of a "<hash> <subject>" line
+var conventionalTypePattern = regexp.MustCompile(`^\S+\s+(\w+)(\([^)]*\))?!?:`)
+
+// filePathPattern matches words in a bullet that look like file names, e.g. "main.go" or "src/app.ts"
+var filePathPattern = regexp.MustCompile("(?:^|[\\s`(（「])([\\w./-]*\\w\\.(?:go|js|jsx|ts|tsx|py|rb|rs|java|kt|swift|c|h|cpp|cs|php|json|ya?ml|toml|md|sh|sql|css|html))\\b")
+
+// commitSections maps conventional commit types to the section their changes are expected in
+var commitSections = map[string]string{"feat": "追加", "fix": "修正"}
+
+// entryQualityWarnings lists heuristic signs that a generated entry is low quality: generic bullets,
+// files that are not in the diff, sections missing for the commit types present, and a heading
+// with another version or date than expected
+func entryQualityWarnings(entry, version string, date time.Time, diff, commits string) []string {
+	var warnings []string
+	lines := strings.Split(entry, "\n")
+
+	if got, ok := changelogHeading.Version(lines[0]); !ok || !sameVersion(got, version) {
+		warnings = append(warnings, fmt.Sprintf("the heading is not for version %s: %s", version, lines[0]))
+	}
+	if got, ok := changelogHeading.Date(lines[0]); ok && changelogHeading.FormatDate(got) != changelogHeading.FormatDate(date) {
+		warnings = append(warnings, fmt.Sprintf("the heading date %s is not the release date %s", changelogHeading.FormatDate(got), changelogHeading.FormatDate(date)))
+	}
+
+	changed := map[string]bool{}
+	for _, line := range strings.Split(diff, "\n") {
+		fields := strings.Split(line, "\t")
+		for _, file := range fields[1:] {
+			changed[file] = true
+			changed[path.Base(file)] = true
+		}
+	}
+	for _, bullet := range topLevelBullets(entry) {
+		if genericBulletPattern.MatchString(strings.TrimSpace(bulletReferencePattern.ReplaceAllString(bullet, ""))) {
+			warnings = append(warnings, fmt.Sprintf("too generic: %q", bullet))
+		}
+		if diff == "" {
+			continue
+		}
+		for _, m := range filePathPattern.FindAllStringSubmatch(bullet, -1) {
+			if file := strings.TrimPrefix(m[1], "./"); !changed[file] && !changed[path.Base(file)] {
+				warnings = append(warnings, fmt.Sprintf("mentions %s, which is not in the diff", file))
+			}
+		}
+	}
+
+	sections := map[string]bool{}
+	for _, line := range lines[1:] {
+		if m := htmlHeadingPattern.FindStringSubmatch(line); m != nil {
+			sections[strings.TrimSpace(m[2])] = true
+		}
+	}
+	counts := map[string]int{}
+	for _, line := range strings.Split(commits, "\n") {
+		if m := conventionalTypePattern.FindStringSubmatch(line); m != nil {
+			counts[strings.ToLower(m[1])]++
+		}
+	}
+	for _, commitType := range []string{"feat", "fix"} {
+		if section := commitSections[commitType]; counts[commitType] > 0 && !sections[section] {
+			warnings = append(warnings, fmt.Sprintf("%d %s: commit(s) but no %s section", counts[commitType], commitType, section))
+		}
+	}
+	return warnings
+}
+
+// printQualityWarnings shows the quality warnings for an entry below its preview
+func printQualityWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	ui.Printf("\n⚠️  The entry may need attention (%d warning(s)):\n", len(warnings))
+	for _, w := range warnings {
+		ui.Printf("  - %s\n", w)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEntryQualityWarnings(t *testing.T) {
+	date := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	diff := "M\tcmd/export.go\nA\tREADME.md"
+	commits := "abc1234 feat: add CSV export\ndef5678 fix: handle empty tags"
+
+	good := "## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- `export.go` にCSVエクスポートを追加 (abc1234)\n\n### 修正\n\n- タグがない場合に終了する不具合を修正"
+	if got := entryQualityWarnings(good, "v1.2.0", date, diff, commits); len(got) != 0 {
+		t.Errorf("entryQualityWarnings(good) = %q, want none", got)
+	}
+
+	bad := "## [v1.1.0] - 2025-08-30\n\n### 追加\n\n- CSVエクスポートを追加（config.yaml で設定可能）\n- 様々なバグを修正"
+	got := strings.Join(entryQualityWarnings(bad, "v1.2.0", date, diff, commits), "\n")
+	for _, want := range []string{"not for version v1.2.0", "2025-08-30 is not the release date 2025-09-01", "config.yaml", "too generic: \"様々なバグを修正\"", "1 fix: commit(s) but no 修正 section"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("entryQualityWarnings(bad) =\n%s\nwant a warning containing %q", got, want)
+		}
+	}
+}
+
+func TestGenericBulletPattern(t *testing.T) {
+	for _, bullet := range []string{"Various bug fixes", "minor improvements.", "細かな改善", "軽微な不具合を修正しました", "バグ修正"} {
+		if !genericBulletPattern.MatchString(bullet) {
+			t.Errorf("%q was not recognized as generic", bullet)
+		}
+	}
+	for _, bullet := range []string{"ログイン時のタイムアウトを修正", "Fix crash when no tags exist"} {
+		if genericBulletPattern.MatchString(bullet) {
+			t.Errorf("%q was recognized as generic", bullet)
+		}
+	}
+}
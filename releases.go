@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// releaseAction is a GitHub Release change planned by sync-releases
+type releaseAction struct {
+	Tag    string
+	Create bool
+	Body   string
+}
+
+// ghRelease is the subset of `gh release list` output used for syncing
+type ghRelease struct {
+	TagName string `json:"tagName"`
+}
+
+func syncReleasesCommand(args []string) error {
+	fs := flag.NewFlagSet("sync-releases", flag.ContinueOnError)
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	repo := fs.String("repo", "", "GitHub repository (OWNER/REPO), defaults to the current repository")
+	dryRun := fs.Bool("dry-run", false, "Show planned changes without modifying releases")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	entries, err := readChangelogEntries(repoPath(*changelogFile))
+	if err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+
+	tags, err := getAllTags()
+	if err != nil {
+		return fmt.Errorf("failed to get all tags: %w", err)
+	}
+
+	releases, err := fetchReleaseBodies(*repo)
+	if err != nil {
+		return err
+	}
+
+	actions := planReleaseSync(entries, releases, tags)
+	if len(actions) == 0 {
+		ui.Println("✅ All GitHub Releases are up to date with the changelog.")
+		return nil
+	}
+
+	for _, action := range actions {
+		verb, planned := "Updating", "update"
+		if action.Create {
+			verb, planned = "Creating", "create"
+		}
+		if *dryRun {
+			ui.Printf("📝 Would %s release %s\n", ui.translate(planned), action.Tag)
+			continue
+		}
+		ui.Printf("🔧 %s release %s...\n", ui.translate(verb), action.Tag)
+		if err := applyReleaseAction(*repo, action); err != nil {
+			return err
+		}
+	}
+
+	if !*dryRun {
+		ui.Printf("✅ Synced %d release(s).\n", len(actions))
+	}
+	return nil
+}
+
+// planReleaseSync decides which releases to create or update so that their bodies match the changelog
+func planReleaseSync(entries []changelogEntry, releases map[string]string, tags []string) []releaseAction {
+	tagSet := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tagSet[tag] = true
+	}
+
+	var actions []releaseAction
+	for _, entry := range entries {
+		if !tagSet[entry.Version] || entry.Body == "" {
+			continue
+		}
+		existing, ok := releases[entry.Version]
+		switch {
+		case !ok:
+			actions = append(actions, releaseAction{Tag: entry.Version, Create: true, Body: entry.Body})
+		case strings.TrimSpace(normalizeNewlines(existing)) != entry.Body:
+			actions = append(actions, releaseAction{Tag: entry.Version, Body: entry.Body})
+		}
+	}
+	return actions
+}
+
+// ghCommand builds a gh command, targeting repo when it is set
+func ghCommand(repo string, args ...string) *exec.Cmd {
+	if repo != "" {
+		args = append(args, "--repo", repo)
+	}
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = gitDir
+	return cmd
+}
+
+// fetchReleaseBodies returns the body of every existing GitHub Release keyed by tag
+func fetchReleaseBodies(repo string) (map[string]string, error) {
+	output, err := ghCommand(repo, "release", "list", "--limit", "1000", "--json", "tagName").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GitHub Releases (is gh installed and authenticated?): %w", err)
+	}
+
+	var list []ghRelease
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse release list: %w", err)
+	}
+
+	bodies := make(map[string]string, len(list))
+	for _, release := range list {
+		output, err := ghCommand(repo, "release", "view", release.TagName, "--json", "body", "--jq", ".body").Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read release %s: %w", release.TagName, err)
+		}
+		bodies[release.TagName] = strings.TrimSuffix(string(output), "\n")
+	}
+	return bodies, nil
+}
+
+// applyReleaseAction creates or edits a GitHub Release with the changelog body
+func applyReleaseAction(repo string, action releaseAction) error {
+	args := []string{"release", "edit", action.Tag, "--notes-file", "-"}
+	if action.Create {
+		args = []string{"release", "create", action.Tag, "--verify-tag", "--title", action.Tag, "--notes-file", "-"}
+	}
+	cmd := ghCommand(repo, args...)
+	cmd.Stdin = strings.NewReader(action.Body)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to sync release %s: %w\nOutput: %s", action.Tag, err, output)
+	}
+	return nil
+}
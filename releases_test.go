@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanReleaseSync(t *testing.T) {
+	entries := []changelogEntry{
+		{Version: "v1.3.0", Body: "### 追加\n\n- Unreleased tag"},
+		{Version: "v1.2.0", Body: "### 追加\n\n- New"},
+		{Version: "v1.1.0", Body: "### 修正\n\n- Fixed"},
+		{Version: "v1.0.0", Body: "### 追加\n\n- Same"},
+		{Version: "v0.9.0", Body: ""},
+	}
+	releases := map[string]string{
+		"v1.1.0": "old body",
+		"v1.0.0": "### 追加\r\n\r\n- Same\r\n",
+	}
+	tags := []string{"v0.9.0", "v1.0.0", "v1.1.0", "v1.2.0"}
+
+	want := []releaseAction{
+		{Tag: "v1.2.0", Create: true, Body: "### 追加\n\n- New"},
+		{Tag: "v1.1.0", Body: "### 修正\n\n- Fixed"},
+	}
+
+	if got := planReleaseSync(entries, releases, tags); !reflect.DeepEqual(got, want) {
+		t.Errorf("planReleaseSync() = %+v, want %+v", got, want)
+	}
+}
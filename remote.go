@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// gitRemote is the remote tags are fetched from, web links are derived from and branches are pushed to
+var gitRemote = "origin"
+
+var scpLikeRemote = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// remoteWebURL returns the browsable https URL of a git remote, or "" when it cannot be determined
+func remoteWebURL(remote string) string {
+	output, err := gitCommand("remote", "get-url", remote).Output()
+	if err != nil {
+		return ""
+	}
+	return webURLFromRemote(strings.TrimSpace(string(output)))
+}
+
+// webURLFromRemote converts https, ssh and scp-like git remote URLs to an https web URL
+func webURLFromRemote(remoteURL string) string {
+	u := strings.TrimSuffix(strings.TrimSuffix(remoteURL, "/"), ".git")
+	switch {
+	case strings.HasPrefix(u, "https://"), strings.HasPrefix(u, "http://"):
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return ""
+		}
+		parsed.User = nil
+		return parsed.String()
+	case strings.HasPrefix(u, "ssh://"):
+		u = strings.TrimPrefix(u, "ssh://")
+		if at := strings.Index(u, "@"); at != -1 {
+			u = u[at+1:]
+		}
+		host, rest, _ := strings.Cut(u, "/")
+		host, _, _ = strings.Cut(host, ":")
+		return "https://" + host + "/" + rest
+	case scpLikeRemote.MatchString(u):
+		m := scpLikeRemote.FindStringSubmatch(u)
+		return "https://" + m[1] + "/" + m[2]
+	}
+	return ""
+}
+
+// commitURL returns the web URL of a commit on the hosting service
+func commitURL(repoURL, hash string) string {
+	if strings.Contains(repoURL, "gitlab") {
+		return repoURL + "/-/commit/" + hash
+	}
+	return repoURL + "/commit/" + hash
+}
+
+// pullRequestURL returns the web URL of a pull request (merge request on GitLab)
+func pullRequestURL(repoURL, number string) string {
+	if strings.Contains(repoURL, "gitlab") {
+		return repoURL + "/-/merge_requests/" + number
+	}
+	return repoURL + "/pull/" + number
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// remoteRepoSkipFlags are the flags handled by the --repo run itself and not passed to the run in the clone
+var remoteRepoSkipFlags = map[string]bool{"repo": true, "push-branch": true, "summary-json": true, "C": true, "remote": true}
+
+// remoteRepoArgs rebuilds the flags set on the command line for the run inside the clone at dir,
+// writing its summary to summaryFile; quiet adds --quiet so the run prints no status messages or
+// next steps meant for a clone that is deleted afterwards
+func remoteRepoArgs(fs *flag.FlagSet, dir, summaryFile string, quiet bool) []string {
+	args := []string{"-C", dir}
+	fs.Visit(func(f *flag.Flag) {
+		if !remoteRepoSkipFlags[f.Name] {
+			args = append(args, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	args = append(args, "--summary-json="+summaryFile)
+	if quiet {
+		args = append(args, "--quiet")
+	}
+	return append(args, fs.Args()...)
+}
+
+// remoteRepoEnv returns environ without the CHANGELOG_UPDATE_ variables: remoteRepoArgs already
+// passes the flags they set, and flags such as --repo must not apply to the run in the clone again
+func remoteRepoEnv(environ []string) []string {
+	return slices.DeleteFunc(slices.Clone(environ), func(v string) bool { return strings.HasPrefix(v, envFlagPrefix) })
+}
+
+// remoteBranchCommitMessage is the commit message for the changelog update pushed with --push-branch
+func remoteBranchCommitMessage(summary *runSummary) string {
+	switch {
+	case summary.NewTag != "":
+		return releaseCommitMessage(summary.NewTag)
+	case len(summary.CatchUpTags) > 0:
+		return releaseCommitMessage(strings.Join(summary.CatchUpTags, ", "))
+	}
+	return "docs: update changelog"
+}
+
+// remoteRunEntries returns the entries added by the run in the clone: those of the catch-up tags,
+// read back from the changelog, followed by the generated entry
+func remoteRunEntries(summary *runSummary, changelogFile string) string {
+	var parts []string
+	if len(summary.CatchUpTags) > 0 {
+		entries, _ := readChangelogEntries(changelogFile)
+		for _, e := range entries {
+			if slices.ContainsFunc(summary.CatchUpTags, func(tag string) bool { return sameVersion(tag, e.Version) }) {
+				parts = append(parts, e.Markdown())
+			}
+		}
+	}
+	if entry := strings.TrimSpace(summary.Entry); entry != "" {
+		parts = append(parts, entry)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// runRemoteRepo clones repoURL into a temporary directory, runs the update there with the same flags
+// and then prints the entry or, with pushBranch, pushes the updated changelog to that branch. The
+// clone is partial rather than shallow: it skips file contents until they are needed but keeps the
+// whole history, which the commits between tags are read from. It returns the exit code.
+func runRemoteRepo(repoURL, pushBranch, summaryPath string) int {
+	printEntry := pushBranch == ""
+	if printEntry {
+		// Keep stdout for the entry alone so it can be piped
+		ui.out = os.Stderr
+		ui.plain = !isTerminal(os.Stderr)
+		ui.color = colorEnabled(os.Stderr)
+	}
+	dir, err := os.MkdirTemp("", "changelog-update-")
+	if err != nil {
+		ui.Printf("❌ Error: failed to create a temporary directory: %v\n", err)
+		return ExitFailure
+	}
+	defer os.RemoveAll(dir)
+
+	ui.Printf("📥 Cloning %s...\n", repoURL)
+	if err := runGit("clone", "--quiet", "--filter=blob:none", repoURL, dir); err != nil {
+		ui.Printf("❌ Error: failed to clone %s: %v\n", repoURL, err)
+		return exitCode(err)
+	}
+	gitDir = dir
+
+	executable, err := os.Executable()
+	if err != nil {
+		ui.Printf("❌ Error: %v\n", err)
+		return ExitFailure
+	}
+	summaryFile := dir + ".json"
+	defer os.Remove(summaryFile)
+
+	cmd := exec.Command(executable, remoteRepoArgs(flag.CommandLine, dir, summaryFile, printEntry)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if printEntry {
+		// Questions and warnings of the run go to stderr with the status messages
+		cmd.Stdout = os.Stderr
+	}
+	cmd.Env = remoteRepoEnv(os.Environ())
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		ui.Printf("❌ Error: %v\n", err)
+		return ExitFailure
+	}
+
+	data, err := os.ReadFile(summaryFile)
+	if err != nil {
+		ui.Printf("❌ Error: failed to read the run summary: %v\n", err)
+		return ExitFailure
+	}
+	var summary runSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		ui.Printf("❌ Error: failed to parse the run summary: %v\n", err)
+		return ExitFailure
+	}
+	if summaryPath != "" {
+		if err := writeSummary(summaryPath, &summary); err != nil {
+			ui.Printf("⚠️  Warning: Failed to write summary: %v\n", err)
+		}
+	}
+	if !summary.ChangelogModified {
+		return summary.ExitCode
+	}
+
+	if printEntry {
+		fmt.Println(remoteRunEntries(&summary, repoPath(filepath.FromSlash(flag.Lookup("changelog").Value.String()))))
+		return summary.ExitCode
+	}
+
+	message := remoteBranchCommitMessage(&summary)
+	for _, args := range [][]string{
+		{"checkout", "--quiet", "-B", pushBranch},
+		{"add", "--all"},
+		{"commit", "--quiet", "-m", message},
+		{"push", "--quiet", "origin", pushBranch},
+	} {
+		if err := runGit(args...); err != nil {
+			ui.Printf("❌ Error: failed to push %s: %v\n", pushBranch, err)
+			return exitCode(err)
+		}
+	}
+	ui.Printf("🚀 Pushed %q to branch %s of %s\n", message, pushBranch, repoURL)
+	return summary.ExitCode
+}
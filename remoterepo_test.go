@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+)
+
+func TestRemoteRepoArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("repo", "", "")
+	fs.String("push-branch", "", "")
+	fs.String("summary-json", "", "")
+	fs.String("tag", "", "")
+	fs.Bool("yes", false, "")
+	fs.String("model", "claude", "")
+	if err := fs.Parse([]string{"--repo", "https://example.com/org/project.git", "--tag", "v1.2.0", "--yes", "--push-branch", "changelog", "--summary-json", "out.json"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := remoteRepoArgs(fs, "/tmp/clone", "/tmp/clone.json", false)
+	want := []string{"-C", "/tmp/clone", "--tag=v1.2.0", "--yes=true", "--summary-json=/tmp/clone.json"}
+	if !slices.Equal(got, want) {
+		t.Errorf("remoteRepoArgs() = %q, want %q", got, want)
+	}
+
+	// Without --push-branch only the entry is printed, so the run in the clone is quiet
+	got = remoteRepoArgs(fs, "/tmp/clone", "/tmp/clone.json", true)
+	if want := append(want, "--quiet"); !slices.Equal(got, want) {
+		t.Errorf("remoteRepoArgs() printing the entry = %q, want %q", got, want)
+	}
+}
+
+func TestRemoteRepoEnv(t *testing.T) {
+	environ := []string{"HOME=/home/me", "CHANGELOG_UPDATE_REPO=https://example.com/org/project.git", "CHANGELOG_UPDATE_PUSH_BRANCH=changelog", "CHANGELOG_NOTIFY_WEBHOOK=https://hooks.example.com"}
+	want := []string{"HOME=/home/me", "CHANGELOG_NOTIFY_WEBHOOK=https://hooks.example.com"}
+	if got := remoteRepoEnv(environ); !slices.Equal(got, want) {
+		t.Errorf("remoteRepoEnv() = %q, want %q", got, want)
+	}
+	if len(environ) != 4 {
+		t.Errorf("remoteRepoEnv() changed its argument to %q", environ)
+	}
+}
+
+func TestRemoteBranchCommitMessage(t *testing.T) {
+	tests := []struct {
+		summary runSummary
+		want    string
+	}{
+		{runSummary{NewTag: "v1.2.0"}, "docs: update changelog for v1.2.0"},
+		{runSummary{CatchUpTags: []string{"v1.0.0", "v1.1.0"}}, "docs: update changelog for v1.0.0, v1.1.0"},
+		{runSummary{}, "docs: update changelog"},
+	}
+	for _, tt := range tests {
+		if got := remoteBranchCommitMessage(&tt.summary); got != tt.want {
+			t.Errorf("remoteBranchCommitMessage(%+v) = %q, want %q", tt.summary, got, tt.want)
+		}
+	}
+}
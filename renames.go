@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renameDetectionArgs make git diff --name-status report a moved file as "R100<TAB>old<TAB>new"
+// and a copied one as "C075<TAB>src<TAB>dst" instead of a deletion and an addition
+var renameDetectionArgs = []string{"-M", "-C"}
+
+// nameStatusArgs returns the git diff arguments for a name-status list with renames and copies
+func nameStatusArgs(args ...string) []string {
+	return append(append([]string{"diff", "--name-status"}, renameDetectionArgs...), args...)
+}
+
+// renameNotes explains the rename and copy lines of a name-status list, so moved files are not
+// described as removed and added features
+func renameNotes(diff string) []string {
+	renames, copies := 0, 0
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "R") && strings.Count(line, "\t") == 2:
+			renames++
+		case strings.HasPrefix(line, "C") && strings.Count(line, "\t") == 2:
+			copies++
+		}
+	}
+	var kinds []string
+	if renames > 0 {
+		kinds = append(kinds, fmt.Sprintf("`R100 旧パス 新パス` はファイルの移動・名前の変更（%d件）", renames))
+	}
+	if copies > 0 {
+		kinds = append(kinds, fmt.Sprintf("`C075 元のパス 新しいパス` はファイルのコピー（%d件）", copies))
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+	return []string{"差分情報の" + strings.Join(kinds, "、") + "で、数字は内容の類似度（%）です。移動・名前の変更を機能の削除や追加として記載せず、利用者に影響する場合（設定ファイルやコマンドの場所が変わった場合など）のみ変更として記載してください"}
+}
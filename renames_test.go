@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenameNotes(t *testing.T) {
+	if notes := renameNotes("M\tmain.go\nA\tnew.go"); notes != nil {
+		t.Errorf("renameNotes() without renames = %q", notes)
+	}
+	notes := renameNotes("R100\tcmd/old.go\tcmd/new.go\nR087\ta.go\tb.go\nM\tmain.go")
+	if len(notes) != 1 || !strings.Contains(notes[0], "名前の変更（2件）") || strings.Contains(notes[0], "コピー") {
+		t.Errorf("renameNotes() = %q", notes)
+	}
+	notes = renameNotes("C075\tsrc.go\tdst.go")
+	if len(notes) != 1 || !strings.Contains(notes[0], "コピー（1件）") || strings.Contains(notes[0], "名前の変更（") {
+		t.Errorf("renameNotes() with a copy = %q", notes)
+	}
+}
+
+func TestGetGitDiffRenames(t *testing.T) {
+	savedFilter := aiFilter
+	defer func() { aiFilter = savedFilter }()
+	newTestRepo(t)
+	aiFilter = newPathFilter(nil, nil)
+
+	content := strings.Repeat("package main\n\n// a line that makes the file long enough to be recognized\n", 10)
+	testCommit(t, "change", map[string]string{"old.go": content})
+	testGit(t, "tag", "v1.0.0")
+	if err := os.Rename(filepath.Join(gitDir, "old.go"), filepath.Join(gitDir, "new.go")); err != nil {
+		t.Fatal(err)
+	}
+	testCommit(t, "change", nil)
+
+	diff, err := getGitDiff("v1.0.0", gitRefHEAD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "R100\told.go\tnew.go" {
+		t.Errorf("getGitDiff() = %q, want a rename", diff)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	inlineBoldPattern = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+	inlineLinkPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	listItemPattern   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+)
+
+// renderMarkdown renders a CHANGELOG entry for terminal display, styling it with ANSI codes when color is true
+func renderMarkdown(entry string, color bool) string {
+	style := func(code, text string) string {
+		if !color {
+			return text
+		}
+		return code + text + ansiReset
+	}
+
+	lines := strings.Split(entry, "\n")
+	rendered := make([]string, 0, len(lines))
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "### "):
+			rendered = append(rendered, "  "+style(ansiBold+ansiMagenta, "▌ "+renderInline(strings.TrimPrefix(line, "### "), color)))
+		case strings.HasPrefix(line, "## "):
+			title := renderInline(strings.TrimPrefix(line, "## "), color)
+			rendered = append(rendered, style(ansiBold+ansiCyan, title))
+		case listItemPattern.MatchString(line):
+			m := listItemPattern.FindStringSubmatch(line)
+			bullet := "•"
+			if len(m[1]) > 0 {
+				bullet = "◦"
+			}
+			rendered = append(rendered, "    "+m[1]+bullet+" "+renderInline(m[2], color))
+		default:
+			rendered = append(rendered, renderInline(line, color))
+		}
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// renderInline renders bold text, inline code and links within a single line
+func renderInline(text string, color bool) string {
+	if !color {
+		text = inlineBoldPattern.ReplaceAllString(text, "$1")
+		text = inlineCodePattern.ReplaceAllString(text, "$1")
+		return inlineLinkPattern.ReplaceAllString(text, "$1 ($2)")
+	}
+	text = inlineBoldPattern.ReplaceAllString(text, ansiBold+"$1"+ansiReset)
+	text = inlineCodePattern.ReplaceAllString(text, ansiYellow+"$1"+ansiReset)
+	return inlineLinkPattern.ReplaceAllString(text, "$1 ("+ansiCyan+"$2"+ansiReset+")")
+}
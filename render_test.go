@@ -0,0 +1,25 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdownWithoutColor(t *testing.T) {
+	entry := "## [v1.0.0] - 2025-08-27\n\n### 追加\n\n- **新機能** `--flag` を追加\n  - 詳細は [README](https://example.com) を参照"
+	want := "[v1.0.0] - 2025-08-27\n\n  ▌ 追加\n\n    • 新機能 --flag を追加\n      ◦ 詳細は README (https://example.com) を参照"
+
+	if got := renderMarkdown(entry, false); got != want {
+		t.Errorf("renderMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdownWithColor(t *testing.T) {
+	got := renderMarkdown("## [v1.0.0] - 2025-08-27\n- **bold** item", true)
+	if !strings.Contains(got, ansiBold+ansiCyan+"[v1.0.0] - 2025-08-27"+ansiReset) {
+		t.Errorf("heading not styled: %q", got)
+	}
+	if !strings.Contains(got, "• "+ansiBold+"bold"+ansiReset+" item") {
+		t.Errorf("inline bold not styled: %q", got)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Answers accepted when reviewing a catch-up entry
+const (
+	reviewAccept     = "a"
+	reviewEdit       = "e"
+	reviewSkip       = "s"
+	reviewRegenerate = "r"
+)
+
+// editText opens text in $VISUAL or $EDITOR (vi when neither is set) and returns the saved content
+var editText = func(text string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	file, err := os.CreateTemp("", "changelog-entry-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a file for editing: %w", err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(text + "\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create a file for editing: %w", err)
+	}
+
+	// The editor may come with arguments, e.g. "code --wait"
+	args := append(strings.Fields(editor), file.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", editor, err)
+	}
+	edited, err := readTextFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read the edited entry: %w", err)
+	}
+	return strings.TrimSpace(normalizeNewlines(edited)), nil
+}
+
+// reviewAnswer asks how to handle an entry until a known answer is given; "y" counts as accept and
+// an empty answer as skip, so the default never writes an entry. --yes accepts and --no skips.
+func reviewAnswer(tag string) (string, error) {
+	for {
+		question := ui.Sprintf("\nAdd the entry for %s? [a]ccept / [e]dit / [s]kip / [r]egenerate (default: skip): ", tag)
+		switch assumeAnswer {
+		case answerYes:
+			assumedAnswer(question)
+			return reviewAccept, nil
+		case answerNo:
+			assumedAnswer(question)
+			return reviewSkip, nil
+		}
+		ui.Print(question)
+		response, err := readLine(stdinReader)
+		if err != nil {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+		switch response = strings.ToLower(response); response {
+		case reviewAccept, "accept", responseY, responseYes:
+			return reviewAccept, nil
+		case reviewEdit, "edit":
+			return reviewEdit, nil
+		case "", reviewSkip, "skip", "n", "no":
+			return reviewSkip, nil
+		case reviewRegenerate, "regenerate":
+			return reviewRegenerate, nil
+		}
+		ui.Printf("❓ Unknown answer %q\n", response)
+	}
+}
+
+// reviewCatchUpEntry shows the generated entry of tag and lets the user accept, edit, skip or
+// regenerate it. It returns the entry to write and whether it was accepted.
+func reviewCatchUpEntry(tag, entry string, regenerate func() (string, error)) (string, bool, error) {
+	for {
+		ui.Printf("\n📝 Entry for %s:\n", tag)
+		ui.Separator()
+		ui.Entry(entry)
+		ui.Separator()
+
+		answer, err := reviewAnswer(tag)
+		if err != nil {
+			return "", false, err
+		}
+		switch answer {
+		case reviewAccept:
+			return entry, true, nil
+		case reviewSkip:
+			ui.Printf("⏭️  Skipped %s\n", tag)
+			return "", false, nil
+		case reviewEdit:
+			edited, err := editText(entry)
+			switch {
+			case err != nil:
+				ui.Printf("⚠️  Warning: %v\n", err)
+			case edited == "":
+				ui.Println("⚠️  Warning: The edited entry is empty; keeping the previous one.")
+			default:
+				entry = edited
+			}
+		case reviewRegenerate:
+			regenerated, err := regenerate()
+			if err != nil {
+				ui.Printf("⚠️  Warning: %v\n", err)
+				continue
+			}
+			entry = regenerated
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReviewCatchUpEntry(t *testing.T) {
+	original, originalReader, originalEdit := ui, stdinReader, editText
+	defer func() { ui, stdinReader, editText = original, originalReader, originalEdit }()
+	editText = func(text string) (string, error) { return text + "\n- 手で追記", nil }
+
+	tests := []struct {
+		name         string
+		input        string
+		regenerate   func() (string, error)
+		want         string
+		wantAccepted bool
+	}{
+		{name: "accept", input: "a\n", want: "## [v1.0.0]\n\n- 生成", wantAccepted: true},
+		{name: "y accepts", input: "y\n", want: "## [v1.0.0]\n\n- 生成", wantAccepted: true},
+		{name: "default skips", input: "\n"},
+		{name: "unknown answer asks again", input: "x\ns\n"},
+		{name: "edit then accept", input: "e\na\n", want: "## [v1.0.0]\n\n- 生成\n- 手で追記", wantAccepted: true},
+		{
+			name:         "regenerate then accept",
+			input:        "r\na\n",
+			regenerate:   func() (string, error) { return "## [v1.0.0]\n\n- 再生成", nil },
+			want:         "## [v1.0.0]\n\n- 再生成",
+			wantAccepted: true,
+		},
+		{
+			name:         "failed regeneration keeps the entry",
+			input:        "r\na\n",
+			regenerate:   func() (string, error) { return "", errors.New("AI unavailable") },
+			want:         "## [v1.0.0]\n\n- 生成",
+			wantAccepted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ui = &console{out: &buf, plain: true}
+			stdinReader = bufio.NewReader(strings.NewReader(tt.input))
+
+			got, accepted, err := reviewCatchUpEntry("v1.0.0", "## [v1.0.0]\n\n- 生成", tt.regenerate)
+			if err != nil {
+				t.Fatalf("reviewCatchUpEntry() error = %v", err)
+			}
+			if got != tt.want || accepted != tt.wantAccepted {
+				t.Errorf("reviewCatchUpEntry() = %q, %v; want %q, %v", got, accepted, tt.want, tt.wantAccepted)
+			}
+		})
+	}
+
+	stdinReader = bufio.NewReader(strings.NewReader(""))
+	if _, _, err := reviewCatchUpEntry("v1.0.0", "## [v1.0.0]", nil); err == nil {
+		t.Error("reviewCatchUpEntry() without input should return an error")
+	}
+}
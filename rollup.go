@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// rollupCommand combines the entries of several releases into one consolidated summary
+func rollupCommand(args []string) error {
+	fs := flag.NewFlagSet("rollup", flag.ContinueOnError)
+	from := fs.String("from", "", "Version the summary starts after, e.g. the version users upgrade from")
+	to := fs.String("to", "", "Last version covered by the summary (default: the latest release)")
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	title := fs.String("title", "", "Title of the summary (default: \"Release notes <from> → <to>\")")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	model := fs.String("model", "claude", "AI model to use: claude, or mock[:file.md] for a canned entry without AI access")
+	noAI := fs.Bool("no-ai", false, "List the entries one after another without AI consolidation")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update rollup --from v1.0.0 [--to v1.4.0] [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Summarizes every release after --from up to --to in one document, e.g. for quarterly\n")
+		fmt.Fprintf(os.Stderr, "announcements or upgrade notes across several versions.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *from == "" {
+		fs.Usage()
+		return &ConfigError{Err: fmt.Errorf("--from is required")}
+	}
+
+	entries, err := readChangelogEntries(repoPath(*changelogFile))
+	if err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+	between, err := entriesBetween(entries, *from, *to)
+	if err != nil {
+		return err
+	}
+	if len(between) == 0 {
+		ui.Printf("✅ No entries after %s\n", *from)
+		return nil
+	}
+	if *title == "" {
+		*title = fmt.Sprintf("Release notes %s → %s", *from, between[0].Version)
+	}
+
+	var document string
+	if *noAI {
+		document = renderRollup(*title, between)
+	} else {
+		executor, err := newExecutor(*model)
+		if err != nil {
+			return err
+		}
+		spin := startSpinner(ui.Sprintf("Summarizing %d release(s)", len(between)), !ui.plain)
+		document, err = executor.Execute(rollupPrompt(*title, between))
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to summarize releases: %w", err)
+		}
+	}
+
+	if *output == "" {
+		fmt.Println(document)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(strings.TrimRight(document, "\n")+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+	ui.Printf("✅ Wrote the summary of %d release(s) to %s\n", len(between), *output)
+	return nil
+}
+
+// rollupEntries returns the entries as Markdown, newest first
+func rollupEntries(entries []changelogEntry) string {
+	parts := make([]string, len(entries))
+	for i, entry := range entries {
+		parts[i] = entry.Markdown()
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderRollup lists the entries under the title without AI assistance, with version headings at level 2 or below
+func renderRollup(title string, entries []changelogEntry) string {
+	return fmt.Sprintf("# %s\n\n%s", title, demoteHeadings(normalizeNewlines(rollupEntries(entries)), max(0, 2-changelogHeading.Level())))
+}
+
+// rollupPrompt asks the AI to consolidate several releases into one summary
+func rollupPrompt(title string, entries []changelogEntry) string {
+	versions := make([]string, len(entries))
+	for i, entry := range entries {
+		versions[len(entries)-1-i] = entry.Version
+	}
+
+	return fmt.Sprintf(`以下はCHANGELOGから抜き出した %s のリリースエントリーです。これらのリリース全体をまとめて、1つのリリースノートを作成してください。
+
+---
+%s
+---
+
+以下の形式で出力してください:
+# %s
+
+## 概要
+
+- 期間全体で特に重要な変更を3〜5項目で要約
+
+## 追加
+
+- 変更を箇条書きで記載
+
+セクションは ## %s の順序で、該当する変更がある場合のみ記載してください。破壊的変更やアップグレード時に必要な対応がある場合は、最後に「## アップグレード時の注意」セクションを追加してください。
+
+注意事項：
+- 複数のリリースにまたがる同じ機能への変更は1項目にまとめ、最終的な状態を記載してください
+- 後のリリースで取り消された変更や、同じ期間内に追加されて修正されたバグは記載しないでください
+- 重要な項目には導入されたバージョンを（v1.2.0）の形式で付けてください
+- 元のエントリーにない変更を追加しないでください
+- 前置きや説明文は一切含めないでください
+- リリースノート本文のみを出力してください
+- 各項目は日本語で記述してください`, strings.Join(versions, ", "), rollupEntries(entries), title, strings.Join(changelogSections, " / ## "))
+}
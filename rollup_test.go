@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRollupCommand(t *testing.T) {
+	dir := t.TempDir()
+	changelog := filepath.Join(dir, "CHANGELOG.md")
+	content := "# Changelog\n\n## [v1.2.0] - 2025-03-01\n\n### 修正\n\n- C\n\n## [v1.1.0] - 2025-02-01\n\n### 追加\n\n- B\n\n## [v1.0.0] - 2025-01-01\n\n### 追加\n\n- A\n"
+	if err := os.WriteFile(changelog, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(dir, "NOTES.md")
+	if err := rollupCommand([]string{"--changelog", changelog, "--from", "v1.0.0", "--no-ai", "--output", output}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	if !strings.HasPrefix(got, "# Release notes v1.0.0 → v1.2.0\n\n## [v1.2.0]") || !strings.Contains(got, "## [v1.1.0]") || strings.Contains(got, "v1.0.0]") {
+		t.Errorf("rollup output =\n%s", got)
+	}
+}
+
+func TestRollupPrompt(t *testing.T) {
+	entries := []changelogEntry{
+		{Version: "v1.2.0", Heading: "## [v1.2.0] - 2025-03-01", Body: "### 修正\n\n- C"},
+		{Version: "v1.1.0", Heading: "## [v1.1.0] - 2025-02-01", Body: "### 追加\n\n- B"},
+	}
+	got := rollupPrompt("Q1 2025", entries)
+	for _, want := range []string{"v1.1.0, v1.2.0 のリリースエントリー", "# Q1 2025", "- C", "アップグレード時の注意"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rollupPrompt() does not contain %q", want)
+		}
+	}
+}
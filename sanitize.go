@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// cleanEntryResponse removes the wrapping the AI tends to add around an entry: code fences,
+// a preamble before the version heading and commentary after the last list item. A version
+// heading on the first line is replaced with the expected heading.
+func cleanEntryResponse(response, heading string) string {
+	var lines []string
+	for _, line := range strings.Split(normalizeNewlines(response), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+
+	start := slices.IndexFunc(lines, func(line string) bool {
+		_, ok := changelogHeading.Version(line)
+		return ok
+	})
+	if start >= 0 {
+		lines = lines[start:]
+		lines[0] = heading
+	}
+
+	end := len(lines)
+	if slices.ContainsFunc(lines, isListLine) {
+		for end > 0 && !isListLine(lines[end-1]) {
+			end--
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines[:end], "\n"))
+}
+
+// isListLine reports whether line is a list item or an indented continuation of one
+func isListLine(line string) bool {
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	return listItemPattern.MatchString(line) || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// entryViolations lists the ways entry deviates from the expected format
+func entryViolations(entry, heading string) []string {
+	lines := strings.Split(entry, "\n")
+	if strings.TrimSpace(entry) == "" {
+		return []string{"エントリーが空です"}
+	}
+
+	var violations []string
+	if lines[0] != heading {
+		violations = append(violations, fmt.Sprintf("1行目がバージョン見出し `%s` ではありません", heading))
+	}
+	for _, line := range lines[1:] {
+		switch {
+		case strings.TrimSpace(line) == "":
+		case htmlHeadingPattern.MatchString(line):
+			if _, ok := changelogHeading.Version(line); ok {
+				violations = append(violations, fmt.Sprintf("バージョン見出しが複数あります: `%s`", line))
+				continue
+			}
+			title := htmlHeadingPattern.FindStringSubmatch(line)[2]
+			if !activeSpec.allowsSection(title) {
+				violations = append(violations, fmt.Sprintf("許可されていないセクションです: `%s`", line))
+			}
+		case isListLine(line):
+		default:
+			violations = append(violations, fmt.Sprintf("箇条書きではない行があります: `%s`", line))
+		}
+	}
+	return violations
+}
+
+// allowedSectionsText describes the section headings allowed by the spec for prompts
+func allowedSectionsText() string {
+	if activeSpec.Sections == nil {
+		return "### で始まる見出し"
+	}
+	return "### " + strings.Join(activeSpec.Sections, " / ### ") + " のみ"
+}
+
+// entryRepairPrompt asks the AI to fix the listed format violations without changing the content
+func entryRepairPrompt(entry, heading string, violations []string) string {
+	return fmt.Sprintf(`以下のCHANGELOGエントリーには形式の問題があります。記載内容は変えずに問題を修正し、修正後のエントリー本文のみを出力してください。
+
+問題:
+- %s
+
+守るべき形式:
+- 1行目は見出し %s
+- セクション見出しは %s
+- 各セクションの内容は箇条書き（- ）のみ
+- 前置き、コードブロック、末尾の説明文は含めない
+
+エントリー:
+---
+%s
+---`, strings.Join(violations, "\n- "), heading, allowedSectionsText(), entry)
+}
+
+// validateEntry cleans a generated entry and, when format violations remain, asks the AI once to fix them
+func validateEntry(executor AIExecutor, response, heading string) (string, error) {
+	entry := cleanEntryResponse(response, heading)
+	violations := entryViolations(entry, heading)
+	if len(violations) == 0 {
+		return entry, nil
+	}
+
+	ui.Printf("🔧 Generated entry for %s has %d format problem(s), asking the AI to fix them...\n", heading, len(violations))
+	fixed, err := executor.Execute(entryRepairPrompt(entry, heading, violations))
+	if err != nil {
+		return "", err
+	}
+	repaired := cleanEntryResponse(fixed, heading)
+	if remaining := entryViolations(repaired, heading); len(remaining) > 0 {
+		ui.Printf("⚠️  Warning: Generated entry for %s still has format problems: %s\n", heading, strings.Join(remaining, "; "))
+	}
+	return repaired, nil
+}
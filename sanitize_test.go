@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCleanEntryResponse(t *testing.T) {
+	heading := "## [v1.2.0] - 2025-09-01"
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{
+			name:     "already clean",
+			response: heading + "\n\n### 追加\n\n- 新機能",
+			want:     heading + "\n\n### 追加\n\n- 新機能",
+		},
+		{
+			name:     "preamble, code fence and trailing commentary",
+			response: "Here is your changelog:\n\n```markdown\n## [v1.2.0] - 2025-09-01\n\n### 修正\n\n- バグを修正\n  詳細\n```\n\nこれらの変更でより安定しました。",
+			want:     heading + "\n\n### 修正\n\n- バグを修正\n  詳細",
+		},
+		{
+			name:     "heading with wrong date is replaced",
+			response: "## v1.2.0 - 2024-01-01\n\n### 追加\n\n- 新機能",
+			want:     heading + "\n\n### 追加\n\n- 新機能",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanEntryResponse(tt.response, heading); got != tt.want {
+				t.Errorf("cleanEntryResponse() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryViolations(t *testing.T) {
+	heading := "## [v1.2.0] - 2025-09-01"
+	if got := entryViolations(heading+"\n\n### 追加\n\n- **api**\n  - 新機能", heading); got != nil {
+		t.Errorf("entryViolations() = %v, want none", got)
+	}
+
+	got := entryViolations("### 追加\n\n- 新機能\n\n### Notes\n\n説明文です\n\n## [v1.1.0] - 2025-08-01", heading)
+	want := []string{
+		"1行目がバージョン見出し `## [v1.2.0] - 2025-09-01` ではありません",
+		"許可されていないセクションです: `### Notes`",
+		"箇条書きではない行があります: `説明文です`",
+		"バージョン見出しが複数あります: `## [v1.1.0] - 2025-08-01`",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("entryViolations() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestValidateEntryRetriesOnce(t *testing.T) {
+	heading := "## [v1.2.0] - 2025-09-01"
+	executor := &MockExecutor{response: heading + "\n\n### 追加\n\n- 新機能"}
+
+	got, err := validateEntry(executor, heading+"\n\n### 追加\n\n新機能を追加しました", heading)
+	if err != nil {
+		t.Fatalf("validateEntry() error = %v", err)
+	}
+	if got != executor.response {
+		t.Errorf("validateEntry() = %q, want the repaired entry", got)
+	}
+	if len(executor.prompts) != 1 || !strings.Contains(executor.prompts[0], "箇条書きではない行があります") {
+		t.Errorf("repair prompts = %q", executor.prompts)
+	}
+
+	executor.prompts = nil
+	if _, err := validateEntry(executor, executor.response, heading); err != nil || len(executor.prompts) != 0 {
+		t.Errorf("valid entry triggered a retry: err = %v, prompts = %d", err, len(executor.prompts))
+	}
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	providerGitHub = "github"
+	providerGitLab = "gitlab"
+
+	tagRefPrefix = "refs/tags/"
+	zeroSHA      = "0000000000000000000000000000000000000000"
+	maxHookBody  = 10 << 20
+
+	// Deliveries are small and processed in the background, so requests need little time
+	hookReadHeaderTimeout = 10 * time.Second
+	hookReadTimeout       = 30 * time.Second
+	hookWriteTimeout      = 30 * time.Second
+)
+
+// errIgnoredEvent is returned for webhook deliveries that are not tag pushes
+var errIgnoredEvent = errors.New("event ignored")
+
+// tagEvent describes a tag push received from a webhook
+type tagEvent struct {
+	Provider      string
+	Tag           string
+	CloneURL      string
+	FullName      string
+	DefaultBranch string
+}
+
+type githubPushPayload struct {
+	Ref        string `json:"ref"`
+	Deleted    bool   `json:"deleted"`
+	Repository struct {
+		FullName      string `json:"full_name"`
+		CloneURL      string `json:"clone_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+}
+
+type gitlabTagPushPayload struct {
+	ObjectKind string `json:"object_kind"`
+	Ref        string `json:"ref"`
+	After      string `json:"after"`
+	Project    struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		GitHTTPURL        string `json:"git_http_url"`
+		DefaultBranch     string `json:"default_branch"`
+	} `json:"project"`
+}
+
+// webhookServer generates CHANGELOG entries for tags announced by webhooks
+type webhookServer struct {
+	secret        string
+	workDir       string
+	changelogFile string
+	executor      AIExecutor
+	jobs          chan tagEvent
+}
+
+func serveCommand(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "Address to listen on")
+	secret := fs.String("secret", os.Getenv("CHANGELOG_WEBHOOK_SECRET"), "Webhook secret (GitHub HMAC key or GitLab token)")
+	insecure := fs.Bool("insecure-no-secret", false, "Accept deliveries without verifying them when no secret is configured")
+	workDir := fs.String("workdir", filepath.Join(os.TempDir(), "changelog-update"), "Directory for repository clones")
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md inside the repository")
+	model := fs.String("model", "claude", "AI model to use: claude, or mock[:file.md] for a canned entry without AI access")
+	commitTemplate := fs.String("commit-message-template", defaultCommitMessageTemplate, "Message and pull request title of changelog commits; {version} is replaced by the tag")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := setCommitMessageTemplate(*commitTemplate); err != nil {
+		return err
+	}
+
+	if *secret == "" {
+		if !*insecure {
+			return &ConfigError{Err: fmt.Errorf("no webhook secret configured: pass --secret or set CHANGELOG_WEBHOOK_SECRET (--insecure-no-secret accepts unverified deliveries)")}
+		}
+		ui.Println("⚠️  Warning: No webhook secret configured, deliveries will not be verified.")
+	}
+
+	executor, err := newExecutor(*model)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*workDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+
+	srv := &webhookServer{
+		secret:        *secret,
+		workDir:       *workDir,
+		changelogFile: *changelogFile,
+		executor:      executor,
+		jobs:          make(chan tagEvent, 16),
+	}
+	go srv.worker()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhook", srv.handleWebhook)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: hookReadHeaderTimeout,
+		ReadTimeout:       hookReadTimeout,
+		WriteTimeout:      hookWriteTimeout,
+	}
+	ui.Printf("🌐 Listening for tag webhooks on %s/webhook\n", *addr)
+	return server.ListenAndServe()
+}
+
+func (s *webhookServer) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxHookBody))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	ev, err := parseWebhook(r.Header, body, s.secret)
+	if err != nil {
+		if errors.Is(err, errIgnoredEvent) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	select {
+	case s.jobs <- *ev:
+		ui.Printf("📨 Queued %s from %s\n", ev.Tag, ev.FullName)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		http.Error(w, "queue is full", http.StatusServiceUnavailable)
+	}
+}
+
+// worker processes queued tag events one at a time, since git commands share gitDir
+func (s *webhookServer) worker() {
+	for ev := range s.jobs {
+		if err := s.process(ev); err != nil {
+			ui.Printf("❌ Error processing %s from %s: %v\n", ev.Tag, ev.FullName, err)
+		}
+	}
+}
+
+// parseWebhook verifies and decodes a GitHub or GitLab tag push delivery
+func parseWebhook(header http.Header, body []byte, secret string) (*tagEvent, error) {
+	switch {
+	case header.Get("X-GitHub-Event") != "":
+		if secret != "" && !validGitHubSignature(body, header.Get("X-Hub-Signature-256"), secret) {
+			return nil, fmt.Errorf("invalid signature")
+		}
+		if header.Get("X-GitHub-Event") != "push" {
+			return nil, errIgnoredEvent
+		}
+		var p githubPushPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse payload: %w", err)
+		}
+		if !strings.HasPrefix(p.Ref, tagRefPrefix) || p.Deleted {
+			return nil, errIgnoredEvent
+		}
+		return validTagEvent(&tagEvent{
+			Provider:      providerGitHub,
+			Tag:           strings.TrimPrefix(p.Ref, tagRefPrefix),
+			CloneURL:      p.Repository.CloneURL,
+			FullName:      p.Repository.FullName,
+			DefaultBranch: p.Repository.DefaultBranch,
+		})
+	case header.Get("X-Gitlab-Event") != "":
+		if secret != "" && !hmac.Equal([]byte(header.Get("X-Gitlab-Token")), []byte(secret)) {
+			return nil, fmt.Errorf("invalid token")
+		}
+		var p gitlabTagPushPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse payload: %w", err)
+		}
+		if p.ObjectKind != "tag_push" || !strings.HasPrefix(p.Ref, tagRefPrefix) || p.After == zeroSHA {
+			return nil, errIgnoredEvent
+		}
+		return validTagEvent(&tagEvent{
+			Provider:      providerGitLab,
+			Tag:           strings.TrimPrefix(p.Ref, tagRefPrefix),
+			CloneURL:      p.Project.GitHTTPURL,
+			FullName:      p.Project.PathWithNamespace,
+			DefaultBranch: p.Project.DefaultBranch,
+		})
+	default:
+		return nil, errIgnoredEvent
+	}
+}
+
+// validTagEvent rejects events whose tag or default branch is not a valid ref name, or whose clone
+// URL is not an https or ssh URL, since all of them are passed to git as arguments
+func validTagEvent(ev *tagEvent) (*tagEvent, error) {
+	if !validRefName(tagRefPrefix + ev.Tag) {
+		return nil, fmt.Errorf("invalid tag name %q", ev.Tag)
+	}
+	if ev.CloneURL != "" && !validCloneURL(ev.CloneURL) {
+		return nil, fmt.Errorf("invalid clone URL %q", ev.CloneURL)
+	}
+	if ev.DefaultBranch != "" && !validRefName(ev.DefaultBranch, "--branch") {
+		return nil, fmt.Errorf("invalid default branch %q", ev.DefaultBranch)
+	}
+	return ev, nil
+}
+
+// validCloneURL reports whether rawURL is an https or ssh URL with a host
+func validCloneURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "ssh") && u.Host != ""
+}
+
+// validRefName reports whether git check-ref-format accepts name; names starting with a dash are
+// rejected because git would read them as options
+func validRefName(name string, options ...string) bool {
+	if strings.HasPrefix(name, "-") || strings.HasPrefix(name, tagRefPrefix+"-") {
+		return false
+	}
+	args := append(append([]string{"check-ref-format"}, options...), name)
+	return exec.Command("git", args...).Run() == nil
+}
+
+func validGitHubSignature(body []byte, signature, secret string) bool {
+	sig, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// process clones or refreshes the repository, generates the entry and opens a pull request
+func (s *webhookServer) process(ev tagEvent) error {
+	if ev.CloneURL == "" || ev.FullName == "" {
+		return fmt.Errorf("payload is missing repository information")
+	}
+	if ev.DefaultBranch == "" {
+		ev.DefaultBranch = "main"
+	}
+
+	dir := filepath.Join(s.workDir, unsafePathChars.ReplaceAllString(ev.FullName, "_"))
+	if filepath.Dir(dir) != filepath.Clean(s.workDir) {
+		// A name of "." or ".." would leave the work directory
+		return fmt.Errorf("invalid repository name %q", ev.FullName)
+	}
+	if err := syncRepository(ev.CloneURL, dir, ev.DefaultBranch); err != nil {
+		return err
+	}
+
+	previousDir, previousFilter, previousHeading, previousOutputs := gitDir, aiFilter, changelogHeading, outputTargets
+	gitDir = dir
+	defer func() {
+		gitDir, aiFilter, changelogHeading, outputTargets = previousDir, previousFilter, previousHeading, previousOutputs
+	}()
+
+	cfg, err := loadConfig(configPath(""), false)
+	if err != nil {
+		return err
+	}
+	aiFilter = newPathFilter(cfg.AIAllow, cfg.AIDeny)
+	outputTargets = cfg.Outputs
+	if changelogHeading, err = newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat); err != nil {
+		return err
+	}
+
+	ui.Printf("🔧 Processing %s for %s...\n", ev.Tag, ev.FullName)
+
+	allTags, err := getAllTags()
+	if err != nil {
+		return fmt.Errorf("failed to get all tags: %w", err)
+	}
+
+	entry, err := generateCatchUpEntry(s.executor, allTags, ev.Tag)
+	if err != nil {
+		return err
+	}
+
+	return publishEntry(ev, s.changelogFile, entry)
+}
+
+// publishEntry commits the entry to changelogFile (relative to gitDir) on a
+// changelog-update/<tag> branch and opens a pull request for it
+func publishEntry(ev tagEvent, changelogFile, entry string) error {
+	branch := "changelog-update/" + ev.Tag
+	if err := runGit("checkout", "-B", branch); err != nil {
+		return err
+	}
+	written, err := writeChangelogOutputs(filepath.Join(gitDir, changelogFile), entry)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	title := releaseCommitMessage(ev.Tag)
+	if err := runGit(append([]string{"add", "--"}, written...)...); err != nil {
+		return err
+	}
+	if err := runGit("commit", "-m", title); err != nil {
+		return err
+	}
+
+	return openPullRequest(ev, branch, title, entry)
+}
+
+// syncRepository clones the repository into dir or resets an existing clone to the default branch
+func syncRepository(cloneURL, dir, defaultBranch string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		cmd := gitCommand("clone", "--", cloneURL, dir)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to clone %s: %w\nOutput: %s", cloneURL, err, output)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"-C", dir, "fetch", "--tags", "--force", "origin"},
+		{"-C", dir, "checkout", "-f", defaultBranch},
+		{"-C", dir, "reset", "--hard", "origin/" + defaultBranch},
+	} {
+		if err := runGit(args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runGit runs a git command and includes its output in the returned error
+func runGit(args ...string) error {
+	cmd := gitCommand(args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return newGitError(args, output, err)
+	}
+	return nil
+}
+
+// openPullRequest pushes the branch and opens a GitHub pull request or GitLab merge request
+func openPullRequest(ev tagEvent, branch, title, body string) error {
+	switch ev.Provider {
+	case providerGitLab:
+		return runGit("push", "--force", gitRemote, branch,
+			"-o", "merge_request.create",
+			"-o", "merge_request.target="+ev.DefaultBranch,
+			"-o", "merge_request.title="+title)
+	default:
+		if err := runGit("push", "--force", gitRemote, branch); err != nil {
+			return err
+		}
+		cmd := exec.Command("gh", "pr", "create",
+			"--repo", ev.FullName,
+			"--base", ev.DefaultBranch,
+			"--head", branch,
+			"--title", title,
+			"--body", body)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to create pull request: %w\nOutput: %s", err, output)
+		}
+		ui.Printf("✅ Opened pull request for %s on %s\n", ev.Tag, ev.FullName)
+		return nil
+	}
+}
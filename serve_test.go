@@ -0,0 +1,177 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func signGitHubBody(body, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseWebhook(t *testing.T) {
+	githubTagPush := `{"ref":"refs/tags/v1.2.0","deleted":false,"repository":{"full_name":"org/repo","clone_url":"https://github.com/org/repo.git","default_branch":"main"}}`
+	githubBranchPush := `{"ref":"refs/heads/main","repository":{"full_name":"org/repo"}}`
+	gitlabTagPush := `{"object_kind":"tag_push","ref":"refs/tags/v2.0.0","after":"abc","project":{"path_with_namespace":"group/proj","git_http_url":"https://gitlab.com/group/proj.git","default_branch":"master"}}`
+	gitlabTagDelete := `{"object_kind":"tag_push","ref":"refs/tags/v2.0.0","after":"` + zeroSHA + `"}`
+
+	tests := []struct {
+		name    string
+		header  map[string]string
+		body    string
+		secret  string
+		want    *tagEvent
+		ignored bool
+		wantErr bool
+	}{
+		{
+			name: "github tag push with valid signature",
+			header: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": signGitHubBody(githubTagPush, "s3cret"),
+			},
+			body:   githubTagPush,
+			secret: "s3cret",
+			want: &tagEvent{
+				Provider:      providerGitHub,
+				Tag:           "v1.2.0",
+				CloneURL:      "https://github.com/org/repo.git",
+				FullName:      "org/repo",
+				DefaultBranch: "main",
+			},
+		},
+		{
+			name: "github invalid signature",
+			header: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": signGitHubBody(githubTagPush, "wrong"),
+			},
+			body:    githubTagPush,
+			secret:  "s3cret",
+			wantErr: true,
+		},
+		{
+			name:    "github branch push is ignored",
+			header:  map[string]string{"X-GitHub-Event": "push"},
+			body:    githubBranchPush,
+			ignored: true,
+		},
+		{
+			name:    "github ping is ignored",
+			header:  map[string]string{"X-GitHub-Event": "ping"},
+			body:    `{}`,
+			ignored: true,
+		},
+		{
+			name: "gitlab tag push with token",
+			header: map[string]string{
+				"X-Gitlab-Event": "Tag Push Hook",
+				"X-Gitlab-Token": "s3cret",
+			},
+			body:   gitlabTagPush,
+			secret: "s3cret",
+			want: &tagEvent{
+				Provider:      providerGitLab,
+				Tag:           "v2.0.0",
+				CloneURL:      "https://gitlab.com/group/proj.git",
+				FullName:      "group/proj",
+				DefaultBranch: "master",
+			},
+		},
+		{
+			name:    "gitlab tag deletion is ignored",
+			header:  map[string]string{"X-Gitlab-Event": "Tag Push Hook"},
+			body:    gitlabTagDelete,
+			ignored: true,
+		},
+		{
+			name: "gitlab invalid token",
+			header: map[string]string{
+				"X-Gitlab-Event": "Tag Push Hook",
+				"X-Gitlab-Token": "nope",
+			},
+			body:    gitlabTagPush,
+			secret:  "s3cret",
+			wantErr: true,
+		},
+		{
+			name:    "github tag starting with a dash",
+			header:  map[string]string{"X-GitHub-Event": "push"},
+			body:    `{"ref":"refs/tags/--upload-pack=x","repository":{"full_name":"org/repo","default_branch":"main"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "github clone URL that is an option",
+			header:  map[string]string{"X-GitHub-Event": "push"},
+			body:    `{"ref":"refs/tags/v1.2.0","repository":{"full_name":"org/repo","clone_url":"--upload-pack=touch /tmp/x","default_branch":"main"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "gitlab clone URL with the file scheme",
+			header:  map[string]string{"X-Gitlab-Event": "Tag Push Hook"},
+			body:    `{"object_kind":"tag_push","ref":"refs/tags/v2.0.0","after":"abc","project":{"path_with_namespace":"group/proj","git_http_url":"file:///etc","default_branch":"master"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "github invalid tag name",
+			header:  map[string]string{"X-GitHub-Event": "push"},
+			body:    `{"ref":"refs/tags/v1..2","repository":{"full_name":"org/repo","default_branch":"main"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "gitlab default branch starting with a dash",
+			header:  map[string]string{"X-Gitlab-Event": "Tag Push Hook"},
+			body:    `{"object_kind":"tag_push","ref":"refs/tags/v2.0.0","after":"abc","project":{"path_with_namespace":"group/proj","default_branch":"-f"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown provider is ignored",
+			header:  map[string]string{},
+			body:    `{}`,
+			ignored: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.header {
+				header.Set(k, v)
+			}
+
+			got, err := parseWebhook(header, []byte(tt.body), tt.secret)
+			if tt.ignored {
+				if !errors.Is(err, errIgnoredEvent) {
+					t.Errorf("parseWebhook() error = %v, want errIgnoredEvent", err)
+				}
+				return
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != *tt.want {
+				t.Errorf("parseWebhook() = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessRejectsNamesOutsideWorkDir(t *testing.T) {
+	srv := &webhookServer{workDir: t.TempDir()}
+	for _, name := range []string{".", ".."} {
+		ev := tagEvent{Tag: "v1.0.0", CloneURL: "https://example.com/x.git", FullName: name, DefaultBranch: "main"}
+		if err := srv.process(ev); err == nil || !strings.Contains(err.Error(), "invalid repository name") {
+			t.Errorf("process() with FullName %q error = %v, want an invalid repository name", name, err)
+		}
+	}
+}
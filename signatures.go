@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Values accepted by --verify-signatures
+const (
+	signaturesWarn    = "warn"
+	signaturesRequire = "require"
+)
+
+// signaturePolicy is the --verify-signatures mode; empty disables signature checks
+var signaturePolicy string
+
+// signatureSummary collects the results of the signature checks of the run for --summary-json
+var signatureSummary *signatureReport
+
+// validSignaturePolicy reports whether policy is a supported --verify-signatures value
+func validSignaturePolicy(policy string) bool {
+	return policy == "" || policy == signaturesWarn || policy == signaturesRequire
+}
+
+// signatureProblem is a tag or commit without a valid signature
+type signatureProblem struct {
+	Ref    string `json:"ref"`
+	Status string `json:"status"`
+}
+
+// signatureReport is the outcome of the signature checks recorded in the run summary
+type signatureReport struct {
+	Tags     []string           `json:"checked_tags"`
+	Commits  int                `json:"checked_commits"`
+	Problems []signatureProblem `json:"problems"`
+}
+
+// signatureRange is a tag and the range of commits it releases; From is empty for the first release
+// and To is empty when only the tag is checked
+type signatureRange struct {
+	From, To, Tag string
+}
+
+// commitSignatureStatus describes the %G? codes of git log that are not a good signature
+var commitSignatureStatus = map[string]string{
+	"N": "unsigned",
+	"B": "bad signature",
+	"X": "expired signature",
+	"Y": "signed with an expired key",
+	"R": "signed with a revoked key",
+	"E": "signature cannot be checked",
+}
+
+// tagSignatureStatus returns "" for a tag with a valid GPG or SSH signature and the problem otherwise
+func tagSignatureStatus(tag string) string {
+	kind, err := gitOutput("cat-file", "-t", "refs/tags/"+tag)
+	if err != nil {
+		return "missing tag"
+	}
+	if strings.TrimSpace(kind) != "tag" {
+		return "unsigned (lightweight tag)"
+	}
+	if gitCommand("verify-tag", tag).Run() == nil {
+		return ""
+	}
+	object, _ := gitOutput("cat-file", "tag", tag)
+	if strings.Contains(object, "-----BEGIN PGP SIGNATURE-----") || strings.Contains(object, "-----BEGIN SSH SIGNATURE-----") {
+		return "invalid or unverifiable signature"
+	}
+	return "unsigned"
+}
+
+// commitSignatureProblems checks the signatures of the commits in from..to and returns the problems
+// with the number of commits checked
+func commitSignatureProblems(from, to string) ([]signatureProblem, int, error) {
+	revRange := to
+	if from != "" {
+		revRange = from + ".." + to
+	}
+	output, err := gitOutput("log", "--format=%h %G?", revRange)
+	if err != nil {
+		return nil, 0, err
+	}
+	var problems []signatureProblem
+	count := 0
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		hash, code, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		count++
+		if status, bad := commitSignatureStatus[code]; bad {
+			problems = append(problems, signatureProblem{Ref: hash, Status: status})
+		}
+	}
+	return problems, count, nil
+}
+
+// verifySignatures checks the signatures of the tags and commits in ranges according to the
+// --verify-signatures policy, reports problems and records them for the run summary. With the
+// require policy a problem is returned as an error so the run stops before generating anything.
+func verifySignatures(ranges []signatureRange) error {
+	if signaturePolicy == "" {
+		return nil
+	}
+	if signatureSummary == nil {
+		signatureSummary = &signatureReport{Problems: []signatureProblem{}}
+	}
+
+	ui.Println("🔏 Verifying tag and commit signatures...")
+	var problems []signatureProblem
+	for _, r := range ranges {
+		if r.Tag != "" && tagExists(r.Tag) {
+			signatureSummary.Tags = append(signatureSummary.Tags, r.Tag)
+			if status := tagSignatureStatus(r.Tag); status != "" {
+				problems = append(problems, signatureProblem{Ref: r.Tag, Status: status})
+			}
+		}
+		if r.To == "" || !hasCommits() {
+			continue
+		}
+		commitProblems, count, err := commitSignatureProblems(r.From, r.To)
+		if err != nil {
+			return fmt.Errorf("failed to check commit signatures: %w", err)
+		}
+		signatureSummary.Commits += count
+		problems = append(problems, commitProblems...)
+	}
+	signatureSummary.Problems = append(signatureSummary.Problems, problems...)
+
+	if len(problems) == 0 {
+		ui.Println("✅ All checked tags and commits have valid signatures.")
+		return nil
+	}
+	ui.Printf("⚠️  %d signature problem(s):\n", len(problems))
+	for _, p := range problems {
+		ui.Printf("  - %s: %s\n", p.Ref, p.Status)
+	}
+	if signaturePolicy == signaturesRequire {
+		return fmt.Errorf("%d tag(s) or commit(s) lack a valid signature (--verify-signatures=%s)", len(problems), signaturesRequire)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestVerifySignatures(t *testing.T) {
+	savedPolicy, savedSummary := signaturePolicy, signatureSummary
+	defer func() { signaturePolicy, signatureSummary = savedPolicy, savedSummary }()
+	newTestRepo(t)
+	testCommit(t, "initial", map[string]string{"main.go": "package main\n"})
+	testGit(t, "tag", "v1.0.0")
+	ranges := []signatureRange{{To: "v1.0.0", Tag: "v1.0.0"}}
+
+	signaturePolicy = ""
+	if err := verifySignatures(ranges); err != nil || signatureSummary != nil {
+		t.Fatalf("verifySignatures() without a policy = %v, summary %v", err, signatureSummary)
+	}
+
+	signaturePolicy = signaturesWarn
+	if err := verifySignatures(ranges); err != nil {
+		t.Errorf("verifySignatures() with warn = %v, want nil", err)
+	}
+	if signatureSummary == nil || signatureSummary.Commits != 1 || len(signatureSummary.Problems) != 2 {
+		t.Fatalf("summary = %+v, want 1 commit and 2 problems", signatureSummary)
+	}
+	if p := signatureSummary.Problems[0]; p.Ref != "v1.0.0" || p.Status != "unsigned (lightweight tag)" {
+		t.Errorf("tag problem = %+v", p)
+	}
+
+	signatureSummary = nil
+	signaturePolicy = signaturesRequire
+	if err := verifySignatures(ranges); err == nil {
+		t.Error("verifySignatures() with require and unsigned commits = nil, want an error")
+	}
+}
+
+func TestValidSignaturePolicy(t *testing.T) {
+	for policy, want := range map[string]bool{"": true, "warn": true, "require": true, "strict": false} {
+		if got := validSignaturePolicy(policy); got != want {
+			t.Errorf("validSignaturePolicy(%q) = %v, want %v", policy, got, want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// sinceItem is a top-level bullet of the consolidated summary with the release that introduced it
+type sinceItem struct {
+	Text    string
+	Version string
+}
+
+// sinceSection collects the items of one section title across releases
+type sinceSection struct {
+	Title string
+	Items []sinceItem
+}
+
+// findEntry returns the index of the entry for version, or -1
+func findEntry(entries []changelogEntry, version string) int {
+	for i, entry := range entries {
+		if sameVersion(entry.Version, version) {
+			return i
+		}
+	}
+	return -1
+}
+
+// releasesSince returns the released entries after from up to to (the latest release when
+// empty), newest first, like the diff subcommand. A from version missing from the changelog, e.g. a
+// skipped patch release, is placed by semantic version.
+func releasesSince(entries []changelogEntry, from, to string) ([]changelogEntry, error) {
+	var released []changelogEntry
+	for _, entry := range entries {
+		if !strings.EqualFold(entry.Version, unreleasedVersion) {
+			released = append(released, entry)
+		}
+	}
+	if findEntry(released, from) >= 0 {
+		return entriesBetween(released, from, to)
+	}
+
+	var newer []changelogEntry
+	for _, entry := range released {
+		c, ok := compareVersions(entry.Version, from)
+		if !ok {
+			return nil, fmt.Errorf("version %s not found in changelog", from)
+		}
+		if c > 0 {
+			newer = append(newer, entry)
+		}
+	}
+	if to != "" {
+		i := findEntry(newer, to)
+		if i < 0 {
+			return nil, fmt.Errorf("version %s not found in changelog after %s", to, from)
+		}
+		newer = newer[i:]
+	}
+	return newer, nil
+}
+
+// bulletKey normalizes a bullet for deduplication, ignoring case, spacing and trailing references
+func bulletKey(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(bulletReferencePattern.ReplaceAllString(text, "")), " "))
+}
+
+// consolidateEntries merges the top-level bullets of entries (newest first) by section title.
+// A bullet repeated in several releases, e.g. in a release candidate and the final release, is
+// listed once with the oldest release that has it. Sections follow the Keep a Changelog order.
+func consolidateEntries(entries []changelogEntry) []sinceSection {
+	var sections []sinceSection
+	index := map[string]int{}
+	seen := map[string]bool{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		title := ""
+		var current *sinceItem
+		add := func() {
+			if current == nil {
+				return
+			}
+			key := bulletKey(strings.SplitN(current.Text, "\n", 2)[0])
+			if !seen[key] {
+				seen[key] = true
+				n, ok := index[title]
+				if !ok {
+					n = len(sections)
+					index[title] = n
+					sections = append(sections, sinceSection{Title: title})
+				}
+				sections[n].Items = append(sections[n].Items, *current)
+			}
+			current = nil
+		}
+		for _, line := range strings.Split(entries[i].Body, "\n") {
+			if m := htmlHeadingPattern.FindStringSubmatch(line); m != nil {
+				add()
+				title = strings.TrimSpace(m[2])
+				continue
+			}
+			if m := listItemPattern.FindStringSubmatch(line); m != nil && m[1] == "" {
+				add()
+				current = &sinceItem{Text: m[2], Version: entries[i].Version}
+				continue
+			}
+			if current != nil && strings.TrimSpace(line) != "" {
+				current.Text += "\n" + line
+			}
+		}
+		add()
+	}
+
+	// Newest changes first within a section
+	for i := range sections {
+		slices.Reverse(sections[i].Items)
+	}
+	// Items outside any section stay on top, where they cannot be mistaken for the last section's
+	rank := func(title string) int {
+		if title == "" {
+			return -1
+		}
+		if i := slices.Index(changelogSections, title); i >= 0 {
+			return i
+		}
+		return len(changelogSections)
+	}
+	slices.SortStableFunc(sections, func(a, b sinceSection) int { return rank(a.Title) - rank(b.Title) })
+	return sections
+}
+
+// renderSince renders the consolidated changes since from as Markdown
+func renderSince(from string, entries []changelogEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Changes since %s\n\n", from)
+	if len(entries) == 0 {
+		fmt.Fprintf(&b, "%s is the latest release.\n", from)
+		return b.String()
+	}
+	if len(entries) == 1 {
+		fmt.Fprintf(&b, "1 release: %s\n", entries[0].Version)
+	} else {
+		fmt.Fprintf(&b, "%d releases: %s – %s\n", len(entries), entries[len(entries)-1].Version, entries[0].Version)
+	}
+	for _, section := range consolidateEntries(entries) {
+		if section.Title != "" {
+			fmt.Fprintf(&b, "\n## %s\n", section.Title)
+		}
+		b.WriteString("\n")
+		for _, item := range section.Items {
+			first, rest, _ := strings.Cut(item.Text, "\n")
+			fmt.Fprintf(&b, "- %s (%s)\n", first, item.Version)
+			if rest != "" {
+				b.WriteString(rest + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// sinceCommand prints everything that changed after a version from the parsed changelog, without AI
+func sinceCommand(args []string) error {
+	fs := flag.NewFlagSet("since", flag.ContinueOnError)
+	to := fs.String("to", "", "Last version included (default: the latest release)")
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update since [flags] <version>\n\n")
+		fmt.Fprintf(os.Stderr, "Prints the changes of every release after <version>, merged by section and deduplicated,\n")
+		fmt.Fprintf(os.Stderr, "e.g. to answer what changed since the version a customer runs.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return &ConfigError{Err: fmt.Errorf("expected exactly one version")}
+	}
+	from := fs.Arg(0)
+
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		return err
+	}
+	if changelogHeading, err = newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat); err != nil {
+		return &ConfigError{Err: err}
+	}
+
+	entries, err := readChangelogEntries(repoPath(*changelogFile))
+	if err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+	releases, err := releasesSince(entries, from, *to)
+	if err != nil {
+		return err
+	}
+	summary := renderSince(from, releases)
+
+	if *output == "" {
+		fmt.Print(summary)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(summary), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+	ui.Printf("✅ Wrote the changes in %d release(s) since %s to %s\n", len(releases), from, *output)
+	return nil
+}
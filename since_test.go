@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const sinceChangelog = `# Changelog
+
+## [Unreleased]
+
+- 未リリースの変更
+
+## [v1.4.0] - 2025-03-01
+
+### 追加
+
+- エクスポート機能 (abc1234)
+
+### 修正
+
+- ログインの不具合を修正
+  - セッション切れの場合
+
+## [v1.4.0-rc.1] - 2025-02-20
+
+### 追加
+
+- エクスポート機能 (def5678)
+
+## [v1.3.0] - 2025-02-01
+
+### Security
+
+- 依存関係を更新
+
+### 修正
+
+- 検索の不具合を修正
+
+## [v1.2.0] - 2025-01-01
+
+### 追加
+
+- 初期機能
+`
+
+func TestReleasesSince(t *testing.T) {
+	entries := parseChangelogEntries(sinceChangelog)
+	versions := func(entries []changelogEntry) string {
+		var v []string
+		for _, e := range entries {
+			v = append(v, e.Version)
+		}
+		return strings.Join(v, ",")
+	}
+	for _, tt := range []struct{ from, to, want string }{
+		{"v1.2.0", "", "v1.4.0,v1.4.0-rc.1,v1.3.0"},
+		{"1.2.0", "v1.3.0", "v1.3.0"},
+		{"v1.2.5", "", "v1.4.0,v1.4.0-rc.1,v1.3.0"},
+		{"v1.4.0", "", ""},
+		{"v1.2.5", "v1.3.0", "v1.3.0"},
+	} {
+		got, err := releasesSince(entries, tt.from, tt.to)
+		if err != nil || versions(got) != tt.want {
+			t.Errorf("releasesSince(%q, %q) = %s, %v, want %s", tt.from, tt.to, versions(got), err, tt.want)
+		}
+	}
+	if _, err := releasesSince(entries, "v1.2.5", "v1.2.0"); err == nil {
+		t.Error("releasesSince() with --to before the version succeeded")
+	}
+	if _, err := releasesSince(entries, "nightly", ""); err == nil {
+		t.Error("releasesSince() with an unknown version succeeded")
+	}
+}
+
+func TestRenderSince(t *testing.T) {
+	releases, err := releasesSince(parseChangelogEntries(sinceChangelog), "v1.2.0", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Changes since v1.2.0
+
+3 releases: v1.3.0 – v1.4.0
+
+## 追加
+
+- エクスポート機能 (def5678) (v1.4.0-rc.1)
+
+## 修正
+
+- ログインの不具合を修正 (v1.4.0)
+  - セッション切れの場合
+- 検索の不具合を修正 (v1.3.0)
+
+## Security
+
+- 依存関係を更新 (v1.3.0)
+`
+	if got := renderSince("v1.2.0", releases); got != want {
+		t.Errorf("renderSince() =\n%s\nwant\n%s", got, want)
+	}
+	if got := renderSince("v1.4.0", nil); !strings.Contains(got, "v1.4.0 is the latest release") {
+		t.Errorf("renderSince() without releases = %q", got)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import "strings"
+
+// normalizeChangelogSpacing makes the spacing of LF-normalized changelog content deterministic:
+// trailing whitespace is removed except for the two or more spaces of a Markdown hard line break,
+// every heading is preceded by exactly one blank line, runs of
+// blank lines collapse into one and the content ends with a single newline. Fenced code blocks
+// are left untouched.
+func normalizeChangelogSpacing(content string) string {
+	var out []string
+	inCode := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		} else if inCode {
+			out = append(out, line)
+			continue
+		}
+
+		// Trailing spaces are a hard line break in text, but not in a heading
+		if trimmed := strings.TrimRight(line, " \t"); trimmed == "" || !strings.HasSuffix(line, "  ") || htmlHeadingPattern.MatchString(trimmed) {
+			line = trimmed
+		}
+		switch {
+		case line == "":
+			if len(out) == 0 || out[len(out)-1] == "" {
+				continue
+			}
+		case htmlHeadingPattern.MatchString(line):
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+		}
+		out = append(out, line)
+	}
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n") + "\n"
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeChangelogSpacing(t *testing.T) {
+	content := "\n\n# Changelog  \n## [v1.1.0] - 2025-09-01\n### 追加\n\n\n\n- 新機能 \t\n### 修正\n- バグ  \n  続き\n```\ncode  \n\n\n```\n\n\n## [v1.0.0] - 2025-08-01\n\n- 初回\n\n\n"
+	want := "# Changelog\n\n## [v1.1.0] - 2025-09-01\n\n### 追加\n\n- 新機能\n\n### 修正\n- バグ  \n  続き\n```\ncode  \n\n\n```\n\n## [v1.0.0] - 2025-08-01\n\n- 初回\n"
+	if got := normalizeChangelogSpacing(content); got != want {
+		t.Errorf("normalizeChangelogSpacing() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestUpdatedChangelogContentIsStableAcrossRuns(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	initial := "# Changelog\n\n## [v1.0.0] - 2025-08-01\n\n### 追加\n\n- 初回リリース\n\n\n"
+	if err := os.WriteFile(file, []byte(initial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := "## [v1.1.0] - 2025-09-01\n\n### 追加\n\n- 新機能\n\n"
+	want := "# Changelog\n\n## [v1.1.0] - 2025-09-01\n\n### 追加\n\n- 新機能\n\n## [v1.0.0] - 2025-08-01\n\n### 追加\n\n- 初回リリース\n"
+	for run := 1; run <= 3; run++ {
+		got, err := updatedChangelogContent(file, entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("run %d: updatedChangelogContent() =\n%q\nwant\n%q", run, got, want)
+		}
+		if err := os.WriteFile(file, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Values accepted by --spec
+const (
+	specKeepAChangelog = "keepachangelog-1.1"
+	specSimple         = "simple"
+	specCustom         = "custom"
+)
+
+// unreleasedVersion is the version of the section collecting changes that are not released yet
+const unreleasedVersion = "Unreleased"
+
+// changelogSpec is the set of structural rules the changelog follows
+type changelogSpec struct {
+	Name string
+	// RequireUnreleased keeps an Unreleased section above the releases
+	RequireUnreleased bool
+	// RequireLinkReferences requires a link reference definition for every bracketed version heading
+	RequireLinkReferences bool
+	// Sections is the allowed section vocabulary; nil allows any section
+	Sections []string
+}
+
+// specConfig is the "spec" section of the configuration file, used by --spec custom
+type specConfig struct {
+	RequireUnreleased     bool     `json:"require_unreleased,omitempty"`
+	RequireLinkReferences bool     `json:"require_link_references,omitempty"`
+	Sections              []string `json:"sections,omitempty"`
+}
+
+// changelogSpecs are the built-in specs; simple only restricts the sections, as generation always did
+var changelogSpecs = map[string]changelogSpec{
+	specKeepAChangelog: {Name: specKeepAChangelog, RequireUnreleased: true, RequireLinkReferences: true, Sections: changelogSections},
+	specSimple:         {Name: specSimple, Sections: changelogSections},
+}
+
+// generatedSections are appended to entries by the tool itself after generation, so the section
+// check of a changelog accepts them whatever the vocabulary of the spec
+var generatedSections = []string{strings.TrimPrefix(dependencySectionTitle, "### ")}
+
+// activeSpec is the spec selected with --spec
+var activeSpec = changelogSpecs[specSimple]
+
+// bracketLabelPattern matches the bracketed label of a version heading, e.g. "[v1.2.0]"
+var bracketLabelPattern = regexp.MustCompile(`\[([^\[\]]+)\]`)
+
+// setSpec selects the spec named by --spec; custom takes its rules from the configuration file
+func setSpec(name string, custom *specConfig) error {
+	if name == specCustom {
+		if custom == nil {
+			return &ConfigError{Err: fmt.Errorf("--spec %s requires a \"spec\" section in the configuration file", specCustom)}
+		}
+		activeSpec = changelogSpec{Name: specCustom, RequireUnreleased: custom.RequireUnreleased, RequireLinkReferences: custom.RequireLinkReferences, Sections: custom.Sections}
+		return nil
+	}
+	spec, ok := changelogSpecs[name]
+	if !ok {
+		return &ConfigError{Err: fmt.Errorf("--spec must be %q, %q or %q", specKeepAChangelog, specSimple, specCustom)}
+	}
+	activeSpec = spec
+	return nil
+}
+
+// allowsSection reports whether title is in the section vocabulary of the spec
+func (s changelogSpec) allowsSection(title string) bool {
+	return s.Sections == nil || slices.Contains(s.Sections, strings.TrimSpace(title))
+}
+
+// sectionNote asks the AI to use the section vocabulary of the spec when it is not the default one
+func (s changelogSpec) sectionNote() string {
+	if s.Sections == nil || slices.Equal(s.Sections, changelogSections) {
+		return ""
+	}
+	return "セクション見出しは ### " + strings.Join(s.Sections, " / ### ") + " のみを使ってください（他のセクションの指示より優先してください）"
+}
+
+// versionLabel returns the bracketed label of a version heading line, or "" when it has none
+func versionLabel(line, version string) string {
+	for _, m := range bracketLabelPattern.FindAllStringSubmatch(line, -1) {
+		if strings.Contains(m[1], version) {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// linkDefinitions returns the lowercase labels of the link reference definitions in lines
+func linkDefinitions(lines []string) map[string]bool {
+	labels := map[string]bool{}
+	for _, line := range lines {
+		if linkDefinitionPattern.MatchString(line) {
+			label, _, _ := strings.Cut(strings.TrimSpace(line)[1:], "]")
+			labels[strings.ToLower(label)] = true
+		}
+	}
+	return labels
+}
+
+// Rules reported by spec problems
+const (
+	ruleUnreleasedSection = "unreleased-section"
+	ruleSectionVocabulary = "section-vocabulary"
+	ruleLinkReference     = "link-reference"
+)
+
+// specProblem is a place where changelog content breaks a rule of the spec; Line is 1-based
+type specProblem struct {
+	Line    int
+	Rule    string
+	Message string
+	// Label is the version label without a link reference definition, for link-reference problems
+	Label string
+}
+
+// problems lists where changelog content breaks the rules of the spec, in line order except for
+// a missing Unreleased section, which is reported first at the first version heading
+func (s changelogSpec) problems(content string) []specProblem {
+	lines, numbers := changelogHeading.atxLines(normalizeNewlines(content))
+	defined := linkDefinitions(lines)
+
+	var problems []specProblem
+	hasUnreleased := false
+	firstVersion := 1
+	version := ""
+	for i, line := range lines {
+		if v, ok := changelogHeading.Version(line); ok {
+			if version == "" {
+				firstVersion = numbers[i]
+			}
+			version = v
+			if strings.EqualFold(v, unreleasedVersion) {
+				hasUnreleased = true
+			}
+			if label := versionLabel(line, v); s.RequireLinkReferences && label != "" && !defined[strings.ToLower(label)] {
+				problems = append(problems, specProblem{Line: numbers[i], Rule: ruleLinkReference, Message: "no link reference for " + label, Label: label})
+			}
+			continue
+		}
+		m := htmlHeadingPattern.FindStringSubmatch(line)
+		if version != "" && m != nil && len(m[1]) > changelogHeading.Level() && !s.allowsSection(m[2]) && !slices.Contains(generatedSections, strings.TrimSpace(m[2])) {
+			problems = append(problems, specProblem{Line: numbers[i], Rule: ruleSectionVocabulary, Message: fmt.Sprintf("section %q in %s is not allowed", strings.TrimSpace(m[2]), version)})
+		}
+	}
+	if s.RequireUnreleased && !hasUnreleased {
+		problems = append([]specProblem{{Line: firstVersion, Rule: ruleUnreleasedSection, Message: "no Unreleased section"}}, problems...)
+	}
+	return problems
+}
+
+// violations summarizes the spec problems of changelog content, listing unlinked versions together
+func (s changelogSpec) violations(content string) []string {
+	var violations, unlinked []string
+	for _, p := range s.problems(content) {
+		if p.Rule == ruleLinkReference {
+			unlinked = append(unlinked, p.Label)
+			continue
+		}
+		violations = append(violations, p.Message)
+	}
+	if len(unlinked) > 0 {
+		violations = append(violations, "no link reference for "+strings.Join(unlinked, ", "))
+	}
+	return violations
+}
+
+// releaseTagName returns the tag of a version heading, which may be written without the "v" of
+// the tag or the module directory of --module tags
+func releaseTagName(version string) string {
+	tag := moduleTag(version)
+	if !tagExists(tag) && tagExists(moduleTag("v"+version)) {
+		return moduleTag("v" + version)
+	}
+	return tag
+}
+
+// compareURL returns the web URL comparing two refs on the hosting service
+func compareURL(repoURL, from, to string) string {
+	if strings.Contains(repoURL, "gitlab") {
+		return repoURL + "/-/compare/" + from + "..." + to
+	}
+	return repoURL + "/compare/" + from + "..." + to
+}
+
+// releaseURL returns the web URL of the first release, which has nothing to compare with
+func releaseURL(repoURL, tag string) string {
+	if strings.Contains(repoURL, "gitlab") {
+		return repoURL + "/-/tags/" + tag
+	}
+	return repoURL + "/releases/tag/" + tag
+}
+
+// apply adds the structure the spec requires to changelog lines: the Unreleased section above
+// the releases and, when repoURL is known, missing link reference definitions at the end. The
+// Unreleased link always compares the latest release with HEAD, so it is kept up to date.
+func (s changelogSpec) apply(lines []string, repoURL string) []string {
+	type heading struct{ version, label string }
+	var headings []heading
+	first := -1
+	for i, line := range lines {
+		if v, ok := changelogHeading.Version(line); ok {
+			if first == -1 {
+				first = i
+			}
+			headings = append(headings, heading{v, versionLabel(line, v)})
+		}
+	}
+
+	if s.RequireUnreleased && !slices.ContainsFunc(headings, func(h heading) bool { return strings.EqualFold(h.version, unreleasedVersion) }) {
+		section := strings.Repeat("#", changelogHeading.Level()) + " [" + unreleasedVersion + "]"
+		if first == -1 {
+			lines = append(lines, "", section)
+		} else {
+			lines = slices.Insert(lines, first, section, "")
+		}
+		headings = append([]heading{{unreleasedVersion, unreleasedVersion}}, headings...)
+	}
+	if !s.RequireLinkReferences || repoURL == "" {
+		return lines
+	}
+
+	defined := linkDefinitions(lines)
+	var added []string
+	for i, h := range headings {
+		if h.label == "" {
+			continue
+		}
+		var previous string
+		for _, older := range headings[i+1:] {
+			if !strings.EqualFold(older.version, unreleasedVersion) {
+				previous = releaseTagName(older.version)
+				break
+			}
+		}
+		unreleased := strings.EqualFold(h.version, unreleasedVersion)
+		var url string
+		switch {
+		case unreleased && previous == "":
+			continue
+		case unreleased:
+			url = compareURL(repoURL, previous, gitRefHEAD)
+			lines = slices.DeleteFunc(lines, func(line string) bool {
+				return linkDefinitionPattern.MatchString(line) && strings.HasPrefix(strings.ToLower(strings.TrimSpace(line)), "["+strings.ToLower(h.label)+"]:")
+			})
+		case defined[strings.ToLower(h.label)]:
+			continue
+		case previous == "":
+			url = releaseURL(repoURL, releaseTagName(h.version))
+		default:
+			url = compareURL(repoURL, previous, releaseTagName(h.version))
+		}
+		added = append(added, fmt.Sprintf("[%s]: %s", h.label, url))
+	}
+	if len(added) == 0 {
+		return lines
+	}
+
+	// New definitions go above the existing ones, which are older releases
+	end := entriesEnd(lines)
+	tail := slices.DeleteFunc(slices.Clone(lines[end:]), func(line string) bool { return strings.TrimSpace(line) == "" })
+	result := append(slices.Clone(lines[:end]), "")
+	result = append(result, added...)
+	return append(result, tail...)
+}
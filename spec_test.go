@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetSpec(t *testing.T) {
+	defer func() { activeSpec = changelogSpecs[specSimple] }()
+
+	var configErr *ConfigError
+	if err := setSpec("strict", nil); !errors.As(err, &configErr) {
+		t.Errorf("setSpec(strict) = %v, want a ConfigError", err)
+	}
+	if err := setSpec(specCustom, nil); !errors.As(err, &configErr) {
+		t.Errorf("setSpec(custom) without a config section = %v, want a ConfigError", err)
+	}
+	if err := setSpec(specCustom, &specConfig{RequireUnreleased: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !activeSpec.RequireUnreleased || activeSpec.RequireLinkReferences || !activeSpec.allowsSection("なんでも") {
+		t.Errorf("custom spec = %+v", activeSpec)
+	}
+	if err := setSpec(specSimple, nil); err != nil || activeSpec.allowsSection("その他") || !activeSpec.allowsSection("追加") {
+		t.Errorf("simple spec = %+v, %v", activeSpec, err)
+	}
+}
+
+func TestSpecViolations(t *testing.T) {
+	content := "# Changelog\n\n## [v1.1.0] - 2024-02-01\n\n### 追加\n\n- B\n\n### 依存関係\n\n- `x`: v1 → v2\n\n### その他\n\n- C\n\n## [v1.0.0] - 2024-01-01\n\n- A\n\n[v1.0.0]: https://example.com\n"
+
+	if got := changelogSpecs[specSimple].violations(content); !reflect.DeepEqual(got, []string{`section "その他" in v1.1.0 is not allowed`}) {
+		t.Errorf("simple violations = %q", got)
+	}
+	want := []string{"no Unreleased section", `section "その他" in v1.1.0 is not allowed`, "no link reference for v1.1.0"}
+	if got := changelogSpecs[specKeepAChangelog].violations(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("keepachangelog violations = %q, want %q", got, want)
+	}
+}
+
+func TestUpdatedChangelogContentKeepAChangelog(t *testing.T) {
+	savedDir, savedOpts := gitDir, genOpts
+	defer func() { gitDir, genOpts, activeSpec = savedDir, savedOpts, changelogSpecs[specSimple] }()
+	gitDir = t.TempDir()
+	activeSpec = changelogSpecs[specKeepAChangelog]
+	genOpts.RepoURL = "https://github.com/example/app"
+
+	path := filepath.Join(gitDir, "CHANGELOG.md")
+	existing := "# Changelog\n\n## [v1.0.0] - 2024-01-01\n\n### 追加\n\n- A\n\n[v1.0.0]: https://github.com/example/app/releases/tag/v1.0.0\n"
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := updatedChangelogContent(path, "## [v1.1.0] - 2024-02-01\n\n### 追加\n\n- B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Changelog\n\n## [Unreleased]\n\n## [v1.1.0] - 2024-02-01\n\n### 追加\n\n- B\n\n## [v1.0.0] - 2024-01-01\n\n### 追加\n\n- A\n\n" +
+		"[Unreleased]: https://github.com/example/app/compare/v1.1.0...HEAD\n" +
+		"[v1.1.0]: https://github.com/example/app/compare/v1.0.0...v1.1.0\n" +
+		"[v1.0.0]: https://github.com/example/app/releases/tag/v1.0.0\n"
+	if got != want {
+		t.Errorf("updatedChangelogContent() =\n%s\nwant\n%s", got, want)
+	}
+	if v := activeSpec.violations(got); len(v) != 0 {
+		t.Errorf("updated changelog still has violations: %q", v)
+	}
+
+	// The Unreleased link follows the latest release
+	if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = updatedChangelogContent(path, "## [v1.2.0] - 2024-03-01\n\n### 修正\n\n- C")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "[Unreleased]: https://github.com/example/app/compare/v1.2.0...HEAD\n[v1.2.0]: https://github.com/example/app/compare/v1.1.0...v1.2.0\n[v1.1.0]:") ||
+		strings.Count(got, "[Unreleased]:") != 1 {
+		t.Errorf("updatedChangelogContent() did not update the link references:\n%s", got)
+	}
+}
+
+func TestEntryViolationsFollowSpec(t *testing.T) {
+	defer func() { activeSpec = changelogSpecs[specSimple] }()
+	heading := "## [v1.0.0] - 2024-01-01"
+	entry := heading + "\n\n### Features\n\n- A"
+
+	if got := entryViolations(entry, heading); len(got) != 1 {
+		t.Errorf("entryViolations() with the simple spec = %q, want the section reported", got)
+	}
+	activeSpec = changelogSpec{Name: specCustom, Sections: []string{"Features", "Fixes"}}
+	if got := entryViolations(entry, heading); len(got) != 0 {
+		t.Errorf("entryViolations() with custom sections = %q", got)
+	}
+	if note := activeSpec.sectionNote(); !strings.Contains(note, "### Features / ### Fixes") {
+		t.Errorf("sectionNote() = %q", note)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	spinnerInterval   = 100 * time.Millisecond
+	heartbeatInterval = 30 * time.Second
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerOutput guards the output of running spinners against pauseSpinners
+var spinnerOutput struct {
+	sync.Mutex
+	paused int
+}
+
+// pauseSpinners keeps running spinners from writing until resume is called, so an interactive
+// question printed meanwhile, e.g. by --show-prompt, is not overwritten
+func pauseSpinners() (resume func()) {
+	spinnerOutput.Lock()
+	if spinnerOutput.paused == 0 && animatedSpinners > 0 {
+		ui.write("\r\033[K")
+	}
+	spinnerOutput.paused++
+	spinnerOutput.Unlock()
+	return func() {
+		spinnerOutput.Lock()
+		spinnerOutput.paused--
+		spinnerOutput.Unlock()
+	}
+}
+
+// animatedSpinners counts the running animated spinners; guarded by spinnerOutput
+var animatedSpinners int
+
+// spinner shows that a long-running AI call is still in progress
+type spinner struct {
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// startSpinner starts an animated spinner, or periodic heartbeat lines when animate is false;
+// quiet mode shows neither
+func startSpinner(label string, animate bool) *spinner {
+	s := &spinner{stop: make(chan struct{})}
+	animate = animate && !ui.quiet
+	start := time.Now()
+
+	interval := heartbeatInterval
+	if animate {
+		interval = spinnerInterval
+		spinnerOutput.Lock()
+		animatedSpinners++
+		spinnerOutput.Unlock()
+	} else {
+		ui.Printf("🧠 %s...\n", label)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		frame := 0
+		for {
+			select {
+			case <-s.stop:
+				if animate {
+					spinnerOutput.Lock()
+					if spinnerOutput.paused == 0 {
+						ui.write("\r\033[K")
+					}
+					animatedSpinners--
+					spinnerOutput.Unlock()
+				}
+				return
+			case <-ticker.C:
+				elapsed := time.Since(start).Round(time.Second)
+				spinnerOutput.Lock()
+				switch {
+				case spinnerOutput.paused > 0:
+				case animate:
+					ui.write(fmt.Sprintf("\r\033[K%s %s (%s)", spinnerFrames[frame%len(spinnerFrames)], label, elapsed))
+					frame++
+				default:
+					ui.Printf("⏳ Still working on %s (%s elapsed)\n", label, elapsed)
+				}
+				spinnerOutput.Unlock()
+			}
+		}
+	}()
+	return s
+}
+
+// Stop stops the spinner and clears its line
+func (s *spinner) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpinnerHeartbeat(t *testing.T) {
+	var buf bytes.Buffer
+	original := ui
+	ui = &console{out: &buf, plain: true}
+	defer func() { ui = original }()
+
+	s := startSpinner("Generating entry for v1.0.0", false)
+	s.Stop()
+
+	if got := buf.String(); !strings.Contains(got, "Generating entry for v1.0.0...") {
+		t.Errorf("heartbeat spinner output = %q", got)
+	}
+}
+
+func TestPauseSpinners(t *testing.T) {
+	var buf bytes.Buffer
+	original := ui
+	ui = &console{out: &buf}
+	defer func() { ui = original }()
+
+	s := startSpinner("Generating entry for v1.0.0", true)
+	resume := pauseSpinners()
+	buf.Reset()
+	time.Sleep(3 * spinnerInterval)
+	paused := buf.String()
+	resume()
+	s.Stop()
+
+	if paused != "" {
+		t.Errorf("paused spinner wrote %q", paused)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// splitOnly writes the per-version files of --split-dir without updating the changelog itself
+var splitOnly bool
+
+// splitTarget returns the output target of --split-dir: one file per version with front matter
+func splitTarget(dir string) outputTarget {
+	return outputTarget{Path: path.Join(filepath.ToSlash(dir), versionPlaceholder+".md"), FrontMatter: true}
+}
+
+// versionFileName returns the file name part of a per-version output for a version
+func versionFileName(version string) string {
+	return strings.NewReplacer("/", "-", `\`, "-").Replace(version)
+}
+
+// frontMatterPage renders an entry as a page for static site generators such as Hugo and
+// Docusaurus: the version and date go into the front matter, which also provides the title, so
+// the version heading is left out of the body
+func frontMatterPage(e changelogEntry) (string, error) {
+	page, err := frontMatter.render(e)
+	if err != nil {
+		return "", err
+	}
+	if e.Body != "" {
+		page += "\n" + e.Body + "\n"
+	}
+	return page, nil
+}
+
+// splitVersions returns the versions that already have a file in the per-version outputs, so
+// catch-up does not regenerate them when the changelog itself is not written
+func splitVersions(targets []outputTarget) []string {
+	var versions []string
+	for _, target := range targets {
+		if !strings.Contains(target.Path, versionPlaceholder) {
+			continue
+		}
+		pattern := filepath.Join(gitDir, filepath.FromSlash(target.Path))
+		prefix, suffix, _ := strings.Cut(pattern, versionPlaceholder)
+		matches, _ := filepath.Glob(strings.ReplaceAll(pattern, versionPlaceholder, "*"))
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && info.Mode().IsRegular() {
+				versions = append(versions, strings.TrimSuffix(strings.TrimPrefix(match, prefix), suffix))
+			}
+		}
+	}
+	return versions
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFrontMatterPage(t *testing.T) {
+	entry := changelogEntry{Version: "v1.2.0", Heading: "## [v1.2.0] - 2025-09-01", Body: "### 追加\n\n- B"}
+	want := "---\ntitle: \"v1.2.0\"\nslug: \"v1.2.0\"\nversion: \"v1.2.0\"\ndate: 2025-09-01\n---\n\n### 追加\n\n- B\n"
+	if got, err := frontMatterPage(entry); err != nil || got != want {
+		t.Errorf("frontMatterPage() = %q, %v, want %q", got, err, want)
+	}
+	unreleased := changelogEntry{Version: "Unreleased", Heading: "## [Unreleased]"}
+	if got, _ := frontMatterPage(unreleased); got != "---\ntitle: \"Unreleased\"\nslug: \"unreleased\"\nversion: \"Unreleased\"\n---\n" {
+		t.Errorf("frontMatterPage() without a date = %q", got)
+	}
+}
+
+func TestSplitOnly(t *testing.T) {
+	dir := t.TempDir()
+	originalDir, originalTargets, originalSplitOnly := gitDir, outputTargets, splitOnly
+	defer func() { gitDir, outputTargets, splitOnly = originalDir, originalTargets, originalSplitOnly }()
+	gitDir = dir
+	outputTargets = []outputTarget{splitTarget("docs/releases/")}
+	splitOnly = true
+
+	changelog := filepath.Join(dir, "CHANGELOG.md")
+	written, err := writeChangelogOutputs(changelog, "## [v1.1.0] - 2025-09-01\n\n- B\n\n## [v1.0.0] - 2025-08-01\n\n- A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 2 {
+		t.Errorf("written = %v, want the two version files", written)
+	}
+	if _, err := os.Stat(changelog); !os.IsNotExist(err) {
+		t.Errorf("the changelog was written with --split-only: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "docs", "releases", "v1.1.0.md"))
+	if err != nil || string(content) != "---\ntitle: \"v1.1.0\"\nslug: \"v1.1.0\"\nversion: \"v1.1.0\"\ndate: 2025-09-01\n---\n\n- B\n" {
+		t.Errorf("v1.1.0.md = %q, %v", content, err)
+	}
+
+	versions := splitVersions(outputTargets)
+	sort.Strings(versions)
+	if !reflect.DeepEqual(versions, []string{"v1.0.0", "v1.1.0"}) {
+		t.Errorf("splitVersions() = %q", versions)
+	}
+}
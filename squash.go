@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxExpandedSquashMerges caps the pull requests fetched for --expand-squash-merges in one entry
+const maxExpandedSquashMerges = 20
+
+// squashMergePattern matches GitHub squash-merge subjects such as "abc1234 Add OAuth login (#456)"
+// in git log --oneline output
+var squashMergePattern = regexp.MustCompile(`^(\S+)\s+(.+?)\s+\(#(\d+)\)$`)
+
+// squashMerge is a commit that squash-merged a whole pull request
+type squashMerge struct {
+	Hash    string
+	Subject string
+	Number  string
+}
+
+// squashMerges returns the squash-merge commits in one-line commit output
+func squashMerges(commits string) []squashMerge {
+	var merges []squashMerge
+	for _, line := range strings.Split(commits, "\n") {
+		if m := squashMergePattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			merges = append(merges, squashMerge{Hash: m[1], Subject: m[2], Number: m[3]})
+		}
+	}
+	return merges
+}
+
+// describeSquashMerge describes the pull request behind a squash merge with its description and commits
+func describeSquashMerge(merge squashMerge) (string, error) {
+	pr, err := fetchPullRequest("", merge.Number)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "#%s %s", merge.Number, merge.Subject)
+	if description := strings.TrimSpace(firstLines(normalizeNewlines(pr.Body), 20)); description != "" {
+		b.WriteString("\n    説明:\n      " + strings.ReplaceAll(description, "\n", "\n      "))
+	}
+	if commits := strings.TrimSpace(pr.Commits); commits != "" {
+		b.WriteString("\n    PR内のコミット:\n      " + strings.ReplaceAll(commits, "\n", "\n      "))
+	}
+	return b.String(), nil
+}
+
+// squashMergeNotes returns the prompt instructions for squash-merged pull requests in commits: a
+// request to reference the PR number, and with ExpandSquashMerges the description and commits of
+// each PR, which the squashed commit no longer carries
+func squashMergeNotes(commits string) []string {
+	merges := squashMerges(commits)
+	if len(merges) == 0 {
+		return nil
+	}
+
+	var notes []string
+	if !genOpts.LinkCommits {
+		notes = append(notes, "件名が `(#123)` で終わるコミットはGitHubのsquash mergeで、PR全体の変更を1つにまとめたものです。これらのコミットに基づく項目の末尾には `(#123)` の形式でPR番号を付けてください")
+	}
+	if !genOpts.ExpandSquashMerges {
+		return notes
+	}
+
+	if len(merges) > maxExpandedSquashMerges {
+		ui.Printf("ℹ️  Expanding the first %d of %d squash-merged pull requests.\n", maxExpandedSquashMerges, len(merges))
+		merges = merges[:maxExpandedSquashMerges]
+	}
+	var described []string
+	for _, merge := range merges {
+		description, err := describeSquashMerge(merge)
+		if err != nil {
+			ui.Printf("⚠️  Warning: %v\n", err)
+			continue
+		}
+		described = append(described, description)
+	}
+	if len(described) > 0 {
+		notes = append(notes, "squash mergeされた以下のPRの説明とPR内のコミットも参考に、変更内容を具体的に記載してください:\n  - "+strings.Join(described, "\n  - "))
+	}
+	return notes
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSquashMerges(t *testing.T) {
+	got := squashMerges("abc1234 Add OAuth login (#456)\ndef5678 fix: typo\n0123456 feat(api): paginate results (#78)\n1111111 Mention #9 in docs")
+	want := []squashMerge{{"abc1234", "Add OAuth login", "456"}, {"0123456", "feat(api): paginate results", "78"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("squashMerges() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSquashMergeNotes(t *testing.T) {
+	originalOpts, originalFetch, originalUI := genOpts, fetchPullRequest, ui
+	defer func() { genOpts, fetchPullRequest, ui = originalOpts, originalFetch, originalUI }()
+	ui = &console{out: &strings.Builder{}, plain: true}
+
+	fetchPullRequest = func(repo, number string) (*pullRequest, error) {
+		if number != "456" {
+			return nil, errors.New("not found")
+		}
+		return &pullRequest{Body: "Adds GitHub and Google sign-in.", Commits: "aaa1111 add oauth client\nbbb2222 add login page"}, nil
+	}
+	commits := "abc1234 Add OAuth login (#456)\ndef5678 Remove legacy login (#457)"
+
+	genOpts = generationOptions{}
+	notes := squashMergeNotes(commits)
+	if len(notes) != 1 || !strings.Contains(notes[0], "(#123)") {
+		t.Errorf("squashMergeNotes() = %q, want only the PR number note", notes)
+	}
+	if notes := squashMergeNotes("abc1234 fix: typo"); notes != nil {
+		t.Errorf("squashMergeNotes() without squash merges = %q", notes)
+	}
+
+	genOpts = generationOptions{LinkCommits: true, ExpandSquashMerges: true}
+	notes = squashMergeNotes(commits)
+	if len(notes) != 1 {
+		t.Fatalf("squashMergeNotes() with --link-commits = %q, want only the expansion", notes)
+	}
+	for _, want := range []string{"#456 Add OAuth login", "Adds GitHub and Google sign-in.", "bbb2222 add login page"} {
+		if !strings.Contains(notes[0], want) {
+			t.Errorf("expansion lacks %q:\n%s", want, notes[0])
+		}
+	}
+	if strings.Contains(notes[0], "#457") {
+		t.Errorf("expansion includes a PR that could not be fetched:\n%s", notes[0])
+	}
+}
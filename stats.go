@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// emptyTreeHash is git's well-known empty tree, used to diff an initial release against nothing
+const emptyTreeHash = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+var shortstatPattern = regexp.MustCompile(`(\d+) files? changed(?:, (\d+) insertions?\(\+\))?(?:, (\d+) deletions?\(-\))?`)
+
+// releaseStats summarizes the size of a release range
+type releaseStats struct {
+	Commits      int
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+	Contributors int
+}
+
+// String formats the stats as a single human-readable line
+func (s releaseStats) String() string {
+	return fmt.Sprintf("%s commits, %s files changed, +%s −%s, %s contributors",
+		formatThousands(s.Commits), formatThousands(s.FilesChanged),
+		formatThousands(s.Insertions), formatThousands(s.Deletions), formatThousands(s.Contributors))
+}
+
+// computeReleaseStats collects commit, diff and contributor counts for from..to in the selected
+// module; an empty from means the whole history
+func computeReleaseStats(from, to string) (releaseStats, error) {
+	var stats releaseStats
+
+	revRange, diffFrom := to, emptyTreeHash
+	if from != "" && from != gitRefHEAD {
+		revRange, diffFrom = from+".."+to, from
+	}
+
+	output, err := gitCommand(append([]string{"rev-list", "--count", revRange}, modulePathspec()...)...).Output()
+	if err != nil {
+		return stats, fmt.Errorf("failed to count commits: %w", err)
+	}
+	stats.Commits, _ = strconv.Atoi(strings.TrimSpace(string(output)))
+
+	output, err = gitCommand(append([]string{"diff", "--shortstat", diffFrom, to}, modulePathspec()...)...).Output()
+	if err != nil {
+		return stats, fmt.Errorf("failed to get diff stats: %w", err)
+	}
+	stats.FilesChanged, stats.Insertions, stats.Deletions = parseShortstat(string(output))
+
+	output, err = gitCommand(append([]string{"shortlog", "-sne", revRange}, modulePathspec()...)...).Output()
+	if err != nil {
+		return stats, fmt.Errorf("failed to count contributors: %w", err)
+	}
+	stats.Contributors = countLines(string(output))
+
+	return stats, nil
+}
+
+// parseShortstat parses the output of `git diff --shortstat`
+func parseShortstat(output string) (files, insertions, deletions int) {
+	m := shortstatPattern.FindStringSubmatch(output)
+	if m == nil {
+		return 0, 0, 0
+	}
+	files, _ = strconv.Atoi(m[1])
+	insertions, _ = strconv.Atoi(m[2])
+	deletions, _ = strconv.Atoi(m[3])
+	return files, insertions, deletions
+}
+
+// formatThousands formats n with comma thousands separators
+func formatThousands(n int) string {
+	if n < 0 {
+		return "-" + formatThousands(-n)
+	}
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// appendStats appends the release stats line to an entry when --stats is enabled
+func appendStats(entry, from, to string) string {
+	if !genOpts.Stats {
+		return entry
+	}
+	stats, err := computeReleaseStats(from, to)
+	if err != nil {
+		ui.Printf("⚠️  Warning: Failed to compute release stats: %v\n", err)
+		return entry
+	}
+	return strings.TrimRight(entry, "\n") + "\n\n_" + stats.String() + "_"
+}
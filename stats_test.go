@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestParseShortstat(t *testing.T) {
+	testCases := []struct {
+		input                        string
+		files, insertions, deletions int
+	}{
+		{" 34 files changed, 1204 insertions(+), 356 deletions(-)\n", 34, 1204, 356},
+		{" 1 file changed, 1 insertion(+)\n", 1, 1, 0},
+		{" 2 files changed, 5 deletions(-)\n", 2, 0, 5},
+		{"", 0, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		files, insertions, deletions := parseShortstat(tc.input)
+		if files != tc.files || insertions != tc.insertions || deletions != tc.deletions {
+			t.Errorf("parseShortstat(%q) = %d, %d, %d; want %d, %d, %d",
+				tc.input, files, insertions, deletions, tc.files, tc.insertions, tc.deletions)
+		}
+	}
+}
+
+func TestReleaseStatsString(t *testing.T) {
+	stats := releaseStats{Commits: 12, FilesChanged: 34, Insertions: 1204, Deletions: 356, Contributors: 4}
+	want := "12 commits, 34 files changed, +1,204 −356, 4 contributors"
+	if got := stats.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatThousands(t *testing.T) {
+	testCases := map[int]string{0: "0", 999: "999", 1000: "1,000", 1234567: "1,234,567", -1500: "-1,500"}
+	for n, want := range testCases {
+		if got := formatThousands(n); got != want {
+			t.Errorf("formatThousands(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
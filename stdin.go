@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// pipeInput is the change information read with --stdin instead of running git
+type pipeInput struct {
+	// PreviousTag is the tag the changes start from; empty for an initial release
+	PreviousTag string `json:"previous_tag"`
+	// Commits lists the commits, one "<hash> <subject>" per line
+	Commits string `json:"commits"`
+	// Diff lists the changed files in git diff --name-status format
+	Diff string `json:"diff"`
+}
+
+// pipeSectionPattern matches the delimiter line starting a section of the plain stdin format
+var pipeSectionPattern = regexp.MustCompile(`^--- ([a-z-]+) ---$`)
+
+// parsePipeInput reads --stdin input: either a JSON object with previous_tag, commits and diff,
+// or plain text split into sections by "--- previous-tag ---", "--- commits ---" and "--- diff ---" lines
+func parsePipeInput(r io.Reader) (*pipeInput, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	content := strings.TrimSpace(normalizeNewlines(string(data)))
+
+	var input pipeInput
+	if strings.HasPrefix(content, "{") {
+		if err := json.Unmarshal([]byte(content), &input); err != nil {
+			return nil, &ConfigError{Err: fmt.Errorf("invalid JSON on stdin: %w", err)}
+		}
+	} else {
+		sections := map[string]*string{
+			"previous-tag": &input.PreviousTag,
+			"commits":      &input.Commits,
+			"diff":         &input.Diff,
+		}
+		var current *string
+		for _, line := range strings.Split(content, "\n") {
+			if m := pipeSectionPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				section, ok := sections[m[1]]
+				if !ok {
+					return nil, &ConfigError{Err: fmt.Errorf("unknown stdin section %q", m[1])}
+				}
+				current = section
+				continue
+			}
+			if current == nil {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				return nil, &ConfigError{Err: fmt.Errorf("stdin must start with a section line such as \"--- commits ---\"")}
+			}
+			*current += line + "\n"
+		}
+	}
+
+	input.PreviousTag = strings.TrimSpace(input.PreviousTag)
+	input.Commits = commitFilter.filterOneline(strings.TrimSpace(normalizeNewlines(input.Commits)))
+	input.Diff = aiFilter.filterNameStatus(strings.TrimSpace(normalizeNewlines(input.Diff)))
+	return &input, nil
+}
+
+// disableGitOptions turns off generation options that read the repository, which is not
+// available with --stdin; bot commits are left to the AI because they cannot be counted
+func disableGitOptions() {
+	genOpts.DependencySection = false
+	genOpts.Stats = false
+	genOpts.AttributeAuthors = false
+	genOpts.SecurityAdvisories = false
+	genOpts.IncludeStaged = false
+	genOpts.IncludeWorkingTree = false
+	genOpts.IncludeUntracked = false
+	if genOpts.BotCommits == botCommitsCollapse {
+		genOpts.BotCommits = botCommitsKeep
+	}
+}
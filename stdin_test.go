@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePipeInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  pipeInput
+	}{
+		{
+			name:  "sections",
+			input: "--- previous-tag ---\r\nv1.0.0\r\n--- commits ---\r\nabc1234 feat: 追加\r\ndef5678 fix: 修正\r\n--- diff ---\r\nM\tmain.go\r\n",
+			want:  pipeInput{PreviousTag: "v1.0.0", Commits: "abc1234 feat: 追加\ndef5678 fix: 修正", Diff: "M\tmain.go"},
+		},
+		{
+			name:  "json",
+			input: `{"previous_tag": "v1.0.0", "commits": "abc1234 feat: 追加\n", "diff": "A\tnew.go\n"}`,
+			want:  pipeInput{PreviousTag: "v1.0.0", Commits: "abc1234 feat: 追加", Diff: "A\tnew.go"},
+		},
+		{
+			name:  "initial release",
+			input: "--- commits ---\nabc1234 initial\n",
+			want:  pipeInput{Commits: "abc1234 initial"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePipeInput(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("parsePipeInput() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("parsePipeInput() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePipeInputErrors(t *testing.T) {
+	for _, input := range []string{"abc1234 feat: no section\n", "--- changes ---\nx\n", "{not json"} {
+		if _, err := parsePipeInput(strings.NewReader(input)); exitCode(err) != ExitConfig {
+			t.Errorf("parsePipeInput(%q) error = %v, want a config error", input, err)
+		}
+	}
+}
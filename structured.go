@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// changelogSections are the Keep a Changelog sections in the order they are rendered
+var changelogSections = []string{"追加", "変更", "非推奨", "削除", "修正", "セキュリティ"}
+
+// defaultEntryTemplate renders a structured entry as Keep a Changelog Markdown
+const defaultEntryTemplate = `{{.Heading}}
+{{- range .Sections}}
+
+### {{.Title}}
+{{range .Items}}
+- {{.}}
+{{- end}}
+{{- end}}
+`
+
+// entryTemplate renders structured entries; replaced by the entry_template configuration
+var entryTemplate = template.Must(template.New("entry").Parse(defaultEntryTemplate))
+
+var blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+
+// structuredEntry is the JSON the AI returns in structured mode
+type structuredEntry struct {
+	Sections []structuredSection `json:"sections"`
+}
+
+// structuredSection is one changelog section with its bullet items
+type structuredSection struct {
+	Title string   `json:"title"`
+	Items []string `json:"items"`
+}
+
+// entryTemplateData is passed to the entry template
+type entryTemplateData struct {
+	Version  string
+	Date     string
+	Heading  string
+	Sections []structuredSection
+}
+
+// structuredOutputNote asks the AI for JSON instead of Markdown
+func structuredOutputNote() string {
+	return fmt.Sprintf("上記のMarkdown形式ではなく、次のJSON形式のみを出力してください（コードブロックや説明文は不要です）: "+
+		`{"sections": [{"title": "追加", "items": ["項目1", "項目2"]}]}`+
+		"。title には %s のいずれかを使い、items の各要素は先頭の `- ` を付けない1行の文にしてください", strings.Join(changelogSections, "、"))
+}
+
+// loadEntryTemplate parses a user template file for structured entries
+func loadEntryTemplate(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entry template: %w", err)
+	}
+	tmpl, err := template.New("entry").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse entry template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// parseStructuredEntry extracts the JSON object from an AI response, tolerating code fences and surrounding text
+func parseStructuredEntry(response string) (*structuredEntry, error) {
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("AI response does not contain a JSON object")
+	}
+	var entry structuredEntry
+	if err := json.Unmarshal([]byte(response[start:end+1]), &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse AI response as JSON: %w", err)
+	}
+	return &entry, nil
+}
+
+// orderedSections merges sections with the same title, drops empty ones and sorts them in
+// Keep a Changelog order; unknown titles follow in the order the AI returned them
+func orderedSections(sections []structuredSection) []structuredSection {
+	var titles []string
+	items := map[string][]string{}
+	for _, s := range sections {
+		title := strings.TrimSpace(strings.TrimLeft(s.Title, "# "))
+		for _, item := range s.Items {
+			item = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(item), "- "))
+			if item == "" {
+				continue
+			}
+			if _, ok := items[title]; !ok {
+				titles = append(titles, title)
+			}
+			items[title] = append(items[title], item)
+		}
+	}
+
+	var ordered []structuredSection
+	for _, title := range changelogSections {
+		if len(items[title]) > 0 {
+			ordered = append(ordered, structuredSection{Title: title, Items: items[title]})
+			delete(items, title)
+		}
+	}
+	for _, title := range titles {
+		if len(items[title]) > 0 {
+			ordered = append(ordered, structuredSection{Title: title, Items: items[title]})
+		}
+	}
+	return ordered
+}
+
+// renderStructuredEntry renders the AI's JSON response through the entry template
+func renderStructuredEntry(response, version, date, heading string) (string, error) {
+	entry, err := parseStructuredEntry(response)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	data := entryTemplateData{Version: version, Date: date, Heading: heading, Sections: orderedSections(entry.Sections)}
+	if err := entryTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render entry template: %w", err)
+	}
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(buf.String(), "\n\n")), nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestRenderStructuredEntry(t *testing.T) {
+	response := "```json\n" + `{"sections": [
+  {"title": "修正", "items": ["クラッシュを修正"]},
+  {"title": "### 追加", "items": ["- export コマンドを追加", ""]},
+  {"title": "ドキュメント", "items": ["READMEを更新"]},
+  {"title": "変更", "items": []},
+  {"title": "追加", "items": ["watch コマンドを追加"]}
+]}` + "\n```"
+
+	got, err := renderStructuredEntry(response, "v1.2.0", "2025-09-01", "## [v1.2.0] - 2025-09-01")
+	if err != nil {
+		t.Fatalf("renderStructuredEntry() error = %v", err)
+	}
+
+	want := `## [v1.2.0] - 2025-09-01
+
+### 追加
+
+- export コマンドを追加
+- watch コマンドを追加
+
+### 修正
+
+- クラッシュを修正
+
+### ドキュメント
+
+- READMEを更新`
+	if got != want {
+		t.Errorf("renderStructuredEntry() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderStructuredEntryCustomTemplate(t *testing.T) {
+	original := entryTemplate
+	defer func() { entryTemplate = original }()
+	entryTemplate = template.Must(template.New("entry").Parse("# {{.Version}} ({{.Date}})\n{{range .Sections}}{{range .Items}}* [{{$.Version}}] {{.}}\n{{end}}{{end}}"))
+
+	got, err := renderStructuredEntry(`{"sections":[{"title":"追加","items":["A"]}]}`, "v1.2.0", "2025-09-01", "")
+	if err != nil {
+		t.Fatalf("renderStructuredEntry() error = %v", err)
+	}
+	if got != "# v1.2.0 (2025-09-01)\n* [v1.2.0] A" {
+		t.Errorf("renderStructuredEntry() = %q", got)
+	}
+}
+
+func TestParseStructuredEntryErrors(t *testing.T) {
+	for _, response := range []string{"## [v1.0.0]\n\n- not json", `{"sections": "wrong"}`} {
+		if _, err := parseStructuredEntry(response); err == nil {
+			t.Errorf("parseStructuredEntry(%q) should fail", response)
+		}
+	}
+}
+
+func TestGenerateChangelogEntryStructured(t *testing.T) {
+	original := genOpts
+	defer func() { genOpts = original }()
+	genOpts = generationOptions{Structured: true}
+
+	mock := &MockExecutor{response: `{"sections":[{"title":"修正","items":["バグを修正"]}]}`}
+	got, err := generateChangelogEntryForTag(mock, "", "v1.0.0", "M\tmain.go", "abc fix: bug", "")
+	if err != nil {
+		t.Fatalf("generateChangelogEntryForTag() error = %v", err)
+	}
+	if !strings.HasPrefix(got, "## [v1.0.0] - ") || !strings.HasSuffix(got, "### 修正\n\n- バグを修正") {
+		t.Errorf("generateChangelogEntryForTag() = %q", got)
+	}
+	if !strings.Contains(mock.prompts[0], `{"sections"`) {
+		t.Error("prompt should ask for JSON output")
+	}
+}
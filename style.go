@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// maxStyleExamples is the number of recent entries shown to the AI as style examples
+	maxStyleExamples = 2
+	// maxStyleExampleLines skips unusually long entries, which would mostly inflate the prompt
+	maxStyleExampleLines = 40
+)
+
+// styleEntries are the existing changelog entries offered as style examples; nil disables them
+var styleEntries []changelogEntry
+
+// loadStyleExamples reads the entries of changelogFile that generation prompts use as style examples
+func loadStyleExamples(changelogFile string) {
+	entries, err := readChangelogEntries(changelogFile)
+	if err != nil {
+		return
+	}
+	styleEntries = entries
+}
+
+// styleExamples returns up to maxStyleExamples of the most recent well-formed entries, leaving out
+// the entry of tag so a regenerated entry is not copied from its previous version
+func styleExamples(tag string) []string {
+	var examples []string
+	for _, entry := range styleEntries {
+		if len(examples) == maxStyleExamples {
+			break
+		}
+		if sameVersion(entry.Version, tag) {
+			continue
+		}
+		markdown := withoutMetadataComment(entry.Markdown())
+		if strings.Count(markdown, "\n") >= maxStyleExampleLines || !strings.Contains(markdown, "\n- ") {
+			continue
+		}
+		if len(entryViolations(markdown, entry.Heading)) > 0 {
+			continue
+		}
+		examples = append(examples, markdown)
+	}
+	return examples
+}
+
+// withoutMetadataComment removes the generation metadata comment from an entry
+func withoutMetadataComment(entry string) string {
+	var lines []string
+	for _, line := range strings.Split(entry, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), metadataCommentPrefix) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// styleExampleSection formats the style examples for the generation prompt of tag
+func styleExampleSection(tag string) string {
+	examples := styleExamples(tag)
+	if len(examples) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+
+このプロジェクトのCHANGELOGにある最近のエントリー（文体・項目の粒度・言語をこれに合わせてください。内容は転記しないでください）:
+---
+%s
+---`, strings.Join(examples, "\n\n"))
+}
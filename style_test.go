@@ -0,0 +1,62 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStyleExamples(t *testing.T) {
+	saved := styleEntries
+	defer func() { styleEntries = saved }()
+	styleEntries = parseChangelogEntries(`# Changelog
+
+## [v1.3.0] - 2025-10-01
+
+### 追加
+
+- 再生成される項目
+
+## [v1.2.0] - 2025-09-01
+
+説明文だけの崩れたエントリー
+
+## [v1.1.0] - 2025-08-01
+
+### 修正
+
+- 修正の項目
+
+<!-- changelog-update: tool=1.0.0 model=claude prompt=sha256:0123456789abcdef range=v1.0.0..v1.1.0 -->
+
+## [v1.0.0] - 2025-07-01
+
+### 追加
+
+- 初回リリース
+
+## [v0.9.0] - 2025-06-01
+
+### 追加
+
+- 古い項目
+`)
+
+	want := []string{
+		"## [v1.1.0] - 2025-08-01\n\n### 修正\n\n- 修正の項目",
+		"## [v1.0.0] - 2025-07-01\n\n### 追加\n\n- 初回リリース",
+	}
+	if got := styleExamples("v1.3.0"); !reflect.DeepEqual(got, want) {
+		t.Errorf("styleExamples() = %q, want %q", got, want)
+	}
+
+	section := styleExampleSection("v1.3.0")
+	if !strings.Contains(section, "文体") || !strings.Contains(section, want[0]) {
+		t.Errorf("styleExampleSection() = %q", section)
+	}
+
+	styleEntries = nil
+	if section := styleExampleSection("v1.3.0"); section != "" {
+		t.Errorf("styleExampleSection() without entries = %q, want empty", section)
+	}
+}
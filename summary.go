@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// runSummary is the machine-readable record written by --summary-json
+type runSummary struct {
+	PreviousTag       string   `json:"previous_tag"`
+	NewTag            string   `json:"new_tag"`
+	CatchUpTags       []string `json:"catch_up_tags,omitempty"`
+	CommitCount       int      `json:"commit_count"`
+	FilesChanged      int      `json:"files_changed"`
+	Model             string   `json:"model"`
+	PromptTokens      int      `json:"prompt_tokens"`
+	ResponseTokens    int      `json:"response_tokens"`
+	ChangelogModified bool     `json:"changelog_modified"`
+	Entry             string   `json:"entry"`
+	// Signatures is set when --verify-signatures is used
+	Signatures *signatureReport `json:"signatures,omitempty"`
+	ExitCode   int              `json:"exit_code"`
+}
+
+// writeSummary writes the run summary as indented JSON
+func writeSummary(path string, summary *runSummary) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// countLines counts the non-empty lines in git output
+func countLines(output string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
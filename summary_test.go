@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestCountLines(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"abc123 feat: add\n", 1},
+		{"A\tfile1.go\nM\tfile2.go\n\n", 2},
+	}
+
+	for _, tc := range testCases {
+		if got := countLines(tc.input); got != tc.want {
+			t.Errorf("countLines(%q) = %d, want %d", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	path := t.TempDir() + "/summary.json"
+	summary := &runSummary{
+		PreviousTag:       "v1.0.0",
+		NewTag:            "v1.1.0",
+		CommitCount:       3,
+		FilesChanged:      5,
+		Model:             "claude",
+		ChangelogModified: true,
+		Entry:             "## [v1.1.0] - 2025-09-01",
+	}
+
+	if err := writeSummary(path, summary); err != nil {
+		t.Fatalf("writeSummary() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("summary is not valid JSON: %v", err)
+	}
+	if got["new_tag"] != "v1.1.0" || got["changelog_modified"] != true || got["commit_count"] != float64(3) {
+		t.Errorf("unexpected summary content: %s", data)
+	}
+	if _, ok := got["catch_up_tags"]; ok {
+		t.Errorf("catch_up_tags should be omitted when empty: %s", data)
+	}
+}
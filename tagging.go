@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// tagExists reports whether tag exists in the repository
+func tagExists(tag string) bool {
+	return gitCommand("rev-parse", "--verify", "--quiet", "refs/tags/"+tag).Run() == nil
+}
+
+// defaultCommitMessageTemplate is the default --commit-message-template
+const defaultCommitMessageTemplate = "docs: update changelog for {version}"
+
+// commitMessageTemplate is the message of commits that update the changelog; {version} is replaced by the tag
+var commitMessageTemplate = defaultCommitMessageTemplate
+
+// setCommitMessageTemplate validates and sets the --commit-message-template
+func setCommitMessageTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return &ConfigError{Err: fmt.Errorf("--commit-message-template must not be empty")}
+	}
+	commitMessageTemplate = template
+	return nil
+}
+
+// releaseCommitMessage is the commit message used for the changelog update of tag
+func releaseCommitMessage(tag string) string {
+	return strings.ReplaceAll(commitMessageTemplate, "{version}", tag)
+}
+
+// nextSteps lists the commands that finish a release after the changelog at path was updated
+func nextSteps(path, tag string, packageJSON bool) []string {
+	steps := []string{
+		ui.Sprintf("Review and edit %s if needed", path),
+		"git add " + path,
+	}
+	if packageJSON {
+		steps = append(steps, "git add package.json")
+	}
+	return append(steps,
+		fmt.Sprintf("git commit -m %q", releaseCommitMessage(tag)),
+		"git tag "+tag,
+		"git push && git push --tags")
+}
+
+// tagMessageArgs returns the git tag arguments that annotate a tag with entry; verbatim cleanup
+// keeps the Markdown headings, which git would otherwise strip as comments
+func tagMessageArgs(entry string) []string {
+	return []string{"-a", "--cleanup=verbatim", "-m", strings.TrimSpace(normalizeNewlines(entry)) + "\n"}
+}
+
+// tagRelease creates and/or annotates tag after the changelog update. With create, files are
+// committed and the tag is created on that commit; with annotate, the tag message is the entry.
+// Annotating an existing tag keeps it on the same commit.
+func tagRelease(tag, entry string, files []string, create, annotate bool) error {
+	exists := tagExists(tag)
+	if create && !exists {
+		args := []string{"add", "--"}
+		for _, file := range files {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				return err
+			}
+			args = append(args, abs)
+		}
+		if err := runGit(args...); err != nil {
+			return err
+		}
+		if err := runGit("commit", "-m", releaseCommitMessage(tag)); err != nil {
+			return err
+		}
+
+		tagArgs := []string{"tag"}
+		if annotate {
+			tagArgs = append(tagArgs, tagMessageArgs(entry)...)
+		}
+		if err := runGit(append(tagArgs, tag)...); err != nil {
+			return err
+		}
+		ui.Printf("🏷️  Committed the changelog and created tag %s\n", tag)
+		return nil
+	}
+
+	switch {
+	case !annotate:
+		return fmt.Errorf("tag %s already exists", tag)
+	case !exists:
+		return fmt.Errorf("tag %s does not exist; use --create-tag to create it", tag)
+	}
+	args := append([]string{"tag", "-f"}, tagMessageArgs(entry)...)
+	if err := runGit(append(args, tag, tag+"^{commit}")...); err != nil {
+		return err
+	}
+	ui.Printf("🏷️  Updated the annotation of tag %s\n", tag)
+	return nil
+}
+
+// tagMessage returns the message of an annotated tag without its signature; lightweight and
+// missing tags have none
+func tagMessage(tag string) string {
+	kind, err := gitOutput("cat-file", "-t", "refs/tags/"+tag)
+	if err != nil || strings.TrimSpace(kind) != "tag" {
+		return ""
+	}
+	output, err := gitOutput("tag", "-l", "--format=%(contents:subject)%0a%0a%(contents:body)", tag)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(normalizeNewlines(output))
+}
+
+// tagMessageSection formats the message of an annotated tag for the generation prompt
+func tagMessageSection(message string) string {
+	if message == "" {
+		return ""
+	}
+	return fmt.Sprintf(`
+
+メンテナーがタグのメッセージに書いたリリースの説明（内容をエントリーに反映してください）:
+---
+%s
+---`, message)
+}
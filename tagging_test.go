@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTagMessageArgs(t *testing.T) {
+	got := tagMessageArgs("## [v1.2.0] - 2025-09-01\r\n\r\n### 追加\r\n\r\n- 新機能\r\n\r\n")
+	want := []string{"-a", "--cleanup=verbatim", "-m", "## [v1.2.0] - 2025-09-01\n\n### 追加\n\n- 新機能\n"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tagMessageArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestTagReleaseRequiresCreateForMissingTag(t *testing.T) {
+	err := tagRelease("v0.0.0-no-such-tag", "## [v0.0.0]", nil, false, true)
+	if err == nil || !strings.Contains(err.Error(), "--create-tag") {
+		t.Errorf("tagRelease() error = %v, want a hint to use --create-tag", err)
+	}
+}
+
+func TestTagMessageSection(t *testing.T) {
+	if got := tagMessageSection(tagMessage("v0.0.0-no-such-tag")); got != "" {
+		t.Errorf("tagMessageSection() for a missing tag = %q, want empty", got)
+	}
+	got := tagMessageSection("Faster startup\n\nThe cache is now persisted between runs.")
+	if !strings.Contains(got, "タグのメッセージ") || !strings.Contains(got, "---\nFaster startup\n\nThe cache is now persisted between runs.\n---") {
+		t.Errorf("tagMessageSection() = %q", got)
+	}
+}
+
+func TestReleaseCommitMessageTemplate(t *testing.T) {
+	defer func() { commitMessageTemplate = defaultCommitMessageTemplate }()
+
+	if got := releaseCommitMessage("v1.2.0"); got != "docs: update changelog for v1.2.0" {
+		t.Errorf("releaseCommitMessage() = %q", got)
+	}
+	var configErr *ConfigError
+	if err := setCommitMessageTemplate("  "); !errors.As(err, &configErr) {
+		t.Errorf("setCommitMessageTemplate() with an empty template = %v, want a ConfigError", err)
+	}
+	if err := setCommitMessageTemplate("chore(release): {version} [skip ci]"); err != nil {
+		t.Fatal(err)
+	}
+	if got := releaseCommitMessage("v1.2.0"); got != "chore(release): v1.2.0 [skip ci]" {
+		t.Errorf("releaseCommitMessage() with a template = %q", got)
+	}
+
+	want := []string{
+		"Review and edit docs/CHANGES.md if needed",
+		"git add docs/CHANGES.md",
+		"git add package.json",
+		`git commit -m "chore(release): v1.2.0 [skip ci]"`,
+		"git tag v1.2.0",
+		"git push && git push --tags",
+	}
+	if got := nextSteps("docs/CHANGES.md", "v1.2.0", true); !reflect.DeepEqual(got, want) {
+		t.Errorf("nextSteps() = %q, want %q", got, want)
+	}
+}
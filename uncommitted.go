@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+)
+
+// getUncommittedDiff returns the name-status lines of the uncommitted changes selected by
+// genOpts: staged changes, unstaged working tree changes and untracked files
+func getUncommittedDiff() (string, error) {
+	var lists []string
+	if genOpts.IncludeStaged {
+		staged, err := getStagedDiff()
+		if err != nil {
+			return "", err
+		}
+		lists = append(lists, staged)
+	}
+	if genOpts.IncludeWorkingTree {
+		output, err := gitOutput(nameStatusArgs()...)
+		if err != nil {
+			return "", err
+		}
+		lists = append(lists, aiFilter.filterNameStatus(strings.TrimSpace(output)))
+	}
+	if genOpts.IncludeUntracked {
+		output, err := gitOutput("ls-files", "--others", "--exclude-standard")
+		if err != nil {
+			return "", err
+		}
+		lists = append(lists, aiFilter.filterNameStatus(addedNameStatus(output)))
+	}
+	return moduleFiles(mergeNameStatus(lists...)), nil
+}
+
+// addedNameStatus formats a list of paths as name-status lines for added files
+func addedNameStatus(paths string) string {
+	var result []string
+	for _, line := range strings.Split(strings.TrimSpace(paths), "\n") {
+		if line != "" {
+			result = append(result, "A\t"+line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// mergeNameStatus joins name-status lists, keeping only the first line for each path
+func mergeNameStatus(lists ...string) string {
+	seen := map[string]bool{}
+	var merged []string
+	for _, list := range lists {
+		for _, line := range strings.Split(list, "\n") {
+			if line == "" {
+				continue
+			}
+			fields := strings.Split(line, "\t")
+			path := fields[len(fields)-1]
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			merged = append(merged, line)
+		}
+	}
+	return strings.Join(merged, "\n")
+}
+
+// uncommittedKinds describes the uncommitted changes selected by genOpts for status messages
+func uncommittedKinds() string {
+	var kinds []string
+	if genOpts.IncludeStaged {
+		kinds = append(kinds, "staged")
+	}
+	if genOpts.IncludeWorkingTree {
+		kinds = append(kinds, "working tree")
+	}
+	if genOpts.IncludeUntracked {
+		kinds = append(kinds, "untracked")
+	}
+	return strings.Join(kinds, ", ")
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestAddedNameStatus(t *testing.T) {
+	if got := addedNameStatus("a.go\n\ndir/b.go\n"); got != "A\ta.go\nA\tdir/b.go" {
+		t.Errorf("addedNameStatus() = %q", got)
+	}
+	if got := addedNameStatus(""); got != "" {
+		t.Errorf("addedNameStatus(\"\") = %q", got)
+	}
+}
+
+func TestMergeNameStatus(t *testing.T) {
+	staged := "A\tnew.go\nM\tmain.go"
+	workingTree := "M\tmain.go\nM\tnew.go\nR100\told.go\trenamed.go"
+	untracked := "A\tscratch.txt"
+	want := "A\tnew.go\nM\tmain.go\nR100\told.go\trenamed.go\nA\tscratch.txt"
+	if got := mergeNameStatus(staged, "", workingTree, untracked); got != want {
+		t.Errorf("mergeNameStatus() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestUncommittedKinds(t *testing.T) {
+	saved := genOpts
+	defer func() { genOpts = saved }()
+
+	genOpts.IncludeStaged, genOpts.IncludeWorkingTree, genOpts.IncludeUntracked = false, true, true
+	if got := uncommittedKinds(); got != "working tree, untracked" {
+		t.Errorf("uncommittedKinds() = %q", got)
+	}
+}
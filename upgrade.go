@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// upgradeGuideFile is the name of the upgrade guide written next to the changelog
+const upgradeGuideFile = "UPGRADING.md"
+
+var breakingChangePattern = regexp.MustCompile(`(?m)^\w+(\([^)]*\))?!:|BREAKING[ -]CHANGE`)
+
+// hasBreakingChanges reports whether any commit of the selected module in from..to is marked as a breaking change
+func hasBreakingChanges(from, to string) (bool, error) {
+	revRange := to
+	if from != "" && from != gitRefHEAD {
+		revRange = from + ".." + to
+	}
+	output, err := gitCommand(append([]string{"log", "--format=%s%n%b", revRange}, modulePathspec()...)...).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to read commit messages: %w", err)
+	}
+	return breakingChangePattern.Match(output), nil
+}
+
+// upgradeGuidePath returns the path of the upgrade guide next to the changelog file
+func upgradeGuidePath(changelogFile string) string {
+	return filepath.Join(filepath.Dir(changelogFile), upgradeGuideFile)
+}
+
+// generateUpgradeGuide asks the AI for the user actions required to upgrade to tag
+func generateUpgradeGuide(executor AIExecutor, tag, diff, commits string) (string, error) {
+	heading := changelogHeading.Render(tag, releaseDate())
+	build := func(diff, commits, _ string) string {
+		return fmt.Sprintf(`以下のgitの差分情報とコミットメッセージには破壊的変更が含まれています。利用者が %s にアップグレードする際に必要な対応を説明する、UPGRADING.md のセクションを生成してください。
+
+コミットメッセージ:
+---
+%s
+---
+
+差分情報:
+---
+%s
+---
+
+以下の形式で出力してください（見出しレベル%dから開始）:
+%s
+
+### 必要な対応
+
+- 利用者が行う必要がある具体的な変更（名前が変わったフラグ、変更されたAPI、設定ファイルの変更など）を記載
+
+注意事項：
+- 各セクションヘッダーの後には必ず空行を入れてください
+- 前置きや説明文は一切含めないでください
+- 破壊的変更に関係しない変更は含めないでください
+- 変更前と変更後が分かるように、必要に応じてコード例を含めてください
+- 各項目は日本語で記述してください`, tag, commits, diff, changelogHeading.Level(), heading)
+	}
+
+	return executor.Execute(fitPrompt(build, diff, commits, "", ""))
+}
+
+// upgradeGuideLink is the note linking a changelog entry to the upgrade guide, which is written
+// next to the changelog
+var upgradeGuideLink = fmt.Sprintf("> **破壊的変更を含みます。** アップグレード手順は [%s](%s) を参照してください。", upgradeGuideFile, upgradeGuideFile)
+
+// addUpgradeGuideLink inserts the upgrade guide note directly below the entry heading
+func addUpgradeGuideLink(entry string) string {
+	heading, rest, _ := strings.Cut(entry, "\n")
+	return heading + "\n\n" + upgradeGuideLink + "\n" + rest
+}
+
+// writeUpgradeGuide inserts or replaces the version's section in the upgrade guide. The guide is
+// not a changelog, so the rules of --spec are not applied to it.
+func writeUpgradeGuide(path, guide string) error {
+	lock, err := acquireLock(path)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	content, err := upgradeGuideContent(path, guide)
+	if err != nil {
+		return err
+	}
+	return commitWrites([]pendingWrite{{path: path, content: content}})
+}
+
+// upgradeGuideContent returns the upgrade guide at path with guide inserted or replacing the
+// section of the same version, keeping the encoding and line endings of the file
+func upgradeGuideContent(path, guide string) (string, error) {
+	block := strings.TrimSpace(normalizeNewlines(stripBOM(guide)))
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "# Upgrading\n\n" + block + "\n", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text, encoding := decodeText(raw)
+	lineEnding := detectLineEnding(text)
+	lines := insertEntry(strings.Split(normalizeNewlines(text), "\n"), strings.Split(block, "\n"))
+	return encoding.encode(applyLineEnding(normalizeChangelogSpacing(strings.Join(lines, "\n")), lineEnding)), nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBreakingChangePattern(t *testing.T) {
+	testCases := []struct {
+		log  string
+		want bool
+	}{
+		{"feat!: drop legacy flag\n", true},
+		{"refactor(api)!: rename endpoint\n", true},
+		{"feat: add option\n\nBREAKING CHANGE: --foo was renamed to --bar\n", true},
+		{"fix: handle BREAKING-CHANGE footer\n", true},
+		{"feat: add option\nfix: typo\n", false},
+	}
+
+	for _, tc := range testCases {
+		if got := breakingChangePattern.MatchString(tc.log); got != tc.want {
+			t.Errorf("breakingChangePattern.MatchString(%q) = %v, want %v", tc.log, got, tc.want)
+		}
+	}
+}
+
+func TestAddUpgradeGuideLink(t *testing.T) {
+	entry := "## [v2.0.0] - 2025-09-01\n\n### 変更\n\n- フラグ名を変更"
+	got := addUpgradeGuideLink(entry)
+
+	if !strings.HasPrefix(got, "## [v2.0.0] - 2025-09-01\n\n> ") {
+		t.Errorf("link should follow the heading: %q", got)
+	}
+	if !strings.Contains(got, "](UPGRADING.md)") || !strings.Contains(got, "### 変更") {
+		t.Errorf("unexpected entry: %q", got)
+	}
+}
+
+func TestWriteUpgradeGuide(t *testing.T) {
+	savedSpec := activeSpec
+	defer func() { activeSpec = savedSpec }()
+	activeSpec = changelogSpecs[specKeepAChangelog]
+	path := filepath.Join(t.TempDir(), upgradeGuideFile)
+
+	if err := writeUpgradeGuide(path, "## [v2.0.0] - 2025-09-01\n\n### 必要な対応\n\n- A"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeUpgradeGuide(path, "## [v3.0.0] - 2025-10-01\n\n### 必要な対応\n\n- B"); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(content)
+	if !strings.HasPrefix(text, "# Upgrading\n") {
+		t.Errorf("upgrade guide should start with its own title: %q", text)
+	}
+	if strings.Index(text, "[v3.0.0]") > strings.Index(text, "[v2.0.0]") {
+		t.Errorf("newest section should come first: %q", text)
+	}
+	if strings.Contains(text, unreleasedVersion) || strings.Contains(text, "[v2.0.0]:") {
+		t.Errorf("the rules of the changelog spec should not apply to the upgrade guide: %q", text)
+	}
+	if _, err := os.Stat(path + lockSuffix); !os.IsNotExist(err) {
+		t.Errorf("lock file was left behind: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// metadataCommitPattern finds the commit recorded in a metadata comment
+var metadataCommitPattern = regexp.MustCompile(`\bcommit=([0-9a-f]{7,64})\b`)
+
+// hasVersionEntry reports whether changelog content contains a heading for version
+func hasVersionEntry(content, version string) bool {
+	for _, line := range strings.Split(changelogHeading.ATX(normalizeNewlines(content)), "\n") {
+		if v, ok := changelogHeading.Version(line); ok && sameVersion(v, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// entryCommit returns the commit recorded in the metadata comment of version's entry, or "" when
+// the entry has none
+func entryCommit(content, version string) string {
+	inEntry := false
+	for _, line := range strings.Split(changelogHeading.ATX(normalizeNewlines(content)), "\n") {
+		if v, ok := changelogHeading.Version(line); ok {
+			inEntry = sameVersion(v, version)
+			continue
+		}
+		if inEntry && strings.HasPrefix(strings.TrimSpace(line), metadataCommentPrefix) {
+			if m := metadataCommitPattern.FindStringSubmatch(line); m != nil {
+				return m[1]
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// taggedAfter reports whether tag points at commit, or at a descendant of it whose later commits
+// all update changelog, such as the commit that adds the entry
+func taggedAfter(commit, tag, changelog string) bool {
+	tagRef := tagRefPrefix + tag
+	if gitCommand("merge-base", "--is-ancestor", commit, tagRef).Run() != nil {
+		return false
+	}
+	all, err := gitOutput("rev-list", "--count", commit+".."+tagRef)
+	if err != nil {
+		return false
+	}
+	updates, err := gitOutput("rev-list", "--count", commit+".."+tagRef, "--", changelog)
+	return err == nil && strings.TrimSpace(all) == strings.TrimSpace(updates)
+}
+
+// entryUpToDate reports whether changelogFile already has an entry for tag that was written for the
+// tag's current commit: the tag exists, and the changelog both in the working tree and at the tagged
+// commit contains the entry. When the entry records the commit it was generated for (--metadata),
+// the tag must also point at that commit or at the commits that only added the entry after it, so
+// a tag moved to a later commit is not up to date.
+func entryUpToDate(changelogFile, tag string) bool {
+	if !tagExists(tag) {
+		return false
+	}
+	content, err := readTextFile(changelogFile)
+	if err != nil || !hasVersionEntry(content, tag) {
+		return false
+	}
+
+	root, err := filepath.Abs(gitDir)
+	if err != nil {
+		return false
+	}
+	file, err := filepath.Abs(changelogFile)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	// A ./ path is resolved against gitDir rather than the repository root
+	tagged, _ := decodeText([]byte(gitShowFile("refs/tags/"+tag, "./"+filepath.ToSlash(rel))))
+	if !hasVersionEntry(tagged, tag) {
+		return false
+	}
+	if commit := entryCommit(content, tag); commit != "" {
+		return taggedAfter(commit, tag, filepath.ToSlash(rel))
+	}
+	return true
+}
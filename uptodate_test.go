@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHasVersionEntry(t *testing.T) {
+	content := "# Changelog\r\n\r\n## [v1.1.0] - 2025-09-01\r\n\r\n- 新機能\r\n\r\n## 1.0.0 (2025-08-01)\r\n"
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"v1.1.0", true},
+		{"1.1.0", true},
+		{"v1.0.0", true},
+		{"v1.2.0", false},
+	}
+	for _, tt := range tests {
+		if got := hasVersionEntry(content, tt.version); got != tt.want {
+			t.Errorf("hasVersionEntry(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestEntryUpToDateRequiresExistingTag(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	if err := os.WriteFile(file, []byte("# Changelog\n\n## [v0.0.0-no-such-tag] - 2025-09-01\n\n- 項目\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if entryUpToDate(file, "v0.0.0-no-such-tag") {
+		t.Error("entryUpToDate() = true for a tag that does not exist")
+	}
+}
+
+func TestEntryUpToDateChecksRecordedCommit(t *testing.T) {
+	newTestRepo(t)
+	head := testCommit(t, "feature", map[string]string{"feature.go": "package main\n"})
+	meta := generationMetadata{ToolVersion: "1.0.0", Model: "mock", PromptHash: "0123", PromptVersion: 1, Range: "v0.9.0..v1.0.0", Commit: head}
+	testCommit(t, "release", map[string]string{"CHANGELOG.md": "# Changelog\n\n## [v1.0.0] - 2025-09-01\n\n" + meta.comment() + "\n\n- 項目\n"})
+	testGit(t, "tag", "v1.0.0")
+	file := filepath.Join(gitDir, "CHANGELOG.md")
+	if !entryUpToDate(file, "v1.0.0") {
+		t.Error("entryUpToDate() = false for a tag on the release commit after the recorded commit")
+	}
+
+	testCommit(t, "feature", map[string]string{"other.go": "package main\n"})
+	testGit(t, "tag", "-f", "v1.0.0")
+	if entryUpToDate(file, "v1.0.0") {
+		t.Error("entryUpToDate() = true for a tag moved past the recorded commit")
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// verificationPassed is the reply the verification prompt asks for when no problems are found
+const verificationPassed = "問題は見つかりませんでした。"
+
+// verifyEntry asks the AI to review a generated entry against the diff and commits, returning its critique
+func verifyEntry(executor AIExecutor, entry, diff, commits, stagedDiff string) (string, error) {
+	build := func(diff, commits, stagedDiff string) string {
+		prompt := fmt.Sprintf(`以下のCHANGELOGエントリーは、その下のgitの差分情報とコミットメッセージから自動生成されたものです。エントリーが変更内容を正しく反映しているかレビューしてください。
+
+生成されたエントリー:
+---
+%s
+---
+
+コミットメッセージ:
+---
+%s
+---
+
+差分情報:
+---
+%s
+---`, entry, commits, diff)
+		if stagedDiff != "" {
+			prompt += fmt.Sprintf(`
+
+ステージングされた変更:
+---
+%s
+---`, stagedDiff)
+		}
+		return prompt + `
+
+以下の観点で確認し、問題点を箇条書きで出力してください:
+- 差分やコミットメッセージに根拠のない項目（事実と異なる記述）
+- エントリーに含まれていない重要な変更
+- カテゴリ（追加/変更/非推奨/削除/修正/セキュリティ）の分類誤り
+
+注意事項：
+- 各項目の先頭に「[根拠なし]」「[漏れ]」「[分類]」のいずれかを付けてください
+- 前置きや説明文は一切含めないでください
+- 問題がない場合は「` + verificationPassed + `」とだけ出力してください
+- 各項目は日本語で記述してください`
+	}
+
+	critique, err := executor.Execute(fitPrompt(build, diff, commits, stagedDiff, ""))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(critique), nil
+}
+
+// verificationFailed reports whether a critique from verifyEntry flags any problems
+func verificationFailed(critique string) bool {
+	return critique != "" && !strings.Contains(critique, verificationPassed)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifyEntry(t *testing.T) {
+	mock := &MockExecutor{response: "- [漏れ] --verify フラグの追加が記載されていません\n"}
+
+	critique, err := verifyEntry(mock, "## [v1.0.0]\n\n### 追加\n\n- 何か", "M\tmain.go", "feat: add --verify", "A\tverify.go")
+	if err != nil {
+		t.Fatalf("verifyEntry() error = %v", err)
+	}
+	if critique != "- [漏れ] --verify フラグの追加が記載されていません" {
+		t.Errorf("verifyEntry() = %q", critique)
+	}
+
+	prompt := mock.prompts[0]
+	for _, want := range []string{"## [v1.0.0]", "feat: add --verify", "M\tmain.go", "A\tverify.go", verificationPassed} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt does not contain %q", want)
+		}
+	}
+}
+
+func TestVerificationFailed(t *testing.T) {
+	if verificationFailed(verificationPassed) {
+		t.Error("a passing verification should not be reported as failed")
+	}
+	if !verificationFailed("- [根拠なし] 存在しない機能") {
+		t.Error("a critique with findings should be reported as failed")
+	}
+}
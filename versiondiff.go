@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func diffCommand(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: changelog-update diff [flags] <from-version> [<to-version>]\n\n")
+		fmt.Fprintf(os.Stderr, "Prints every entry newer than <from-version> up to <to-version> (default: the latest release).\n\n")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return fmt.Errorf("expected one or two versions")
+	}
+
+	entries, err := readChangelogEntries(repoPath(*changelogFile))
+	if err != nil {
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+
+	to := ""
+	if fs.NArg() == 2 {
+		to = fs.Arg(1)
+	}
+	between, err := entriesBetween(entries, fs.Arg(0), to)
+	if err != nil {
+		return err
+	}
+	if len(between) == 0 {
+		ui.Printf("✅ No entries after %s\n", fs.Arg(0))
+		return nil
+	}
+
+	parts := make([]string, len(between))
+	for i, entry := range between {
+		parts[i] = entry.Markdown()
+	}
+	fmt.Println(strings.Join(parts, "\n\n"))
+	return nil
+}
+
+// entriesBetween returns the entries newer than from up to and including to, newest first.
+// An empty to means the newest released entry; the versions may be given in either order.
+func entriesBetween(entries []changelogEntry, from, to string) ([]changelogEntry, error) {
+	index := func(version string) (int, error) {
+		for i, entry := range entries {
+			if sameVersion(entry.Version, version) {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("version %s not found in changelog", version)
+	}
+
+	fromIdx, err := index(from)
+	if err != nil {
+		return nil, err
+	}
+	toIdx := 0
+	for toIdx < fromIdx && strings.EqualFold(entries[toIdx].Version, "Unreleased") {
+		toIdx++
+	}
+	if to != "" {
+		if toIdx, err = index(to); err != nil {
+			return nil, err
+		}
+	}
+	// Entries are listed newest first, so the older version has the larger index
+	if toIdx > fromIdx {
+		fromIdx, toIdx = toIdx, fromIdx
+	}
+	return entries[toIdx:fromIdx], nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEntriesBetween(t *testing.T) {
+	entries := parseChangelogEntries(`# Changelog
+
+## [Unreleased]
+
+## [v1.3.0] - 2025-09-01
+
+- c
+
+## [v1.2.0] - 2025-08-01
+
+- b
+
+## [1.1.0] - 2025-07-01
+
+- a
+
+## [v1.0.0] - 2025-06-01
+`)
+
+	versions := func(entries []changelogEntry) []string {
+		var vs []string
+		for _, e := range entries {
+			vs = append(vs, e.Version)
+		}
+		return vs
+	}
+
+	tests := []struct {
+		from, to string
+		want     []string
+		wantErr  bool
+	}{
+		{from: "v1.0.0", to: "v1.3.0", want: []string{"v1.3.0", "v1.2.0", "1.1.0"}},
+		{from: "v1.3.0", to: "v1.0.0", want: []string{"v1.3.0", "v1.2.0", "1.1.0"}},
+		{from: "v1.1.0", to: "v1.2.0", want: []string{"v1.2.0"}},
+		{from: "v1.2.0", want: []string{"v1.3.0"}},
+		{from: "v1.2.0", to: "Unreleased", want: []string{"Unreleased", "v1.3.0"}},
+		{from: "v1.3.0", to: "v1.3.0", want: nil},
+		{from: "v0.9.0", wantErr: true},
+		{from: "v1.0.0", to: "v2.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := entriesBetween(entries, tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("entriesBetween(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if gotVersions := versions(got); !slices.Equal(gotVersions, tt.want) {
+			t.Errorf("entriesBetween(%q, %q) = %v, want %v", tt.from, tt.to, gotVersions, tt.want)
+		}
+	}
+}
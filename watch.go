@@ -0,0 +1,151 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// tagWatcher generates entries for tags that appear after it started
+type tagWatcher struct {
+	executor      AIExecutor
+	changelogFile string
+	openPR        bool
+	known         map[string]bool
+}
+
+func watchCommand(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := fs.Duration("interval", 5*time.Minute, "How often to fetch and check for new tags")
+	changelogFile := fs.String("changelog", "CHANGELOG.md", "Path to CHANGELOG.md file")
+	model := fs.String("model", "claude", "AI model to use: claude, or mock[:file.md] for a canned entry without AI access")
+	openPR := fs.Bool("open-pr", false, "Commit each new entry on a branch and open a pull request instead of updating the working tree")
+	configFile := fs.String("config", "", "Path to the configuration file (default: "+defaultConfigFile+")")
+	fs.StringVar(&gitRemote, "remote", gitRemote, "Remote to fetch tags from and push pull request branches to")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	cfg, err := loadConfig(configPath(*configFile), *configFile != "")
+	if err != nil {
+		return err
+	}
+	aiFilter = newPathFilter(cfg.AIAllow, cfg.AIDeny)
+	outputTargets = cfg.Outputs
+	if changelogHeading, err = newHeadingFormat(cfg.HeadingFormat, cfg.DateFormat); err != nil {
+		return err
+	}
+
+	executor, err := newExecutor(*model)
+	if err != nil {
+		return err
+	}
+
+	if err := pullTags(); err != nil {
+		ui.Printf("⚠️  Warning: Failed to pull tags: %v\n", err)
+	}
+	tags, err := getAllTags()
+	if err != nil {
+		return fmt.Errorf("failed to get all tags: %w", err)
+	}
+
+	w := &tagWatcher{executor: executor, changelogFile: *changelogFile, openPR: *openPR, known: map[string]bool{}}
+	for _, tag := range tags {
+		w.known[tag] = true
+	}
+
+	ui.Printf("👀 Watching for new tags every %s (%d existing tag(s) ignored)...\n", *interval, len(tags))
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		w.poll()
+	}
+	return nil
+}
+
+// poll fetches tags and generates an entry for every tag not seen before
+func (w *tagWatcher) poll() {
+	if err := pullTags(); err != nil {
+		ui.Printf("⚠️  Warning: Failed to pull tags: %v\n", err)
+	}
+	allTags, err := getAllTags()
+	if err != nil {
+		ui.Printf("❌ Error: Failed to get all tags: %v\n", err)
+		return
+	}
+
+	for _, tag := range newTags(w.known, allTags) {
+		w.known[tag] = true
+		if err := w.process(allTags, tag); err != nil {
+			ui.Printf("❌ Error processing %s: %v\n", tag, err)
+		}
+	}
+}
+
+// newTags returns the tags in allTags that are not in known, keeping their order
+func newTags(known map[string]bool, allTags []string) []string {
+	var tags []string
+	for _, tag := range allTags {
+		if !known[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// process generates the entry for tag and either writes it or opens a pull request
+func (w *tagWatcher) process(allTags []string, tag string) error {
+	ui.Printf("🔧 New tag %s detected, generating entry...\n", tag)
+	entry, err := generateCatchUpEntry(w.executor, allTags, tag)
+	if err != nil {
+		return err
+	}
+
+	if !w.openPR {
+		if _, err := writeChangelogOutputs(repoPath(w.changelogFile), entry); err != nil {
+			return fmt.Errorf("update failed: %w", err)
+		}
+		ui.Printf("✅ Added %s to %s\n", tag, w.changelogFile)
+		return nil
+	}
+
+	output, err := gitCommand("rev-parse", "--abbrev-ref", gitRefHEAD).Output()
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+	branch := strings.TrimSpace(string(output))
+	ev, err := tagEventFromWebURL(remoteWebURL(gitRemote), tag, branch)
+	if err != nil {
+		return err
+	}
+
+	publishErr := publishEntry(ev, w.changelogFile, entry)
+	if err := runGit("checkout", branch); err != nil && publishErr == nil {
+		return err
+	}
+	return publishErr
+}
+
+// tagEventFromWebURL describes a tag of the repository hosted at webURL, for opening pull requests against branch
+func tagEventFromWebURL(webURL, tag, branch string) (tagEvent, error) {
+	u, err := url.Parse(webURL)
+	if err != nil || u.Host == "" {
+		return tagEvent{}, fmt.Errorf("cannot determine the hosting service from the %s remote", gitRemote)
+	}
+	provider := providerGitHub
+	if strings.Contains(u.Host, "gitlab") {
+		provider = providerGitLab
+	}
+	return tagEvent{
+		Provider:      provider,
+		Tag:           tag,
+		CloneURL:      webURL + ".git",
+		FullName:      strings.Trim(u.Path, "/"),
+		DefaultBranch: branch,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewTags(t *testing.T) {
+	known := map[string]bool{"v1.0.0": true, "v1.1.0": true}
+	got := newTags(known, []string{"v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0"})
+	if !reflect.DeepEqual(got, []string{"v1.2.0", "v1.3.0"}) {
+		t.Errorf("newTags() = %v", got)
+	}
+	if got := newTags(known, []string{"v1.0.0"}); got != nil {
+		t.Errorf("newTags() = %v, want nil", got)
+	}
+}
+
+func TestTagEventFromWebURL(t *testing.T) {
+	ev, err := tagEventFromWebURL("https://gitlab.example.com/group/sub/proj", "v1.2.0", "main")
+	if err != nil {
+		t.Fatalf("tagEventFromWebURL() error = %v", err)
+	}
+	want := tagEvent{
+		Provider:      providerGitLab,
+		Tag:           "v1.2.0",
+		CloneURL:      "https://gitlab.example.com/group/sub/proj.git",
+		FullName:      "group/sub/proj",
+		DefaultBranch: "main",
+	}
+	if ev != want {
+		t.Errorf("tagEventFromWebURL() = %+v, want %+v", ev, want)
+	}
+
+	if ev, _ := tagEventFromWebURL("https://github.com/org/repo", "v1", "main"); ev.Provider != providerGitHub || ev.FullName != "org/repo" {
+		t.Errorf("tagEventFromWebURL() = %+v", ev)
+	}
+	if _, err := tagEventFromWebURL("", "v1", "main"); err == nil {
+		t.Error("tagEventFromWebURL() should fail without a remote URL")
+	}
+}